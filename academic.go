@@ -0,0 +1,125 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// ScholarlyDomains are the sites an academic search boosts: preprint
+// servers, publishers and scholarly indexes. A domain also matches its
+// subdomains.
+var ScholarlyDomains = []string{
+	"arxiv.org", "doi.org", "semanticscholar.org", "scholar.archive.org",
+	"pubmed.ncbi.nlm.nih.gov", "ncbi.nlm.nih.gov", "biorxiv.org", "medrxiv.org",
+	"ssrn.com", "jstor.org", "nature.com", "science.org", "springer.com",
+	"sciencedirect.com", "wiley.com", "acm.org", "ieee.org", "plos.org",
+	"researchgate.net", "openreview.net", "aclanthology.org",
+}
+
+// AcademicGoggle is the Goggle definition an academic search re-ranks
+// results with, boosting ScholarlyDomains
+var AcademicGoggle = boostGoggle("Academic", "Boosts scholarly sources", ScholarlyDomains)
+
+// boostGoggle returns a Goggle definition boosting domains
+func boostGoggle(name, description string, domains []string) string {
+	var goggle strings.Builder
+	goggle.WriteString("! name: " + name + "\n! description: " + description + "\n")
+	for _, domain := range domains {
+		goggle.WriteString("$boost=3,site=" + domain + "\n")
+	}
+	return goggle.String()
+}
+
+// AcademicResult is a web result with the identifiers of the paper it
+// links to, for citation managers
+type AcademicResult struct {
+	Title       string
+	URL         string
+	Description string
+	Hostname    string
+	Age         string
+
+	// DOI is the Digital Object Identifier found in the URL, e.g. "10.1145/3368089.3409700"
+	DOI string
+
+	// ArXivID is the arXiv identifier found in the URL, e.g. "2401.01234v2" or "hep-th/9901001"
+	ArXivID string
+
+	// Scholarly reports whether the result is from one of ScholarlyDomains
+	Scholarly bool
+}
+
+// doiPattern matches a DOI: the "10." directory indicator, a registrant
+// code and a suffix that may contain slashes
+var doiPattern = regexp.MustCompile(`(?i)\b10\.\d{4,9}/[^\s"<>?#&]+`)
+
+// arXivPattern matches the identifier of arXiv abstract and PDF URLs in the
+// current (2401.01234v2) and old (hep-th/9901001) schemes
+var arXivPattern = regexp.MustCompile(`^/(?:abs|pdf|html)/(\d{4}\.\d{4,5}(?:v\d+)?|[a-z-]+(?:\.[A-Z]{2})?/\d{7}(?:v\d+)?)`)
+
+// WebSearchAcademic performs a web search that ranks scholarly sources
+// higher (see AcademicGoggle) and returns the web results with the DOIs and
+// arXiv IDs found in their URLs. Results from other sites are kept, ranked
+// lower.
+func (c *Client) WebSearchAcademic(ctx context.Context, query string) ([]AcademicResult, error) {
+	response, err := c.WebSearch(ctx, query, &WebSearchParams{
+		ResultFilter: "web",
+		Goggles:      AcademicGoggle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AcademicResult
+	for _, result := range response.GetWebResults() {
+		results = append(results, parseAcademicResult(result))
+	}
+	return results, nil
+}
+
+// parseAcademicResult extracts the paper identifiers of a web result
+func parseAcademicResult(result SearchResult) AcademicResult {
+	academic := AcademicResult{
+		Title:       result.Title,
+		URL:         result.URL,
+		Description: result.Description,
+		Age:         result.Age,
+	}
+
+	u, err := url.Parse(result.URL)
+	if err != nil {
+		return academic
+	}
+	academic.Hostname = result.hostname()
+	academic.Scholarly = hostutil.MatchesAnyDomain(academic.Hostname, ScholarlyDomains)
+
+	if hostutil.MatchesAnyDomain(academic.Hostname, []string{"arxiv.org"}) {
+		if match := arXivPattern.FindStringSubmatch(u.Path); match != nil {
+			academic.ArXivID = match[1]
+		}
+	}
+	academic.DOI = extractDOI(u)
+	return academic
+}
+
+// extractDOI returns the DOI in the path or query of u, if any
+func extractDOI(u *url.URL) string {
+	for _, part := range []string{u.Path, u.RawQuery} {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			unescaped = part
+		}
+		if doi := doiPattern.FindString(unescaped); doi != "" {
+			doi = strings.TrimRight(doi, ".,;/")
+			if strings.HasSuffix(strings.ToLower(doi), ".pdf") {
+				doi = doi[:len(doi)-len(".pdf")]
+			}
+			return doi
+		}
+	}
+	return ""
+}
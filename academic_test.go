@@ -0,0 +1,85 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// academicResponse has results from arXiv, a publisher and a blog
+const academicResponse = `{
+	"type": "search",
+	"web": {"type": "search", "results": [
+		{"title": "Attention Is All You Need", "url": "https://arxiv.org/abs/1706.03762v7", "meta_url": {"hostname": "arxiv.org"}},
+		{"title": "A paper", "url": "https://dl.acm.org/doi/10.1145/3368089.3409700", "age": "2020"},
+		{"title": "Transformers explained", "url": "https://example.com/blog/transformers"}
+	]}
+}`
+
+// TestWebSearchAcademic tests the academic search
+func TestWebSearchAcademic(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(academicResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	results, err := client.WebSearchAcademic(context.Background(), "transformers")
+	require.NoError(t, err)
+
+	assert.Equal(t, "transformers", query.Get("q"))
+	assert.Equal(t, "web", query.Get("result_filter"))
+	assert.Equal(t, AcademicGoggle, query.Get("goggles"))
+	assert.Contains(t, AcademicGoggle, "$boost=3,site=arxiv.org\n")
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "1706.03762v7", results[0].ArXivID)
+	assert.True(t, results[0].Scholarly)
+	assert.Equal(t, "10.1145/3368089.3409700", results[1].DOI)
+	assert.Equal(t, "2020", results[1].Age)
+	assert.True(t, results[1].Scholarly)
+	assert.False(t, results[2].Scholarly)
+	assert.Empty(t, results[2].DOI)
+}
+
+// TestParseAcademicResult tests extracting DOIs and arXiv IDs from URLs
+func TestParseAcademicResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		doi     string
+		arXivID string
+	}{
+		{"arxiv abstract", "https://arxiv.org/abs/2401.01234", "", "2401.01234"},
+		{"arxiv pdf", "https://arxiv.org/pdf/2401.01234v2.pdf", "", "2401.01234v2"},
+		{"arxiv old scheme", "https://arxiv.org/abs/hep-th/9901001", "", "hep-th/9901001"},
+		{"arxiv listing", "https://arxiv.org/list/cs.CL/recent", "", ""},
+		{"doi resolver", "https://doi.org/10.1038/nature14539", "10.1038/nature14539", ""},
+		{"escaped doi", "https://example.org/doi/10.1002%2Fanie.201915678", "10.1002/anie.201915678", ""},
+		{"doi pdf", "https://link.springer.com/content/pdf/10.1007/s10994-021-05946-3.pdf", "10.1007/s10994-021-05946-3", ""},
+		{"doi in query", "https://example.org/lookup?doi=10.1000/xyz123&format=json", "10.1000/xyz123", ""},
+		{"no identifiers", "https://example.com/10.5/not-a-doi", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			academic := parseAcademicResult(SearchResult{URL: tt.url})
+			assert.Equal(t, tt.doi, academic.DOI)
+			assert.Equal(t, tt.arXivID, academic.ArXivID)
+		})
+	}
+
+	academic := parseAcademicResult(SearchResult{Title: "broken", URL: "://"})
+	assert.Equal(t, "broken", academic.Title)
+	assert.Empty(t, academic.Hostname)
+}
@@ -0,0 +1,168 @@
+// Package answer extracts candidate direct answers to question-style queries
+// from Brave Search web search responses.
+//
+// It is optional and purely heuristic: answers are taken from FAQ results,
+// the infobox and the top result snippets, and ranked by how well they match
+// the question. Applications should present them as suggestions with their
+// sources, not as facts.
+package answer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// Answer is a candidate direct answer
+type Answer struct {
+	// Text is the answer text, without markup
+	Text string
+
+	// Source is the URL the answer was taken from, if known
+	Source string
+
+	// Confidence ranges from 0 to 1
+	Confidence float64
+}
+
+// maxSnippetAnswers is the number of top web results considered for answers
+const maxSnippetAnswers = 3
+
+// Base confidences by answer origin, scaled by how well the answer matches the question
+const (
+	faqConfidence     = 0.9
+	infoboxConfidence = 0.7
+	snippetConfidence = 0.5
+)
+
+// questionWords start question-style queries
+var questionWords = map[string]bool{
+	"what": true, "who": true, "whom": true, "whose": true, "when": true,
+	"where": true, "why": true, "how": true, "which": true,
+	"is": true, "are": true, "was": true, "were": true, "can": true,
+	"could": true, "do": true, "does": true, "did": true, "should": true,
+	"will": true, "would": true, "has": true, "have": true,
+}
+
+// stopWords are ignored when matching answers to questions
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "in": true, "on": true,
+	"to": true, "for": true, "and": true, "or": true, "it": true, "its": true,
+	"by": true, "with": true, "at": true, "from": true, "as": true, "be": true,
+}
+
+// sentenceEnd matches the end of a sentence
+var sentenceEnd = regexp.MustCompile(`[.!?](\s|$)`)
+
+// IsQuestion reports whether query looks like a question: it ends with a
+// question mark or starts with a question word such as "what" or "how"
+func IsQuestion(query string) bool {
+	query = strings.TrimSpace(query)
+	if strings.HasSuffix(query, "?") || strings.HasSuffix(query, "？") {
+		return true
+	}
+	words := strings.Fields(strings.ToLower(query))
+	return len(words) > 1 && questionWords[words[0]]
+}
+
+// Extract returns candidate answers to query from response, best first. It
+// returns nil for queries that are not questions.
+func Extract(query string, response *bravesearch.WebSearchResponse) []Answer {
+	if response == nil || !IsQuestion(query) {
+		return nil
+	}
+
+	keywords := keywordSet(query)
+	var answers []Answer
+	add := func(text, source string, base float64, matchText string) {
+		text = textutil.PlainText(text)
+		if text == "" {
+			return
+		}
+		answers = append(answers, Answer{
+			Text:       text,
+			Source:     source,
+			Confidence: base * (0.5 + 0.5*overlap(keywords, keywordSet(matchText))),
+		})
+	}
+
+	if response.FAQ != nil {
+		for _, qa := range response.FAQ.Results {
+			add(qa.Answer, qa.URL, faqConfidence, qa.Question)
+		}
+	}
+
+	if response.Infobox != nil {
+		for _, infobox := range response.Infobox.Results {
+			description := firstSentence(textutil.PlainText(textutil.FirstNonEmpty(infobox.LongDesc, infobox.Description)))
+			add(description, textutil.FirstNonEmpty(infobox.URL, infobox.WebsiteURL), infoboxConfidence, infobox.Title+" "+description)
+		}
+
+		// Untyped infobox data
+		if data, ok := response.Infobox.Data.(map[string]any); ok {
+			longDesc, _ := data["long_desc"].(string)
+			description, _ := data["description"].(string)
+			source, _ := data["url"].(string)
+			title, _ := data["title"].(string)
+			description = firstSentence(textutil.PlainText(textutil.FirstNonEmpty(longDesc, description)))
+			add(description, source, infoboxConfidence, title+" "+description)
+		}
+	}
+
+	if response.Web != nil {
+		for i, result := range response.Web.Results {
+			if i == maxSnippetAnswers {
+				break
+			}
+			sentence := firstSentence(textutil.PlainText(result.Description))
+			// Lower ranked results are less likely to answer the question
+			add(sentence, result.URL, snippetConfidence/float64(i+1), result.Title+" "+sentence)
+		}
+	}
+
+	sort.SliceStable(answers, func(i, j int) bool {
+		return answers[i].Confidence > answers[j].Confidence
+	})
+	return answers
+}
+
+// keywordSet returns the lowercased words of text, without stop and question words
+func keywordSet(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(textutil.PlainText(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		if !stopWords[word] && !questionWords[word] {
+			set[word] = true
+		}
+	}
+	return set
+}
+
+// overlap returns the fraction of keywords found in words
+func overlap(keywords, words map[string]bool) float64 {
+	if len(keywords) == 0 {
+		return 0
+	}
+	found := 0
+	for keyword := range keywords {
+		if words[keyword] {
+			found++
+		}
+	}
+	return float64(found) / float64(len(keywords))
+}
+
+// firstSentence returns the first sentence of text
+func firstSentence(text string) string {
+	if loc := sentenceEnd.FindStringIndex(text); loc != nil {
+		return strings.TrimSpace(text[:loc[0]+1])
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,80 @@
+package answer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+)
+
+// TestIsQuestion tests detecting question-style queries
+func TestIsQuestion(t *testing.T) {
+	assert.True(t, IsQuestion("how tall is mount fuji"))
+	assert.True(t, IsQuestion("Who invented Go?"))
+	assert.True(t, IsQuestion("golang generics?"))
+	assert.True(t, IsQuestion("富士山の高さは？"))
+	assert.False(t, IsQuestion("golang generics"))
+	assert.False(t, IsQuestion("how"))
+	assert.False(t, IsQuestion(""))
+}
+
+// TestExtract tests extracting and ranking answers
+func TestExtract(t *testing.T) {
+	response := &bravesearch.WebSearchResponse{
+		FAQ: &bravesearch.FAQ{Results: []bravesearch.QA{
+			{Question: "How tall is Mount Fuji?", Answer: "Mount Fuji is <strong>3,776</strong> meters tall.", URL: "https://faq.example/fuji"},
+			{Question: "Where is Lake Kawaguchi?", Answer: "It is at the foot of Mount Fuji.", URL: "https://faq.example/lake"},
+		}},
+		Infobox: &bravesearch.GraphInfobox{Data: map[string]any{
+			"title":       "Mount Fuji",
+			"description": "Mount Fuji is the highest mountain in Japan, at 3,776 m. It is an active volcano.",
+			"url":         "https://en.wikipedia.org/wiki/Mount_Fuji",
+		}},
+		Web: &bravesearch.Search{Results: []bravesearch.SearchResult{
+			{URL: "https://a.example/", Title: "Mount Fuji facts", Description: "Mount Fuji stands 3,776 m tall. It last erupted in 1707."},
+			{URL: "https://b.example/", Title: "Tokyo travel"},
+		}},
+	}
+
+	answers := Extract("how tall is mount fuji", response)
+	require.Len(t, answers, 4)
+
+	assert.Equal(t, "Mount Fuji is 3,776 meters tall.", answers[0].Text)
+	assert.Equal(t, "https://faq.example/fuji", answers[0].Source)
+	assert.InDelta(t, 0.9, answers[0].Confidence, 1e-9)
+
+	assert.Equal(t, "Mount Fuji is the highest mountain in Japan, at 3,776 m.", answers[1].Text)
+	assert.Equal(t, "https://en.wikipedia.org/wiki/Mount_Fuji", answers[1].Source)
+
+	for i := 1; i < len(answers); i++ {
+		assert.GreaterOrEqual(t, answers[i-1].Confidence, answers[i].Confidence)
+	}
+}
+
+// TestExtractNonQuestion tests that only questions are answered
+func TestExtractNonQuestion(t *testing.T) {
+	response := &bravesearch.WebSearchResponse{
+		Web: &bravesearch.Search{Results: []bravesearch.SearchResult{{Description: "Go is a language."}}},
+	}
+	assert.Nil(t, Extract("golang", response))
+	assert.Nil(t, Extract("what is go", nil))
+	assert.Len(t, Extract("what is go", response), 1)
+}
+
+// TestExtractTypedInfobox tests answers from typed infobox results
+func TestExtractTypedInfobox(t *testing.T) {
+	response := &bravesearch.WebSearchResponse{
+		Infobox: &bravesearch.GraphInfobox{Results: []bravesearch.InfoboxResult{{
+			Title:    "Go",
+			URL:      "https://en.wikipedia.org/wiki/Go_(programming_language)",
+			LongDesc: "Go is a statically typed, compiled programming language designed at Google. It is syntactically similar to C.",
+		}}},
+	}
+
+	answers := Extract("what is go", response)
+	require.Len(t, answers, 1)
+	assert.Equal(t, "Go is a statically typed, compiled programming language designed at Google.", answers[0].Text)
+	assert.Equal(t, "https://en.wikipedia.org/wiki/Go_(programming_language)", answers[0].Source)
+}
@@ -0,0 +1,119 @@
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// AuditRecord records a single API request for compliance auditing
+type AuditRecord struct {
+	// Time is when the request started
+	Time time.Time `json:"time"`
+
+	// Actor is who the search was made for, as set with ContextWithActor
+	Actor string `json:"actor,omitempty"`
+
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+
+	// Query is the query text as sent; it is empty in no-retention mode
+	Query string `json:"query,omitempty"`
+
+	// Params are the other request parameters, with all their values
+	Params url.Values `json:"params,omitempty"`
+
+	// Status is the HTTP status the request ended with; 0 if no response was received
+	Status int `json:"status"`
+
+	// Error describes the failure, if any
+	Error string `json:"error,omitempty"`
+
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Auditor receives an AuditRecord for every API request. It is called
+// synchronously after the request completes and must be safe for concurrent use.
+type Auditor interface {
+	Audit(ctx context.Context, record AuditRecord) error
+}
+
+// AuditorFunc is an adapter to allow the use of ordinary functions as Auditors
+type AuditorFunc func(ctx context.Context, record AuditRecord) error
+
+// Audit calls f(ctx, record)
+func (f AuditorFunc) Audit(ctx context.Context, record AuditRecord) error {
+	return f(ctx, record)
+}
+
+// JSONAuditor writes audit records to an io.Writer as JSON lines
+type JSONAuditor struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONAuditor creates an Auditor writing JSON lines to w
+func NewJSONAuditor(w io.Writer) *JSONAuditor {
+	return &JSONAuditor{encoder: json.NewEncoder(w)}
+}
+
+// Audit implements Auditor
+func (a *JSONAuditor) Audit(ctx context.Context, record AuditRecord) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.encoder.Encode(record)
+}
+
+// actorKey is the context key for the audit actor
+type actorKey struct{}
+
+// ContextWithActor returns a copy of ctx carrying the identity of who a
+// search is made for (e.g. a user ID), recorded in audit records
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the audit actor stored in ctx, if any
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok && actor != ""
+}
+
+// auditRequest records the request with the configured Auditor, if any.
+// Failures are reported as warnings.
+func (c *Client) auditRequest(ctx context.Context, method, requestURL string, start time.Time, err error) {
+	if c.config.Auditor == nil {
+		return
+	}
+
+	record := AuditRecord{
+		Time:     start,
+		Method:   method,
+		Status:   statusCodeOf(err),
+		Duration: time.Since(start),
+	}
+	record.Actor, _ = ActorFromContext(ctx)
+	if err != nil {
+		record.Error = telemetryError(err).Error()
+	}
+
+	if parsed, parseErr := url.Parse(requestURL); parseErr == nil {
+		record.Endpoint = parsed.Path
+		params := parsed.Query()
+		if !c.config.NoRetention {
+			record.Query = params.Get("q")
+		}
+		params.Del("q")
+		record.Params = params
+	}
+
+	if auditErr := c.config.Auditor.Audit(ctx, record); auditErr != nil {
+		c.warn(Warning{
+			Code:    WarningCodeAuditFailed,
+			Message: "failed to write audit record: " + auditErr.Error(),
+		})
+	}
+}
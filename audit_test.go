@@ -0,0 +1,116 @@
+package bravesearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditLog tests recording searches as JSON lines
+func TestAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") == "fail" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithAuditLog(NewJSONAuditor(&buf)), WithRetries(0))
+	require.NoError(t, err)
+
+	ctx := ContextWithActor(context.Background(), "user-42")
+	params := &WebSearchParams{Country: "JP", Extra: url.Values{"custom": {"a", "b"}}}
+	_, err = client.WebSearch(ctx, "golang", params)
+	require.NoError(t, err)
+	_, err = client.WebSearch(ctx, "fail", nil)
+	require.Error(t, err)
+
+	decoder := json.NewDecoder(&buf)
+	var record AuditRecord
+	require.NoError(t, decoder.Decode(&record))
+	assert.Equal(t, "user-42", record.Actor)
+	assert.Equal(t, http.MethodGet, record.Method)
+	assert.Equal(t, WebSearchEndpoint, record.Endpoint)
+	assert.Equal(t, "golang", record.Query)
+	assert.Equal(t, []string{"JP"}, record.Params["country"])
+	assert.Equal(t, []string{"a", "b"}, record.Params["custom"])
+	assert.NotContains(t, record.Params, "q")
+	assert.Equal(t, http.StatusOK, record.Status)
+	assert.Empty(t, record.Error)
+	assert.False(t, record.Time.IsZero())
+
+	require.NoError(t, decoder.Decode(&record))
+	assert.Equal(t, "fail", record.Query)
+	assert.Equal(t, http.StatusTooManyRequests, record.Status)
+	assert.NotEmpty(t, record.Error)
+}
+
+// TestAuditLogNoRetention tests that query text is not audited in no-retention mode
+func TestAuditLogNoRetention(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var records []AuditRecord
+	auditor := AuditorFunc(func(ctx context.Context, record AuditRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithAuditLog(auditor), WithNoRetention(true))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "secret query", nil)
+	require.NoError(t, err)
+
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Query)
+	assert.Empty(t, records[0].Actor)
+	assert.NotEmpty(t, records[0].Params)
+}
+
+// TestAuditLogFailure tests that audit failures are reported as warnings
+func TestAuditLogFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var warnings []Warning
+	auditor := AuditorFunc(func(ctx context.Context, record AuditRecord) error {
+		return errors.New("disk full")
+	})
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithAuditLog(auditor),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodeAuditFailed, warnings[0].Code)
+}
+
+// TestWithAuditLog tests the audit log option
+func TestWithAuditLog(t *testing.T) {
+	config := &ClientConfig{}
+	assert.Equal(t, ErrInvalidParameters, WithAuditLog(nil)(config))
+	require.NoError(t, WithAuditLog(NewJSONAuditor(&bytes.Buffer{}))(config))
+	assert.NotNil(t, config.Auditor)
+}
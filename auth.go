@@ -0,0 +1,56 @@
+package bravesearch
+
+import "net/http"
+
+// Authenticator adds authentication to outgoing API requests. It is called
+// once per attempt, after the standard headers have been set, so
+// implementations may sign the request. Implementations must be safe for
+// concurrent use.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc is an adapter to allow the use of ordinary functions as Authenticators
+type AuthenticatorFunc func(req *http.Request) error
+
+// Authenticate calls f(req)
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// SubscriptionTokenAuthenticator authenticates requests with the
+// X-Subscription-Token header. This is the default scheme.
+type SubscriptionTokenAuthenticator struct {
+	Token string
+
+	// Header overrides the name of the header, sent with exactly this casing
+	Header string
+}
+
+// Authenticate implements Authenticator
+func (a SubscriptionTokenAuthenticator) Authenticate(req *http.Request) error {
+	if a.Token == "" {
+		return ErrMissingAPIKey
+	}
+	if a.Header != "" {
+		setExactHeader(req.Header, a.Header, a.Token)
+		return nil
+	}
+	req.Header.Set(HeaderSubscriptionToken, a.Token)
+	return nil
+}
+
+// BearerTokenAuthenticator authenticates requests with an
+// "Authorization: Bearer" header, as expected by many API gateways
+type BearerTokenAuthenticator struct {
+	Token string
+}
+
+// Authenticate implements Authenticator
+func (a BearerTokenAuthenticator) Authenticate(req *http.Request) error {
+	if a.Token == "" {
+		return ErrMissingAPIKey
+	}
+	req.Header.Set(HeaderAuthorization, "Bearer "+a.Token)
+	return nil
+}
@@ -0,0 +1,90 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSubscriptionTokenAuthenticator tests the default authentication scheme
+func TestSubscriptionTokenAuthenticator(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+
+	err = SubscriptionTokenAuthenticator{Token: "test-api-key"}.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-api-key", req.Header.Get(HeaderSubscriptionToken))
+
+	// Test with missing token
+	err = SubscriptionTokenAuthenticator{}.Authenticate(req)
+	assert.Equal(t, ErrMissingAPIKey, err)
+}
+
+// TestBearerTokenAuthenticator tests the bearer token scheme
+func TestBearerTokenAuthenticator(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+
+	err = BearerTokenAuthenticator{Token: "gateway-token"}.Authenticate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer gateway-token", req.Header.Get(HeaderAuthorization))
+	assert.Empty(t, req.Header.Get(HeaderSubscriptionToken))
+
+	// Test with missing token
+	err = BearerTokenAuthenticator{}.Authenticate(req)
+	assert.Equal(t, ErrMissingAPIKey, err)
+}
+
+// TestWithAuthenticator tests using a custom authenticator with the client
+func TestWithAuthenticator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gateway-token", r.Header.Get(HeaderAuthorization))
+		assert.Empty(t, r.Header.Get(HeaderSubscriptionToken))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	// The API key is optional with a custom authenticator
+	client, err := NewClient("",
+		WithBaseURL(server.URL),
+		WithAuthenticator(BearerTokenAuthenticator{Token: "gateway-token"}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "go programming", nil)
+	assert.NoError(t, err)
+
+	// A nil authenticator is rejected
+	_, err = NewClient("test-api-key", WithAuthenticator(nil))
+	assert.Equal(t, ErrInvalidParameters, err)
+}
+
+// TestAuthenticatorError tests that authentication failures abort the request
+func TestAuthenticatorError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	authErr := errors.New("signing failed")
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithAuthenticator(AuthenticatorFunc(func(req *http.Request) error {
+			return authErr
+		})),
+	)
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "go programming", nil)
+	assert.Nil(t, response)
+	assert.ErrorIs(t, err, authErr)
+	assert.Equal(t, 0, requests)
+}
@@ -21,6 +21,8 @@
 //	}
 package bravesearch
 
+import "strings"
+
 // Version is the current version of the client library
 const Version = "0.1.0"
 
@@ -36,3 +38,35 @@ func GetVersion() string {
 func GetUserAgent() string {
 	return UserAgentPrefix + "/" + Version
 }
+
+// composeUserAgent builds a User-Agent identifying both the application and
+// this library, e.g. "myapp/2.1 go-brave-search/0.1.0 (+contact)"
+func composeUserAgent(appName, appVersion, contact string) string {
+	var parts []string
+	if appName != "" {
+		product := appName
+		if appVersion != "" {
+			product += "/" + appVersion
+		}
+		parts = append(parts, product)
+	}
+	parts = append(parts, GetUserAgent())
+	if contact != "" {
+		parts = append(parts, "(+"+contact+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// isUserAgentToken reports whether s can be used as a product name or
+// version in a User-Agent header
+func isUserAgentToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune("()<>@,;:\\\"/[]?={}", r) {
+			return false
+		}
+	}
+	return true
+}
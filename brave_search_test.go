@@ -20,3 +20,10 @@ func TestGetUserAgent(t *testing.T) {
 	assert.Contains(t, userAgent, "go-brave-search")
 	assert.Contains(t, userAgent, Version)
 }
+
+// TestComposeUserAgent tests composing the User-Agent from application info
+func TestComposeUserAgent(t *testing.T) {
+	assert.Equal(t, "myapp/2.1 "+GetUserAgent()+" (+ops@example.com)", composeUserAgent("myapp", "2.1", "ops@example.com"))
+	assert.Equal(t, "myapp "+GetUserAgent(), composeUserAgent("myapp", "", ""))
+	assert.Equal(t, GetUserAgent()+" (+https://example.com)", composeUserAgent("", "", "https://example.com"))
+}
@@ -0,0 +1,206 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Vertical is a kind of results a combined Search can include
+type Vertical string
+
+// Verticals
+const (
+	VerticalWeb    Vertical = "web"
+	VerticalNews   Vertical = "news"
+	VerticalVideos Vertical = "videos"
+	VerticalImages Vertical = "images"
+)
+
+// DefaultVerticals are the verticals of a Search without
+// BundleOptions.Verticals. VerticalImages is not included, as the Image
+// Search API needs a subscription that includes it.
+var DefaultVerticals = []Vertical{VerticalWeb, VerticalNews, VerticalVideos}
+
+// BundleOptions configures a combined Search
+type BundleOptions struct {
+	// Verticals are the kinds of results to include, DefaultVerticals if empty
+	Verticals []Vertical
+
+	// Params are the web search parameters. ResultFilter is set from Verticals.
+	Params *WebSearchParams
+
+	// ImageParams are the image search parameters. If nil, the country,
+	// search language, spellcheck and (if off or strict) SafeSearch of Params
+	// apply.
+	ImageParams *ImageSearchParams
+}
+
+// SearchBundle is the combined response of a Search, for rendering a
+// universal results page
+type SearchBundle struct {
+	// Query is the query as the API understood it
+	Query *Query
+
+	Web    []SearchResult
+	News   []NewsResult
+	Videos []VideoResult
+	Images []ImageResult
+
+	// Errors holds the verticals that failed while others succeeded
+	Errors map[Vertical]error
+}
+
+// bundleRequest fetches verticals served by one endpoint into a bundle
+type bundleRequest func(ctx context.Context, bundle *SearchBundle, mu *sync.Mutex) error
+
+// Search fetches several verticals for a query at once and combines them in
+// a SearchBundle. Verticals served by the same endpoint are fetched with a
+// single request, and requests to different endpoints run concurrently, all
+// through the client, so they share its rate limit. If only some verticals
+// fail, their errors are reported in the bundle; if all fail, Search fails.
+func (c *Client) Search(ctx context.Context, query string, opts *BundleOptions) (*SearchBundle, error) {
+	if opts == nil {
+		opts = &BundleOptions{}
+	}
+	verticals := opts.Verticals
+	if len(verticals) == 0 {
+		verticals = DefaultVerticals
+	}
+
+	var filters []string
+	requests := make(map[Endpoint]bundleRequest)
+	for _, vertical := range verticals {
+		switch vertical {
+		case VerticalWeb, VerticalNews, VerticalVideos:
+			if !slices.Contains(filters, string(vertical)) {
+				filters = append(filters, string(vertical))
+			}
+		case VerticalImages:
+			requests[EndpointImageSearch] = c.imageSearchBundle(query, opts.Params, opts.ImageParams)
+		default:
+			return nil, fmt.Errorf("%w: unknown vertical %q", ErrInvalidParameters, vertical)
+		}
+	}
+	if len(filters) > 0 {
+		requests[EndpointWebSearch] = c.webSearchBundle(query, opts.Params, strings.Join(filters, ","))
+	}
+
+	bundle := &SearchBundle{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[Endpoint]error, len(requests))
+	for endpoint, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := request(ctx, bundle, &mu)
+			mu.Lock()
+			errs[endpoint] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	var failed []error
+	for endpoint, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed = append(failed, err)
+		for _, vertical := range verticalsOf(endpoint, verticals) {
+			if bundle.Errors == nil {
+				bundle.Errors = make(map[Vertical]error)
+			}
+			bundle.Errors[vertical] = err
+		}
+	}
+	if len(failed) == len(requests) {
+		return nil, errors.Join(failed...)
+	}
+	return bundle, nil
+}
+
+// webSearchBundle fetches the verticals served by the web search endpoint
+func (c *Client) webSearchBundle(query string, params *WebSearchParams, resultFilter string) bundleRequest {
+	return func(ctx context.Context, bundle *SearchBundle, mu *sync.Mutex) error {
+		searchParams := &WebSearchParams{}
+		if params != nil {
+			*searchParams = *params
+		}
+		searchParams.ResultFilter = resultFilter
+
+		response, err := c.WebSearch(ctx, query, searchParams)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if response.Query != nil {
+			bundle.Query = response.Query
+		}
+		if response.Web != nil {
+			bundle.Web = response.Web.Results
+		}
+		if response.News != nil {
+			bundle.News = response.News.Results
+		}
+		if response.Videos != nil {
+			bundle.Videos = response.Videos.Results
+		}
+		return nil
+	}
+}
+
+// imageSearchBundle fetches the image vertical from the image search endpoint
+func (c *Client) imageSearchBundle(query string, params *WebSearchParams, imageParams *ImageSearchParams) bundleRequest {
+	return func(ctx context.Context, bundle *SearchBundle, mu *sync.Mutex) error {
+		searchParams := imageParams
+		if searchParams == nil && params != nil {
+			searchParams = &ImageSearchParams{
+				Country:    params.Country,
+				SearchLang: params.SearchLang,
+				Spellcheck: params.Spellcheck,
+			}
+			if params.SafeSearch == SafeSearchOff || params.SafeSearch == SafeSearchStrict {
+				searchParams.SafeSearch = params.SafeSearch
+			}
+		}
+
+		response, err := c.ImageSearch(ctx, query, searchParams)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		// The web search query, which carries more detail, takes precedence
+		if bundle.Query == nil {
+			bundle.Query = response.Query
+		}
+		bundle.Images = response.Results
+		return nil
+	}
+}
+
+// verticalsOf returns the requested verticals served by endpoint
+func verticalsOf(endpoint Endpoint, verticals []Vertical) []Vertical {
+	var served []Vertical
+	for _, vertical := range verticals {
+		switch vertical {
+		case VerticalWeb, VerticalNews, VerticalVideos:
+			if endpoint == EndpointWebSearch {
+				served = append(served, vertical)
+			}
+		case VerticalImages:
+			if endpoint == EndpointImageSearch {
+				served = append(served, vertical)
+			}
+		}
+	}
+	return served
+}
@@ -0,0 +1,153 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bundleResponse has web, news and video results
+const bundleResponse = `{
+	"type": "search",
+	"query": {"original": "golang"},
+	"web": {"type": "search", "results": [{"title": "Go", "url": "https://go.dev/"}]},
+	"news": {"type": "news", "results": [{"title": "Go 1.24", "url": "https://go.dev/blog/go1.24"}]},
+	"videos": {"type": "videos", "results": [{"title": "Go in 100 seconds", "url": "https://example.com/video"}]}
+}`
+
+// TestSearchBundle tests combining verticals into a bundle
+func TestSearchBundle(t *testing.T) {
+	var requests atomic.Int32
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(bundleResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	bundle, err := client.Search(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.NotNil(t, bundle.Query)
+	assert.Equal(t, "golang", bundle.Query.Original)
+	require.Len(t, bundle.Web, 1)
+	assert.Equal(t, "https://go.dev/", bundle.Web[0].URL)
+	require.Len(t, bundle.News, 1)
+	require.Len(t, bundle.Videos, 1)
+	assert.Empty(t, bundle.Errors)
+
+	// Verticals of the web search endpoint share one request
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Equal(t, "web,news,videos", query.Get("result_filter"))
+
+	_, err = client.Search(context.Background(), "golang", &BundleOptions{
+		Verticals: []Vertical{VerticalNews, VerticalNews},
+		Params:    &WebSearchParams{Count: 5, ResultFilter: "faq"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "news", query.Get("result_filter"))
+	assert.Equal(t, "5", query.Get("count"))
+}
+
+// TestSearchBundleImages tests fetching images from the image search endpoint
+// alongside the web search verticals
+func TestSearchBundleImages(t *testing.T) {
+	var webRequests, imageRequests atomic.Int32
+	var imageQuery atomic.Pointer[url.Values]
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == ImageSearchEndpoint {
+			imageRequests.Add(1)
+			query := r.URL.Query()
+			imageQuery.Store(&query)
+			data, err := os.ReadFile("testdata/image_search_response.json")
+			assert.NoError(t, err)
+			_, _ = w.Write(data)
+			return
+		}
+		webRequests.Add(1)
+		_, _ = w.Write([]byte(bundleResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	bundle, err := client.Search(context.Background(), "golang", &BundleOptions{
+		Verticals: []Vertical{VerticalWeb, VerticalImages},
+		Params:    &WebSearchParams{Country: "JP", SafeSearch: SafeSearchStrict},
+	})
+	require.NoError(t, err)
+	require.Len(t, bundle.Web, 1)
+	require.Len(t, bundle.Images, 2)
+	require.NotNil(t, bundle.Query)
+	assert.Equal(t, "golang", bundle.Query.Original)
+	assert.Equal(t, int32(1), webRequests.Load())
+	assert.Equal(t, int32(1), imageRequests.Load())
+	assert.Equal(t, "JP", imageQuery.Load().Get("country"))
+	assert.Equal(t, SafeSearchStrict, imageQuery.Load().Get("safesearch"))
+
+	// Images only, with their own params
+	bundle, err = client.Search(context.Background(), "gopher", &BundleOptions{
+		Verticals:   []Vertical{VerticalImages},
+		Params:      &WebSearchParams{SafeSearch: SafeSearchModerate},
+		ImageParams: &ImageSearchParams{License: ImageLicensePublic},
+	})
+	require.NoError(t, err)
+	require.Len(t, bundle.Images, 2)
+	assert.Equal(t, "gopher", bundle.Query.Original)
+	assert.Equal(t, int32(1), webRequests.Load())
+	assert.Equal(t, ImageLicensePublic, imageQuery.Load().Get("license"))
+	assert.False(t, imageQuery.Load().Has("safesearch"))
+}
+
+// TestSearchBundleErrors tests failing bundles
+func TestSearchBundleErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Search(context.Background(), "golang", nil)
+	assert.True(t, IsAuthError(err))
+
+	_, err = client.Search(context.Background(), "golang", &BundleOptions{Verticals: []Vertical{"maps"}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	// A failing vertical is reported while the others succeed
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == ImageSearchEndpoint {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(bundleResponse))
+	}))
+	defer server.Close()
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	bundle, err := client.Search(context.Background(), "golang", &BundleOptions{
+		Verticals: []Vertical{VerticalNews, VerticalImages},
+	})
+	require.NoError(t, err)
+	require.Len(t, bundle.News, 1)
+	assert.Empty(t, bundle.Images)
+	require.Len(t, bundle.Errors, 1)
+	assert.ErrorIs(t, bundle.Errors[VerticalImages], ErrForbidden)
+}
@@ -8,24 +8,49 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
-// Client is the API client for Brave Search
+// Client is the API client for Brave Search.
+//
+// A Client is safe for concurrent use by multiple goroutines. Its
+// configuration is fixed at construction time; the only state that changes
+// afterwards (such as the latest rate limit snapshot) is synchronized
+// internally. Share a single Client rather than creating one per request.
 type Client struct {
 	config ClientConfig
 	http   *http.Client
+
+	// rateLimit holds the rate limit snapshot of the latest successful response
+	rateLimit atomic.Pointer[RateLimit]
+
+	// rateLimitKey identifies the API key in the RateLimitStore
+	rateLimitKey string
+
+	// deprecations holds the latest deprecation warning per endpoint
+	deprecations deprecations
+
+	// compression tracks the cost and benefit of gzip per endpoint
+	compression *compressionTracker
+
+	// latency tracks the smoothed latency per endpoint
+	latency latencyTracker
+
+	// errorBudget tracks the request outcomes per endpoint
+	errorBudget errorBudgetTracker
+
+	// codes holds the country and language codes loaded from the CodeTableSource
+	codes codeTableCache
+
+	// shadow mirrors searches to the shadow Searcher
+	shadow shadowTracker
 }
 
 // NewClient creates a new Brave Search API client
 func NewClient(apiKey string, options ...ClientOption) (*Client, error) {
-	if apiKey == "" {
-		return nil, ErrMissingAPIKey
-	}
-
 	// Default configuration
 	config := ClientConfig{
 		APIKey:            apiKey,
@@ -36,6 +61,9 @@ func NewClient(apiKey string, options ...ClientOption) (*Client, error) {
 		DefaultCountry:    DefaultCountry,
 		DefaultSearchLang: DefaultSearchLang,
 		DefaultUILang:     DefaultUILang,
+		QueryScrubber:     DefaultQueryScrubber(),
+		QueryLimits:       DefaultQueryLimits(),
+		FeedbackStore:     NewMemoryFeedbackStore(),
 	}
 
 	// Apply options
@@ -43,6 +71,20 @@ func NewClient(apiKey string, options ...ClientOption) (*Client, error) {
 		return nil, err
 	}
 
+	if err := ValidateConfig(&config); err != nil {
+		return nil, err
+	}
+
+	// Authenticate with the subscription token unless told otherwise
+	if config.Authenticator == nil {
+		config.Authenticator = SubscriptionTokenAuthenticator{Token: config.APIKey, Header: config.HeaderPolicy.TokenHeader}
+	}
+
+	// Identify the application alongside the library
+	if config.AppName != "" || config.AppContact != "" {
+		config.UserAgent = composeUserAgent(config.AppName, config.AppVersion, config.AppContact)
+	}
+
 	// Create HTTP client if not provided
 	httpClient := config.HTTPClient
 	if httpClient == nil {
@@ -51,9 +93,19 @@ func NewClient(apiKey string, options ...ClientOption) (*Client, error) {
 		}
 	}
 
+	// Never keep cookies unless a jar was configured explicitly
+	httpClient = withCookieJar(httpClient, config.CookieJar)
+
+	// Inject faults for chaos testing (only in bravesearch_faults builds)
+	if config.FaultPolicy != nil {
+		httpClient = withFaults(httpClient, config.FaultPolicy)
+	}
+
 	client := &Client{
-		config: config,
-		http:   httpClient,
+		config:       config,
+		http:         httpClient,
+		rateLimitKey: rateLimitKey(config.APIKey),
+		compression:  newCompressionTracker(config.AdaptiveCompression, config.CompressionStats),
 	}
 
 	return client, nil
@@ -65,8 +117,9 @@ func (c *Client) WebSearch(ctx context.Context, query string, params *WebSearchP
 		return nil, ErrEmptyQuery
 	}
 
-	if len(query) > 400 || len(strings.Fields(query)) > 50 {
-		return nil, ErrQueryTooLong
+	query = NormalizeQuery(query, c.config.QueryNormalization)
+	if err := ValidateQuery(query, c.config.QueryLimits); err != nil {
+		return nil, err
 	}
 
 	// Create a copy of params or initialize a new one
@@ -78,98 +131,130 @@ func (c *Client) WebSearch(ctx context.Context, query string, params *WebSearchP
 	// Set query
 	searchParams.Query = query
 
-	// Apply defaults if not set
-	if searchParams.Country == "" {
-		searchParams.Country = c.config.DefaultCountry
-	}
-	if searchParams.SearchLang == "" {
-		searchParams.SearchLang = c.config.DefaultSearchLang
+	// Apply defaults if not set, unless the API's own defaults should apply
+	if !c.config.NoDefaults {
+		if searchParams.Country == "" {
+			searchParams.Country = c.config.DefaultCountry
+		}
+		if searchParams.SearchLang == "" {
+			searchParams.SearchLang = c.config.DefaultSearchLang
+		}
+		if searchParams.UILang == "" {
+			searchParams.UILang = c.config.DefaultUILang
+		}
+		if searchParams.Count == 0 {
+			searchParams.Count = DefaultCount
+		}
+		if searchParams.SafeSearch == "" {
+			searchParams.SafeSearch = DefaultSafeSearch
+		}
 	}
-	if searchParams.UILang == "" {
-		searchParams.UILang = c.config.DefaultUILang
+
+	// Map country and language codes to the forms the API expects
+	if err := c.normalizeSearchCodes(ctx, searchParams); err != nil {
+		return nil, err
 	}
-	if searchParams.Count == 0 {
-		searchParams.Count = DefaultCount
+
+	// Build URL
+	requestURL, err := c.buildRequestURL(EndpointWebSearch, searchParams)
+	if err != nil {
+		return nil, err
 	}
-	if searchParams.SafeSearch == "" {
-		searchParams.SafeSearch = DefaultSafeSearch
+
+	// Resolve the location of the end user if none was given
+	if searchParams.Location == nil && c.config.LocationResolver != nil {
+		location, err := c.config.LocationResolver.ResolveLocation(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve location: %w", err)
+		}
+		searchParams.Location = location
 	}
 
-	// Build URL
-	requestURL, err := c.buildRequestURL(WebSearchEndpoint, searchParams)
+	// Location headers
+	header, err := searchParams.Location.Headers()
 	if err != nil {
 		return nil, err
 	}
 
 	// Make the request
 	var response WebSearchResponse
-	if err := c.makeRequest(ctx, http.MethodGet, requestURL, nil, &response); err != nil {
+	start := time.Now()
+	if err := c.makeRequest(ctx, http.MethodGet, requestURL, header, nil, &response); err != nil {
 		return nil, err
 	}
+	latency := time.Since(start)
 
-	return &response, nil
-}
-
-// buildRequestURL builds the request URL with query parameters
-func (c *Client) buildRequestURL(endpoint string, params *WebSearchParams) (string, error) {
-	// Ensure baseURL ends with slash if endpoint doesn't start with one
-	baseURL := c.config.BaseURL
-	if !strings.HasSuffix(baseURL, "/") && !strings.HasPrefix(endpoint, "/") {
-		baseURL += "/"
+	if c.config.EscalateBreakingNews && searchParams.Freshness == "" && response.IsBreakingNews() {
+		c.escalateBreakingNews(ctx, searchParams, header, &response)
 	}
-	baseURL += endpoint
 
-	// Build query string
-	values := url.Values{}
-	if params.Query != "" {
-		values.Add("q", params.Query)
-	}
-	if params.Country != "" {
-		values.Add("country", params.Country)
-	}
-	if params.SearchLang != "" {
-		values.Add("search_lang", params.SearchLang)
-	}
-	if params.UILang != "" {
-		values.Add("ui_lang", params.UILang)
-	}
-	if params.Count > 0 {
-		values.Add("count", strconv.Itoa(params.Count))
-	}
-	if params.Offset > 0 {
-		values.Add("offset", strconv.Itoa(params.Offset))
-	}
-	if params.SafeSearch != "" {
-		values.Add("safesearch", params.SafeSearch)
-	}
-	if params.Freshness != "" {
-		values.Add("freshness", params.Freshness)
-	}
-	values.Add("text_decorations", strconv.FormatBool(params.TextDecorations))
-	values.Add("spellcheck", strconv.FormatBool(params.Spellcheck))
-	if params.ResultFilter != "" {
-		values.Add("result_filter", params.ResultFilter)
-	}
-	if params.Goggles != "" {
-		values.Add("goggles", params.Goggles)
+	response.params = searchParams
+	response.setProvenance(&Provenance{
+		Query:       searchParams.Query,
+		Offset:      searchParams.Offset,
+		Count:       searchParams.Count,
+		RetrievedAt: time.Now(),
+		Cache:       CacheMiss,
+	})
+	c.warnDecodeFailures(&response)
+	c.rateSources(&response)
+	c.checkURLs(ctx, &response)
+
+	if c.config.EmptyResultsError {
+		if err := response.CheckResults(); err != nil {
+			return nil, err
+		}
 	}
-	if params.Units != "" {
-		values.Add("units", params.Units)
+
+	c.attachPreviews(ctx, &response)
+	c.mirror(ctx, query, params, &response, latency)
+
+	if c.config.StableResults {
+		return response.Stabilized(), nil
 	}
-	if params.ExtraSnippets {
-		values.Add("extra_snippets", "true")
+
+	return &response, nil
+}
+
+// buildRequestURL builds the request URL with query parameters
+func (c *Client) buildRequestURL(endpoint Endpoint, params *WebSearchParams) (string, error) {
+	// Build query string from the url tags of the params
+	values, err := encodeParams(params)
+	if err != nil {
+		return "", err
 	}
-	if params.Summary {
-		values.Add("summary", "true")
+	if params != nil {
+		if err := mergeExtra(values, params.Extra, params); err != nil {
+			return "", err
+		}
 	}
 
 	// Append query string to URL
-	return baseURL + "?" + values.Encode(), nil
+	return c.endpointURL(endpoint) + "?" + values.Encode(), nil
 }
 
-// makeRequest makes an HTTP request to the API
-func (c *Client) makeRequest(ctx context.Context, method, url string, body interface{}, result interface{}) error {
-	var bodyReader io.Reader
+// makeRequest makes an HTTP request to the API.
+//
+// Every blocking step honors ctx: sending the request, the backoff between
+// retries and reading (and decompressing) the response body. Once ctx is done
+// the call returns ctx.Err() promptly.
+func (c *Client) makeRequest(ctx context.Context, method, url string, header http.Header, body interface{}, result interface{}) error {
+	start := time.Now()
+	var latency time.Duration
+	err := c.doRequest(ctx, method, url, header, body, result, &latency)
+	duration := time.Since(start)
+	c.observeLatency(url, latency, err)
+	c.observeOutcome(url, err)
+	c.logRequest(ctx, method, url, duration, err)
+	c.auditRequest(ctx, method, url, start, err)
+	return err
+}
+
+// doRequest sends the request, retrying transient failures, and decodes the
+// response into result. It adds the time spent waiting on the API, sending
+// the attempts and reading the response, to latency.
+func (c *Client) doRequest(ctx context.Context, method, url string, header http.Header, body interface{}, result interface{}, latency *time.Duration) error {
+	var bodyData []byte
 
 	// Prepare request body if any
 	if body != nil {
@@ -177,42 +262,56 @@ func (c *Client) makeRequest(ctx context.Context, method, url string, body inter
 		if err != nil {
 			return err
 		}
-		bodyReader = bytes.NewBuffer(jsonData)
-	}
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
-	if err != nil {
-		return err
-	}
-
-	// Set headers
-	req.Header.Set(HeaderAccept, MIMETypeJSON)
-	req.Header.Set(HeaderAcceptEncoding, MIMETypeGzip)
-	req.Header.Set(HeaderUserAgent, c.config.UserAgent)
-	req.Header.Set(HeaderSubscriptionToken, c.config.APIKey)
-	req.Header.Set(HeaderCacheControl, "no-cache")
-
-	if body != nil {
-		req.Header.Set("Content-Type", MIMETypeJSON)
+		bodyData = jsonData
 	}
 
 	// Make the request with retries
 	var resp *http.Response
 	var respErr error
+	var endpoint string
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := c.reserveRateLimit(ctx); err != nil {
+			return err
+		}
+
+		// A fresh request per attempt, so the body can be replayed
+		req, err := c.newRequest(ctx, method, url, header, bodyData)
+		if err != nil {
+			return err
+		}
+		endpoint = req.URL.Path
+
+		sent := time.Now()
 		resp, respErr = c.http.Do(req)
+		*latency += time.Since(sent)
+		if respErr == nil {
+			c.updateRateLimitStore(ctx, resp)
+			c.noteDeprecation(req.URL.Path, resp.Header)
+		}
 		if respErr == nil && resp.StatusCode < 500 {
 			// Success or non-retriable error
 			break
 		}
 
-		// If this was the last attempt, return the error
-		if attempt == c.config.MaxRetries {
+		// Never retry once the context is done
+		if err := ctx.Err(); err != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return err
+		}
+
+		// If this was the last attempt or the retry budget is spent, return the error
+		if attempt == c.config.MaxRetries || !c.allowRetry(ctx) {
 			if respErr != nil {
 				return respErr
 			}
+			defer resp.Body.Close()
 			return NewHTTPError(resp)
 		}
 
@@ -223,10 +322,12 @@ func (c *Client) makeRequest(ctx context.Context, method, url string, body inter
 
 		// Add exponential backoff
 		backoffTime := time.Duration(1<<uint(attempt)) * 100 * time.Millisecond
+		timer := time.NewTimer(backoffTime)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return ctx.Err()
-		case <-time.After(backoffTime):
+		case <-timer.C:
 			// Continue with retry
 		}
 	}
@@ -235,55 +336,42 @@ func (c *Client) makeRequest(ctx context.Context, method, url string, body inter
 	if resp == nil {
 		return fmt.Errorf("no response: %w", respErr)
 	}
-	defer resp.Body.Close()
+	rawBody := resp.Body
+	defer rawBody.Close()
+
+	// Unblock body reads as soon as ctx is done, even when the transport
+	// itself does not watch the request context
+	stop := context.AfterFunc(ctx, func() { rawBody.Close() })
+	defer stop()
 
 	// Handle HTTP error status codes
 	if resp.StatusCode != http.StatusOK {
-		var bodyReader io.ReadCloser
-		if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
-			bodyReader, _ = gzip.NewReader(resp.Body)
-			defer bodyReader.Close()
-
-			respBody, _ := io.ReadAll(bodyReader)
-			resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
-		} else {
-			// For debugging, print the response body if there's an error
-			respBody, _ := io.ReadAll(resp.Body)
-			// Create a new response with the same body for further processing
-			resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
+		read := time.Now()
+		respBody, err := readBody(ctx, resp)
+		*latency += time.Since(read)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
 		}
+		// Hand the buffered body over for further processing
+		resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
 
 		return NewHTTPError(resp)
 	}
 
 	// Parse rate limit headers
-	c.parseRateLimitHeaders(resp)
+	c.rateLimit.Store(c.parseRateLimitHeaders(resp))
 
 	// Parse response body
 	if result != nil {
-		// Check if content is gzipped
-		var bodyReader io.ReadCloser
-		contentEncoding := resp.Header.Get("Content-Encoding")
-
-		if strings.Contains(contentEncoding, "gzip") {
-			// Import required for gzip
-			var err error
-			bodyReader, err = gzip.NewReader(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to create gzip reader: %w", err)
-			}
-			defer bodyReader.Close()
-		} else {
-			bodyReader = resp.Body
-		}
-
-		// Read the body
-		body, err := io.ReadAll(bodyReader)
+		read := time.Now()
+		body, stats, err := readBodyStats(ctx, resp)
+		*latency += time.Since(read)
 		if err != nil {
 			return err
 		}
+		c.compression.record(endpoint, len(body), stats)
 
-		if err := json.Unmarshal(body, result); err != nil {
+		if err := c.decodeResponse(body, result); err != nil {
 			return &APIError{
 				StatusCode: resp.StatusCode,
 				Message:    "Failed to parse response",
@@ -295,6 +383,103 @@ func (c *Client) makeRequest(ctx context.Context, method, url string, body inter
 	return nil
 }
 
+// newRequest creates an HTTP request with the API headers and the given extra headers set
+func (c *Client) newRequest(ctx context.Context, method, url string, header http.Header, body []byte) (*http.Request, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers
+	req.Header.Set(HeaderAccept, MIMETypeJSON)
+	req.Header.Set(HeaderAcceptEncoding, c.compression.acceptEncoding(req.URL.Path))
+	req.Header.Set(HeaderUserAgent, c.config.UserAgent)
+	req.Header.Set(HeaderCacheControl, "no-cache")
+	if c.config.APIVersion != "" {
+		req.Header.Set(HeaderAPIVersion, c.config.APIVersion)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", MIMETypeJSON)
+	}
+
+	c.config.HeaderPolicy.apply(req.Header)
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if err := c.config.Authenticator.Authenticate(req); err != nil {
+		return nil, err
+	}
+	if c.config.CookieJar == nil {
+		req.Header.Del(HeaderCookie)
+	}
+
+	// Sign last, so the signature covers the final request
+	if c.config.RequestSigner != nil {
+		if err := c.config.RequestSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+// readBody reads the response body, decompressing it if it is gzipped.
+// Read failures caused by ctx being done are reported as ctx.Err().
+func readBody(ctx context.Context, resp *http.Response) ([]byte, error) {
+	data, _, err := readBodyStats(ctx, resp)
+	return data, err
+}
+
+// readBodyStats is readBody also reporting how the body was transferred. The
+// body is read in full before it is decompressed, so the decompression time
+// does not include waiting for the network.
+func readBodyStats(ctx context.Context, resp *http.Response) ([]byte, bodyStats, error) {
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, bodyStats{}, ctxErr
+		}
+		return raw, bodyStats{}, err
+	}
+
+	stats := bodyStats{wireBytes: len(raw)}
+	if !strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
+		return raw, stats, nil
+	}
+
+	start := time.Now()
+	gzipReader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzipReader.Close()
+
+	data, err := io.ReadAll(gzipReader)
+	stats.gzipped = true
+	stats.decompressTime = time.Since(start)
+	return data, stats, err
+}
+
+// RateLimit returns the rate limit information reported by the most recent
+// successful response. The boolean is false if no response has been received yet.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	rateLimit := c.rateLimit.Load()
+	if rateLimit == nil {
+		return RateLimit{}, false
+	}
+	return *rateLimit, true
+}
+
 // parseRateLimitHeaders parses rate limit information from response headers
 func (c *Client) parseRateLimitHeaders(resp *http.Response) *RateLimit {
 	rateLimit := &RateLimit{}
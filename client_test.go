@@ -3,9 +3,15 @@ package bravesearch
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -42,6 +48,15 @@ func TestNewClient(t *testing.T) {
 	assert.Equal(t, 60*time.Second, client.config.Timeout)
 	assert.Equal(t, "custom-agent", client.config.UserAgent)
 	assert.Equal(t, "UK", client.config.DefaultCountry)
+
+	// Test with application info (takes precedence over the User-Agent)
+	client, err = NewClient("test-api-key",
+		WithUserAgent("custom-agent"),
+		WithAppInfo("myapp", "2.1"),
+		WithAppContact("https://example.com"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "myapp/2.1 "+GetUserAgent()+" (+https://example.com)", client.config.UserAgent)
 }
 
 // TestWebSearch tests the web search functionality
@@ -191,6 +206,81 @@ func TestParseRateLimitHeaders(t *testing.T) {
 	assert.Equal(t, 1, rateLimit.Reset)
 }
 
+// TestClientRateLimit tests that the latest rate limit snapshot is exposed
+func TestClientRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRateLimitLimit, "10, 15000")
+		w.Header().Set(HeaderRateLimitRemaining, "9, 14999")
+		w.Header().Set(HeaderRateLimitReset, "1, 1419704")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	// No response yet
+	_, ok := client.RateLimit()
+	assert.False(t, ok)
+
+	_, err = client.WebSearch(context.Background(), "go programming", nil)
+	require.NoError(t, err)
+
+	rateLimit, ok := client.RateLimit()
+	assert.True(t, ok)
+	assert.Equal(t, RateLimit{Limit: 10, Remaining: 9, Reset: 1}, rateLimit)
+}
+
+// TestClientConcurrentUse stress tests sharing one client across goroutines (run with -race)
+func TestClientConcurrentUse(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set(HeaderRateLimitRemaining, strconv.Itoa(int(n)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search", "web": {"results": [{"title": "Go", "url": "https://go.dev/"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	const goroutines = 50
+	const searchesPerGoroutine = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*searchesPerGoroutine)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < searchesPerGoroutine; j++ {
+				response, err := client.WebSearch(context.Background(), "go programming", nil)
+				if err != nil {
+					errs <- err
+					continue
+				}
+				if response.GetResultCount() != 1 {
+					errs <- fmt.Errorf("unexpected result count: %d", response.GetResultCount())
+				}
+				client.RateLimit()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(goroutines*searchesPerGoroutine), requests.Load())
+
+	rateLimit, ok := client.RateLimit()
+	assert.True(t, ok)
+	assert.Positive(t, rateLimit.Remaining)
+}
+
 // TestMakeRequestWithRetries tests the retry mechanism
 func TestMakeRequestWithRetries(t *testing.T) {
 	// Setup test server that fails twice then succeeds
@@ -220,7 +310,7 @@ func TestMakeRequestWithRetries(t *testing.T) {
 
 	// Make a request to the test server
 	var response WebSearchResponse
-	err = client.makeRequest(context.Background(), http.MethodGet, server.URL, nil, &response)
+	err = client.makeRequest(context.Background(), http.MethodGet, server.URL, nil, nil, &response)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, attempts) // Original request + 2 retries = 3 attempts total
 }
@@ -235,10 +325,12 @@ func TestBuildRequestURL(t *testing.T) {
 	params := &WebSearchParams{
 		Query: "test query",
 	}
-	url, err := client.buildRequestURL(WebSearchEndpoint, params)
+	url, err := client.buildRequestURL(EndpointWebSearch, params)
 	assert.NoError(t, err)
 	assert.Contains(t, url, WebSearchEndpoint)
 	assert.Contains(t, url, "q=test+query")
+	assert.NotContains(t, url, "text_decorations")
+	assert.NotContains(t, url, "spellcheck")
 
 	// Test with all parameters
 	params = &WebSearchParams{
@@ -250,15 +342,15 @@ func TestBuildRequestURL(t *testing.T) {
 		Offset:          2,
 		SafeSearch:      SafeSearchStrict,
 		Freshness:       FreshnessWeek,
-		TextDecorations: true,
-		Spellcheck:      false,
+		TextDecorations: Bool(true),
+		Spellcheck:      Bool(false),
 		ResultFilter:    ResultFilterNews,
 		Goggles:         "custom-goggle",
 		Units:           UnitMetric,
 		ExtraSnippets:   true,
 		Summary:         true,
 	}
-	url, err = client.buildRequestURL(WebSearchEndpoint, params)
+	url, err = client.buildRequestURL(EndpointWebSearch, params)
 	assert.NoError(t, err)
 	assert.Contains(t, url, "q=test+query")
 	assert.Contains(t, url, "country=JP")
@@ -277,6 +369,193 @@ func TestBuildRequestURL(t *testing.T) {
 	assert.Contains(t, url, "summary=true")
 }
 
+// TestMakeRequestCancelDuringBodyRead tests that canceling the context aborts a slow body read
+func TestMakeRequestCancelDuringBodyRead(t *testing.T) {
+	// Setup test server that stalls in the middle of the body
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search", `))
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	var response WebSearchResponse
+	err = client.makeRequest(ctx, http.MethodGet, server.URL, nil, nil, &response)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// blockingBody is a response body whose reads block until it is closed
+type blockingBody struct {
+	closed chan struct{}
+}
+
+func (b *blockingBody) Read(p []byte) (int, error) {
+	<-b.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (b *blockingBody) Close() error {
+	select {
+	case <-b.closed:
+	default:
+		close(b.closed)
+	}
+	return nil
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestMakeRequestCancelWithContextUnawareTransport tests cancellation when the transport ignores the context
+func TestMakeRequestCancelWithContextUnawareTransport(t *testing.T) {
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+			Body:       &blockingBody{closed: make(chan struct{})},
+		}, nil
+	})
+
+	client, err := NewClient("test-api-key", WithHTTPClient(&http.Client{Transport: transport}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	var response WebSearchResponse
+	err = client.makeRequest(ctx, http.MethodGet, "http://example.invalid/", nil, nil, &response)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// TestMakeRequestCancelDuringBackoff tests that canceling the context interrupts the retry backoff
+func TestMakeRequestCancelDuringBackoff(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(10))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.makeRequest(ctx, http.MethodGet, server.URL, nil, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Less(t, attempts.Load(), int32(11))
+}
+
+// TestMakeRequestCanceledBeforeStart tests that no request is sent with an already canceled context
+func TestMakeRequestCanceledBeforeStart(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = client.makeRequest(ctx, http.MethodGet, server.URL, nil, nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(0), attempts.Load())
+}
+
+// TestWithNoDefaults tests that unset parameters are left to the API's defaults
+func TestWithNoDefaults(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithDefaultCountry("JP"))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	for _, name := range []string{"country", "search_lang", "ui_lang", "count", "safesearch"} {
+		assert.True(t, query.Has(name), name)
+	}
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL), WithDefaultCountry("JP"), WithNoDefaults(true))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	for _, name := range []string{"country", "search_lang", "ui_lang", "count", "safesearch"} {
+		assert.False(t, query.Has(name), name)
+	}
+
+	_, err = client.WebSearch(context.Background(), "golang", &WebSearchParams{Country: "DE", Count: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "DE", query.Get("country"))
+	assert.Equal(t, "5", query.Get("count"))
+	assert.False(t, query.Has("search_lang"))
+
+	_, err = client.Suggest(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	for _, name := range []string{"country", "lang", "count"} {
+		assert.False(t, query.Has(name), name)
+	}
+}
+
+// TestExtraParams tests passing through parameters the library does not model
+func TestExtraParams(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", &WebSearchParams{Extra: url.Values{"new_feature": {"on"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "on", query.Get("new_feature"))
+	assert.Equal(t, "golang", query.Get("q"))
+
+	_, err = client.Suggest(context.Background(), "golang", &SuggestParams{Extra: url.Values{"new_feature": {"on"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "on", query.Get("new_feature"))
+
+	_, err = client.WebSearch(context.Background(), "golang", &WebSearchParams{Extra: url.Values{"country": {"DE"}}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.Suggest(context.Background(), "golang", &SuggestParams{Extra: url.Values{"q": {"rust"}}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
 // loadTestData loads test response data
 func loadTestData(t *testing.T, path string) *WebSearchResponse {
 	data, err := os.ReadFile(path)
@@ -0,0 +1,126 @@
+package bravesearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// DefaultClusterThreshold is the similarity above which ClusterResults
+// considers two results duplicates when no threshold is given
+const DefaultClusterThreshold = 0.5
+
+// shingleSize is the number of words per shingle
+const shingleSize = 3
+
+// ClusterableResult is a result type ClusterResults can group
+type ClusterableResult interface {
+	SearchResult | NewsResult | VideoResult
+}
+
+// ResultCluster is a group of near-duplicate results
+type ResultCluster[T ClusterableResult] struct {
+	// Representative is the highest ranked result of the cluster
+	Representative T
+
+	// Results are all results of the cluster, including the representative,
+	// in their original order
+	Results []T
+}
+
+// ClusterResults groups near-duplicate results, such as the same story
+// syndicated across sites. Results are compared by the Jaccard similarity of
+// the word shingles of their titles and descriptions; a result joins the
+// first cluster holding a result at least threshold (0 to 1) similar to it.
+// A threshold of zero or less uses DefaultClusterThreshold. Clusters are in
+// the order of their representatives.
+func ClusterResults[T ClusterableResult](results []T, threshold float64) []ResultCluster[T] {
+	if threshold <= 0 {
+		threshold = DefaultClusterThreshold
+	}
+
+	var clusters []ResultCluster[T]
+	var clusterShingles [][]map[string]struct{}
+
+	for _, result := range results {
+		shingles := resultShingles(result)
+
+		found := -1
+		for i, members := range clusterShingles {
+			for _, member := range members {
+				if jaccard(shingles, member) >= threshold {
+					found = i
+					break
+				}
+			}
+			if found >= 0 {
+				break
+			}
+		}
+
+		if found < 0 {
+			clusters = append(clusters, ResultCluster[T]{Representative: result})
+			clusterShingles = append(clusterShingles, nil)
+			found = len(clusters) - 1
+		}
+		clusters[found].Results = append(clusters[found].Results, result)
+		clusterShingles[found] = append(clusterShingles[found], shingles)
+	}
+
+	return clusters
+}
+
+// resultShingles returns the word shingles of the title and description of result
+func resultShingles[T ClusterableResult](result T) map[string]struct{} {
+	var title, description string
+	switch r := any(result).(type) {
+	case SearchResult:
+		title, description = r.Title, r.Description
+	case NewsResult:
+		title, description = r.Title, r.Description
+	case VideoResult:
+		title, description = r.Title, r.Description
+	}
+	return shingles(title + " " + description)
+}
+
+// shingles returns the set of overlapping word n-grams of text, ignoring
+// markup, case and punctuation. Texts shorter than a shingle are a single shingle.
+func shingles(text string) map[string]struct{} {
+	text = textutil.StripMarkup(text, " ")
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	set := make(map[string]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	if len(words) < shingleSize {
+		set[strings.Join(words, " ")] = struct{}{}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = struct{}{}
+	}
+	return set
+}
+
+// jaccard returns the Jaccard similarity of two sets; two empty sets are not similar
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	intersection := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
@@ -0,0 +1,50 @@
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClusterResults tests grouping syndicated stories
+func TestClusterResults(t *testing.T) {
+	results := []NewsResult{
+		{URL: "https://a.example/quake", Title: "Strong earthquake strikes off the coast of Japan", Description: "A magnitude 7.1 earthquake struck off the coast of Japan on Monday, officials said."},
+		{URL: "https://b.example/rates", Title: "Central bank holds interest rates steady", Description: "The central bank kept its key rate unchanged for a third meeting."},
+		{URL: "https://c.example/quake", Title: "Strong <strong>earthquake</strong> strikes off the coast of Japan", Description: "A magnitude 7.1 earthquake struck off the coast of Japan on Monday, officials said. Tsunami advisories were issued."},
+		{URL: "https://d.example/quake", Title: "STRONG EARTHQUAKE STRIKES OFF THE COAST OF JAPAN!", Description: "A magnitude 7.1 earthquake struck off the coast of Japan on Monday, officials said."},
+	}
+
+	clusters := ClusterResults(results, 0.5)
+	require.Len(t, clusters, 2)
+
+	assert.Equal(t, "https://a.example/quake", clusters[0].Representative.URL)
+	require.Len(t, clusters[0].Results, 3)
+	assert.Equal(t, "https://c.example/quake", clusters[0].Results[1].URL)
+	assert.Equal(t, "https://d.example/quake", clusters[0].Results[2].URL)
+
+	assert.Equal(t, "https://b.example/rates", clusters[1].Representative.URL)
+	assert.Len(t, clusters[1].Results, 1)
+}
+
+// TestClusterResultsThreshold tests that the threshold controls grouping
+func TestClusterResultsThreshold(t *testing.T) {
+	results := []SearchResult{
+		{Title: "Go 1.22 release notes", Description: "Go 1.22 adds range over integers and fixes loop variable capture."},
+		{Title: "Go 1.22 is released", Description: "Go 1.22 adds range over integers and fixes loop variable capture."},
+	}
+
+	assert.Len(t, ClusterResults(results, 0.5), 1)
+	assert.Len(t, ClusterResults(results, 1), 2)
+	assert.Len(t, ClusterResults(results, 0), 1) // default threshold
+}
+
+// TestClusterResultsEdgeCases tests empty input and empty texts
+func TestClusterResultsEdgeCases(t *testing.T) {
+	assert.Empty(t, ClusterResults([]VideoResult(nil), 0.5))
+
+	// Results without text are never duplicates
+	clusters := ClusterResults([]VideoResult{{URL: "a"}, {URL: "b"}}, 0.5)
+	assert.Len(t, clusters, 2)
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+)
+
+// fixtureQuery is a query of the curated query set
+type fixtureQuery struct {
+	Name  string
+	Query string
+}
+
+// Manifest describes the generated fixtures and the schema they cover
+type Manifest struct {
+	Fixtures []FixtureEntry `json:"fixtures"`
+
+	// Paths maps every JSON path seen to the number of fixtures containing it
+	Paths map[string]int `json:"paths"`
+
+	// Unmodeled are paths with values that WebSearchResponse drops, i.e.
+	// candidates for new typed fields
+	Unmodeled []string `json:"unmodeled"`
+}
+
+// FixtureEntry describes a single fixture file
+type FixtureEntry struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	File  string `json:"file"`
+
+	// SHA256 is the digest of the file contents, for verification
+	SHA256 string `json:"sha256"`
+
+	// Sections are the top-level response sections present
+	Sections []string `json:"sections"`
+}
+
+// volatileKeys are removed from fixtures because they change between runs
+var volatileKeys = map[string]bool{
+	"age":          true,
+	"page_age":     true,
+	"page_fetched": true,
+}
+
+// locationKeys of the query object are cleared because they reveal where
+// the fixtures were generated
+var locationKeys = []string{"city", "state", "postal_code", "header_country"}
+
+// parseQueries parses a query set of "<name> <query>" lines. Blank lines
+// and lines starting with "#" are ignored.
+func parseQueries(r io.Reader) ([]fixtureQuery, error) {
+	var queries []fixtureQuery
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		name, query, found := strings.Cut(text, " ")
+		query = strings.TrimSpace(query)
+		if !found || query == "" {
+			return nil, fmt.Errorf("query set line %d: expected a name and a query", line)
+		}
+		if strings.ContainsAny(name, `/\.`) {
+			return nil, fmt.Errorf("query set line %d: name %q must not contain path characters", line, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("query set line %d: duplicate name %q", line, name)
+		}
+		seen[name] = true
+
+		queries = append(queries, fixtureQuery{Name: name, Query: query})
+	}
+
+	return queries, scanner.Err()
+}
+
+// sanitize removes volatile fields and location hints from a decoded
+// response, so fixtures are reproducible and reveal nothing about the
+// machine that generated them
+func sanitize(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if volatileKeys[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = sanitize(item)
+		}
+		if query, ok := v["query"].(map[string]any); ok {
+			for _, key := range locationKeys {
+				if _, ok := query[key]; ok {
+					query[key] = ""
+				}
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = sanitize(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// encodeFixture encodes a decoded response deterministically; map keys are
+// sorted by encoding/json
+func encodeFixture(value any) ([]byte, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// digest returns the hex SHA-256 digest of data
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// collectPaths adds the JSON paths of value to paths, e.g. "web.results[].title".
+// With nonZero, only paths leading to non-zero values are collected.
+func collectPaths(value any, prefix string, nonZero bool, paths map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			if !nonZero || !isZero(item) {
+				paths[path] = true
+			}
+			collectPaths(item, path, nonZero, paths)
+		}
+	case []any:
+		for _, item := range v {
+			collectPaths(item, prefix+"[]", nonZero, paths)
+		}
+	}
+}
+
+// isZero reports whether a decoded JSON value is null, false, zero or empty
+func isZero(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []any:
+		for _, item := range v {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		for _, item := range v {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// unmodeledPaths returns the paths with values in raw that are lost when
+// decoding into a WebSearchResponse and encoding it again
+func unmodeledPaths(raw []byte) ([]string, error) {
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	var typed bravesearch.WebSearchResponse
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+	roundTripped, err := json.Marshal(typed)
+	if err != nil {
+		return nil, err
+	}
+	var modeled any
+	if err := json.Unmarshal(roundTripped, &modeled); err != nil {
+		return nil, err
+	}
+
+	rawPaths := make(map[string]bool)
+	collectPaths(decoded, "", true, rawPaths)
+	modeledPaths := make(map[string]bool)
+	collectPaths(modeled, "", false, modeledPaths)
+
+	var unmodeled []string
+	for path := range rawPaths {
+		if !modeledPaths[path] {
+			unmodeled = append(unmodeled, path)
+		}
+	}
+	sort.Strings(unmodeled)
+	return unmodeled, nil
+}
+
+// sections returns the sorted top-level keys of a decoded response that hold objects
+func sections(value any) []string {
+	var names []string
+	if v, ok := value.(map[string]any); ok {
+		for key, item := range v {
+			if _, ok := item.(map[string]any); ok {
+				names = append(names, key)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recorder is an http.RoundTripper keeping the decompressed body of the
+// last successful response, so fixtures hold the raw JSON rather than what
+// the typed model retains
+type recorder struct {
+	transport http.RoundTripper
+
+	mu   sync.Mutex
+	body []byte
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.Contains(resp.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.body = data
+	r.mu.Unlock()
+
+	resp.Header.Del("Content-Encoding")
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+// last returns the body of the last successful response
+func (r *recorder) last() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body
+}
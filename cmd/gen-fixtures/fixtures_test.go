@@ -0,0 +1,140 @@
+package main
+
+import (
+	"compress/gzip"
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseQueries tests parsing the curated query set
+func TestParseQueries(t *testing.T) {
+	queries, err := parseQueries(strings.NewReader("# comment\n\ngeneric  go programming\nnews election results\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []fixtureQuery{
+		{Name: "generic", Query: "go programming"},
+		{Name: "news", Query: "election results"},
+	}, queries)
+
+	for _, set := range []string{"lonely", "a/b query", "dup one\ndup two"} {
+		_, err := parseQueries(strings.NewReader(set))
+		assert.Error(t, err, set)
+	}
+
+	file, err := os.Open("../../testdata/fixtures/queries.txt")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = parseQueries(file)
+	assert.NoError(t, err)
+}
+
+// TestSanitize tests removing volatile fields and location hints
+func TestSanitize(t *testing.T) {
+	value := map[string]any{
+		"query": map[string]any{"original": "coffee", "city": "Tokyo", "postal_code": "150-0002"},
+		"web": map[string]any{"results": []any{
+			map[string]any{"title": "Cafe", "age": "2 hours ago", "page_age": "2024-01-01T00:00:00"},
+		}},
+	}
+
+	assert.Equal(t, map[string]any{
+		"query": map[string]any{"original": "coffee", "city": "", "postal_code": ""},
+		"web":   map[string]any{"results": []any{map[string]any{"title": "Cafe"}}},
+	}, sanitize(value))
+}
+
+// TestUnmodeledPaths tests finding response fields the typed model drops
+func TestUnmodeledPaths(t *testing.T) {
+	raw := []byte(`{
+		"type": "search",
+		"web": {"type": "search", "results": [
+			{"title": "Go", "url": "https://go.dev/", "rating_widget": {"items": [{"title": "Docs"}]}, "empty_field": ""}
+		]},
+		"brand_new_section": {"type": "x"}
+	}`)
+
+	unmodeled, err := unmodeledPaths(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"brand_new_section",
+		"brand_new_section.type",
+		"web.results[].rating_widget",
+		"web.results[].rating_widget.items",
+		"web.results[].rating_widget.items[].title",
+	}, unmodeled)
+}
+
+// TestGenerateAndVerify tests generating fixtures from a gzipped API response and verifying them
+func TestGenerateAndVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`{"type": "search", "query": {"original": "` + r.URL.Query().Get("q") + `", "city": "Tokyo"},
+			"web": {"type": "search", "results": [{"title": "Go", "url": "https://go.dev/", "age": "1 day ago", "cluster_type": "x"}]}}`))
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	queries := []fixtureQuery{{Name: "generic", Query: "golang"}, {Name: "other", Query: "rust"}}
+	require.NoError(t, generate("test-api-key", queries, dir, private, bravesearch.WithBaseURL(server.URL)))
+
+	data, err := os.ReadFile(filepath.Join(dir, "generic.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"original": "golang"`)
+	assert.NotContains(t, string(data), "Tokyo")
+	assert.NotContains(t, string(data), "1 day ago")
+
+	manifest, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), `"web.results[].title": 2`)
+	assert.Contains(t, string(manifest), `"web.results[].cluster_type"`)
+	assert.Contains(t, string(manifest), `"sections": [`)
+
+	count, err := verify(dir, public)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Without a public key only the digests are checked
+	count, err = verify(dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, err = verify(dir, otherKey)
+	assert.ErrorContains(t, err, "manifest.json does not match its signature")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), append(data, ' '), 0o644))
+	_, err = verify(dir, public)
+	assert.ErrorContains(t, err, "other.json does not match its manifest digest")
+
+	// Updating the digest in the manifest breaks its signature
+	edited := strings.Replace(string(manifest), digest(data), digest(append(data, ' ')), 1)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifestFile), []byte(edited), 0o644))
+	_, err = verify(dir, public)
+	assert.ErrorContains(t, err, "manifest.json does not match its signature")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, signatureFile)))
+	_, err = verify(dir, public)
+	assert.ErrorContains(t, err, "manifest.json is not signed")
+}
+
+// TestVerifyWithoutFixtures tests verifying before any fixtures are generated
+func TestVerifyWithoutFixtures(t *testing.T) {
+	count, err := verify(t.TempDir(), nil)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,239 @@
+// Command gen-fixtures queries the live API for a curated query set and
+// writes sanitized response fixtures under testdata, with a manifest of
+// their digests and schema coverage. Contributors adding typed structs can
+// test against realistic data, and see in the manifest which response
+// fields are not modeled yet.
+//
+// Usage:
+//
+//	go run ./cmd/gen-fixtures -keygen
+//	BRAVE_API_KEY=... FIXTURES_SIGNING_KEY=... go run ./cmd/gen-fixtures [-queries file] [-out dir]
+//	go run ./cmd/gen-fixtures -verify [-public-key key] [-out dir]
+//
+// Fixtures are reproducible: volatile fields (ages) and location hints are
+// removed and keys are sorted. The manifest is signed with the ed25519 key
+// in FIXTURES_SIGNING_KEY, as printed by -keygen, so its digests detect
+// tampering as well as accidental edits. -verify checks the fixtures against
+// the digests in the manifest without calling the API, and the signature of
+// the manifest against the public key of -public-key or FIXTURES_PUBLIC_KEY;
+// it succeeds if no fixtures have been generated yet.
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+)
+
+// manifestFile is the name of the manifest in the output directory
+const manifestFile = "manifest.json"
+
+func main() {
+	queriesPath := flag.String("queries", "testdata/fixtures/queries.txt", "curated query set, one \"<name> <query>\" per line")
+	outDir := flag.String("out", "testdata/fixtures", "directory to write fixtures and the manifest to")
+	verifyOnly := flag.Bool("verify", false, "verify the fixtures against the manifest instead of generating them")
+	publicKeyFlag := flag.String("public-key", os.Getenv(publicKeyEnv), "base64 public key to verify the manifest signature with")
+	keygen := flag.Bool("keygen", false, "print a new key pair to sign manifests with")
+	flag.Parse()
+
+	if *keygen {
+		privateKey, publicKey, err := generateKeys()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s=%s\n%s=%s\n", signingKeyEnv, privateKey, publicKeyEnv, publicKey)
+		return
+	}
+
+	if *verifyOnly {
+		var publicKey ed25519.PublicKey
+		if *publicKeyFlag != "" {
+			key, err := parsePublicKey(*publicKeyFlag)
+			if err != nil {
+				log.Fatal(err)
+			}
+			publicKey = key
+		}
+		count, err := verify(*outDir, publicKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if count == 0 {
+			fmt.Println("no fixtures to verify")
+			return
+		}
+		fmt.Printf("%d fixtures match the manifest\n", count)
+		if publicKey == nil {
+			fmt.Printf("signature not checked: set -public-key or %s\n", publicKeyEnv)
+		}
+		return
+	}
+
+	apiKey := os.Getenv("BRAVE_API_KEY")
+	if apiKey == "" {
+		log.Fatal("BRAVE_API_KEY environment variable is required")
+	}
+	if os.Getenv(signingKeyEnv) == "" {
+		log.Fatalf("%s environment variable is required, see -keygen", signingKeyEnv)
+	}
+	signingKey, err := parsePrivateKey(os.Getenv(signingKeyEnv))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	file, err := os.Open(*queriesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	queries, err := parseQueries(file)
+	file.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := generate(apiKey, queries, *outDir, signingKey); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate queries the API for every query and writes the fixtures and the
+// manifest, signed with signingKey
+func generate(apiKey string, queries []fixtureQuery, outDir string, signingKey ed25519.PrivateKey, options ...bravesearch.ClientOption) error {
+	rec := &recorder{transport: http.DefaultTransport}
+	options = append([]bravesearch.ClientOption{
+		bravesearch.WithHTTPClient(&http.Client{Transport: rec, Timeout: 30 * time.Second}),
+		bravesearch.WithAppInfo("gen-fixtures", bravesearch.GetVersion()),
+	}, options...)
+	client, err := bravesearch.NewClient(apiKey, options...)
+	if err != nil {
+		return err
+	}
+
+	manifest := Manifest{Paths: make(map[string]int)}
+	unmodeled := make(map[string]bool)
+
+	for _, q := range queries {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		params := bravesearch.NewWebSearchParams()
+		params.Summary = true
+		_, err := client.WebSearch(ctx, q.Query, params)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("query %s: %w", q.Name, err)
+		}
+
+		var decoded any
+		if err := json.Unmarshal(rec.last(), &decoded); err != nil {
+			return fmt.Errorf("query %s: %w", q.Name, err)
+		}
+		decoded = sanitize(decoded)
+
+		data, err := encodeFixture(decoded)
+		if err != nil {
+			return err
+		}
+		file := q.Name + ".json"
+		if err := os.WriteFile(filepath.Join(outDir, file), data, 0o644); err != nil {
+			return err
+		}
+
+		paths := make(map[string]bool)
+		collectPaths(decoded, "", false, paths)
+		for path := range paths {
+			manifest.Paths[path]++
+		}
+
+		missing, err := unmodeledPaths(data)
+		if err != nil {
+			return fmt.Errorf("query %s: %w", q.Name, err)
+		}
+		for _, path := range missing {
+			unmodeled[path] = true
+		}
+
+		manifest.Fixtures = append(manifest.Fixtures, FixtureEntry{
+			Name:     q.Name,
+			Query:    q.Query,
+			File:     file,
+			SHA256:   digest(data),
+			Sections: sections(decoded),
+		})
+		log.Printf("wrote %s", file)
+	}
+
+	manifest.Unmodeled = make([]string, 0, len(unmodeled))
+	for path := range unmodeled {
+		manifest.Unmodeled = append(manifest.Unmodeled, path)
+	}
+	sort.Strings(manifest.Unmodeled)
+
+	data, err := encodeFixture(manifest)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outDir, manifestFile), data, 0o644); err != nil {
+		return err
+	}
+	return signManifest(outDir, data, signingKey)
+}
+
+// verify checks that the fixtures in dir match the digests in its manifest,
+// and the manifest its signature if publicKey is set, and returns their
+// number, 0 if no manifest has been generated
+func verify(dir string, publicKey ed25519.PublicKey) (int, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if publicKey != nil {
+		if err := verifySignature(dir, data, publicKey); err != nil {
+			return 0, err
+		}
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return 0, fmt.Errorf("%s: %w", manifestFile, err)
+	}
+
+	var errs []error
+	for _, fixture := range manifest.Fixtures {
+		data, err := os.ReadFile(filepath.Join(dir, fixture.File))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if digest(data) != fixture.SHA256 {
+			errs = append(errs, fmt.Errorf("%s does not match its manifest digest", fixture.File))
+			continue
+		}
+		if formatted, err := reencode(data); err != nil || !bytes.Equal(formatted, data) {
+			errs = append(errs, fmt.Errorf("%s is not in canonical form", fixture.File))
+		}
+	}
+	return len(manifest.Fixtures), errors.Join(errs...)
+}
+
+// reencode decodes and encodes a fixture, to check it is in canonical form
+func reencode(data []byte) ([]byte, error) {
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return encodeFixture(decoded)
+}
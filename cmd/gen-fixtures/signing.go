@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// signatureFile is the name of the manifest signature in the output directory
+const signatureFile = manifestFile + ".sig"
+
+// Environment variables holding the signing keys, base64 encoded
+const (
+	signingKeyEnv = "FIXTURES_SIGNING_KEY"
+	publicKeyEnv  = "FIXTURES_PUBLIC_KEY"
+)
+
+// generateKeys returns a new base64 encoded ed25519 key pair to sign
+// manifests with; the private key is its seed
+func generateKeys() (privateKey, publicKey string, err error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(private.Seed()), base64.StdEncoding.EncodeToString(public), nil
+}
+
+// parsePrivateKey decodes a base64 ed25519 private key or its seed
+func parsePrivateKey(encoded string) (ed25519.PrivateKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
+	}
+	return nil, fmt.Errorf("invalid signing key: %d bytes", len(key))
+}
+
+// parsePublicKey decodes a base64 ed25519 public key
+func parsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: %d bytes", len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// signManifest writes the signature of the manifest data to dir
+func signManifest(dir string, manifest []byte, key ed25519.PrivateKey) error {
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifest))
+	return os.WriteFile(filepath.Join(dir, signatureFile), []byte(signature+"\n"), 0o644)
+}
+
+// verifySignature checks the signature of the manifest data in dir against key
+func verifySignature(dir string, manifest []byte, key ed25519.PublicKey) error {
+	data, err := os.ReadFile(filepath.Join(dir, signatureFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s is not signed", manifestFile)
+	}
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || !ed25519.Verify(key, manifest, signature) {
+		return fmt.Errorf("%s does not match its signature", manifestFile)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseKeys tests decoding the key pairs printed by -keygen
+func TestParseKeys(t *testing.T) {
+	privateEncoded, publicEncoded, err := generateKeys()
+	require.NoError(t, err)
+
+	private, err := parsePrivateKey(privateEncoded + "\n")
+	require.NoError(t, err)
+	public, err := parsePublicKey(publicEncoded)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(public, []byte("manifest"), ed25519.Sign(private, []byte("manifest"))))
+
+	_, err = parsePrivateKey("not base64!")
+	assert.ErrorContains(t, err, "invalid signing key")
+	_, err = parsePrivateKey("c2hvcnQ=")
+	assert.ErrorContains(t, err, "invalid signing key: 5 bytes")
+	_, err = parsePublicKey(privateEncoded[:8])
+	assert.ErrorContains(t, err, "invalid public key")
+}
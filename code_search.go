@@ -0,0 +1,203 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// DefaultCodeDomains are the developer sites a code search is scoped to
+// without CodeSearchOptions.Domains. A domain also matches its subdomains.
+var DefaultCodeDomains = []string{
+	"github.com", "gitlab.com", "stackoverflow.com", "stackexchange.com",
+	"serverfault.com", "superuser.com",
+}
+
+// CodeSearchOptions configures a WebSearchCode
+type CodeSearchOptions struct {
+	// Domains are the sites to search, DefaultCodeDomains if empty
+	Domains []string
+
+	// Params are the web search parameters. ResultFilter is set to web.
+	Params *WebSearchParams
+}
+
+// CodeResultType is the kind of page a code search result links to
+type CodeResultType string
+
+// Code result types
+const (
+	CodeResultRepository  CodeResultType = "repository"
+	CodeResultIssue       CodeResultType = "issue"
+	CodeResultPullRequest CodeResultType = "pull_request"
+	CodeResultQuestion    CodeResultType = "question"
+	CodeResultPage        CodeResultType = "page"
+)
+
+// CodeResult is a code search result with the repository or question it
+// refers to
+type CodeResult struct {
+	Type        CodeResultType
+	Title       string
+	URL         string
+	Description string
+	Hostname    string
+	Age         string
+
+	// Owner and Repo name the repository of results on code hosts, e.g.
+	// "cnosuke" and "go-brave-search". For GitLab projects in subgroups Owner
+	// is the group path if the URL shows where it ends (at "/-/").
+	Owner string
+	Repo  string
+
+	// Number is the issue or pull request number
+	Number int
+
+	// QuestionID is the ID of a Stack Exchange question
+	QuestionID int
+}
+
+// Repository returns the "owner/repo" name of the repository, if the result
+// refers to one
+func (r *CodeResult) Repository() (string, bool) {
+	if r.Owner == "" || r.Repo == "" {
+		return "", false
+	}
+	return r.Owner + "/" + r.Repo, true
+}
+
+// questionDomains host Stack Exchange sites, whose questions live at /questions/<id>
+var questionDomains = []string{"stackoverflow.com", "stackexchange.com", "serverfault.com", "superuser.com", "askubuntu.com", "mathoverflow.net"}
+
+// reservedRepoOwners are first path segments of code hosts that are not users or groups
+var reservedRepoOwners = map[string]bool{
+	"about": true, "collections": true, "enterprise": true, "explore": true,
+	"features": true, "login": true, "marketplace": true, "orgs": true,
+	"pricing": true, "search": true, "settings": true, "sponsors": true,
+	"topics": true, "trending": true, "users": true,
+}
+
+// WebSearchCode searches developer sites for query and returns the web
+// results as CodeResults, with the repository, issue or question they refer
+// to parsed from their URL. The query is scoped with site: operators, and
+// results outside the domains are dropped.
+func (c *Client) WebSearchCode(ctx context.Context, query string, opts *CodeSearchOptions) ([]CodeResult, error) {
+	if opts == nil {
+		opts = &CodeSearchOptions{}
+	}
+	domains := opts.Domains
+	if len(domains) == 0 {
+		domains = DefaultCodeDomains
+	}
+
+	sites := make([]string, 0, len(domains))
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = hostutil.Normalize(strings.TrimSpace(domain))
+		if domain == "" || strings.ContainsAny(domain, " /:") {
+			return nil, fmt.Errorf("%w: invalid code search domain %q", ErrInvalidParameters, domain)
+		}
+		sites = append(sites, "site:"+domain)
+		normalized = append(normalized, domain)
+	}
+
+	params := &WebSearchParams{}
+	if opts.Params != nil {
+		*params = *opts.Params
+	}
+	params.ResultFilter = "web"
+
+	scoped := strings.TrimSpace(query)
+	if scoped != "" {
+		scoped += " " + strings.Join(sites, " OR ")
+	}
+
+	response, err := c.WebSearch(ctx, scoped, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []CodeResult
+	for _, result := range response.GetWebResults() {
+		code, ok := parseCodeResult(result)
+		if !ok || !hostutil.MatchesAnyDomain(code.Hostname, normalized) {
+			continue
+		}
+		results = append(results, code)
+	}
+	return results, nil
+}
+
+// parseCodeResult parses the repository, issue or question a web result
+// refers to from its URL and meta_url
+func parseCodeResult(result SearchResult) (CodeResult, bool) {
+	u, err := url.Parse(result.URL)
+	if err != nil {
+		return CodeResult{}, false
+	}
+
+	code := CodeResult{
+		Type:        CodeResultPage,
+		Title:       result.Title,
+		URL:         result.URL,
+		Description: result.Description,
+		Hostname:    result.hostname(),
+		Age:         result.Age,
+	}
+	segments := pathSegments(u.Path)
+
+	switch {
+	case hostutil.MatchesAnyDomain(code.Hostname, questionDomains):
+		if len(segments) >= 2 && (segments[0] == "questions" || segments[0] == "q") {
+			if id, err := strconv.Atoi(segments[1]); err == nil {
+				code.Type = CodeResultQuestion
+				code.QuestionID = id
+			}
+		}
+	case hostutil.MatchesAnyDomain(code.Hostname, codeRepoDomains):
+		parseRepository(&code, segments)
+	}
+	return code, true
+}
+
+// parseRepository sets the repository, issue or pull request of a code host
+// result from its path, e.g. /owner/repo/issues/1 or, on GitLab,
+// /group/subgroup/project/-/merge_requests/1
+func parseRepository(code *CodeResult, segments []string) {
+	if len(segments) < 2 || reservedRepoOwners[segments[0]] {
+		return
+	}
+
+	// GitLab separates the project path from its pages with "-"
+	repoPath, rest := segments[:2], segments[2:]
+	for i, segment := range segments {
+		if segment == "-" && i >= 2 {
+			repoPath, rest = segments[:i], segments[i+1:]
+			break
+		}
+	}
+
+	code.Type = CodeResultRepository
+	code.Owner = strings.Join(repoPath[:len(repoPath)-1], "/")
+	code.Repo = strings.TrimSuffix(repoPath[len(repoPath)-1], ".git")
+
+	if len(rest) < 2 {
+		return
+	}
+	number, err := strconv.Atoi(rest[1])
+	if err != nil {
+		return
+	}
+	switch rest[0] {
+	case "issues":
+		code.Type = CodeResultIssue
+		code.Number = number
+	case "pull", "pulls", "pull-requests", "merge_requests":
+		code.Type = CodeResultPullRequest
+		code.Number = number
+	}
+}
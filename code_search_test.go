@@ -0,0 +1,121 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codeSearchResponse has results from code hosts, Stack Exchange and elsewhere
+const codeSearchResponse = `{
+	"type": "search",
+	"web": {"type": "search", "results": [
+		{"title": "go-brave-search", "url": "https://github.com/cnosuke/go-brave-search", "description": "Brave Search client", "meta_url": {"hostname": "github.com"}},
+		{"title": "Retry on 429", "url": "https://github.com/cnosuke/go-brave-search/issues/12"},
+		{"title": "How to retry HTTP requests in Go?", "url": "https://stackoverflow.com/questions/12345/how-to-retry", "age": "2 years ago"},
+		{"title": "Blog post", "url": "https://example.com/retry"}
+	]}
+}`
+
+// TestWebSearchCode tests searching developer sites
+func TestWebSearchCode(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(codeSearchResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	results, err := client.WebSearchCode(context.Background(), "go retry", &CodeSearchOptions{
+		Params: &WebSearchParams{Count: 10, ResultFilter: "news"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "go retry site:github.com OR site:gitlab.com OR site:stackoverflow.com OR site:stackexchange.com OR site:serverfault.com OR site:superuser.com", query.Get("q"))
+	assert.Equal(t, "web", query.Get("result_filter"))
+	assert.Equal(t, "10", query.Get("count"))
+
+	// The result outside the developer sites is dropped
+	require.Len(t, results, 3)
+
+	assert.Equal(t, CodeResultRepository, results[0].Type)
+	assert.Equal(t, "Brave Search client", results[0].Description)
+	repository, ok := results[0].Repository()
+	assert.True(t, ok)
+	assert.Equal(t, "cnosuke/go-brave-search", repository)
+
+	assert.Equal(t, CodeResultIssue, results[1].Type)
+	assert.Equal(t, 12, results[1].Number)
+
+	assert.Equal(t, CodeResultQuestion, results[2].Type)
+	assert.Equal(t, 12345, results[2].QuestionID)
+	assert.Equal(t, "2 years ago", results[2].Age)
+	_, ok = results[2].Repository()
+	assert.False(t, ok)
+
+	_, err = client.WebSearchCode(context.Background(), "go retry", &CodeSearchOptions{Domains: []string{"Codeberg.org."}})
+	require.NoError(t, err)
+	assert.Equal(t, "go retry site:codeberg.org", query.Get("q"))
+}
+
+// TestWebSearchCodeInvalid tests rejecting invalid code searches
+func TestWebSearchCodeInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.WebSearchCode(context.Background(), "go retry", &CodeSearchOptions{Domains: []string{"https://github.com"}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.WebSearchCode(context.Background(), " ", nil)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+}
+
+// TestParseCodeResult tests parsing repositories, issues and questions from URLs
+func TestParseCodeResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		typ        CodeResultType
+		owner      string
+		repo       string
+		number     int
+		questionID int
+	}{
+		{"repository", "https://github.com/golang/go", CodeResultRepository, "golang", "go", 0, 0},
+		{"repository file", "https://github.com/golang/go/blob/master/README.md", CodeResultRepository, "golang", "go", 0, 0},
+		{"clone url", "https://github.com/golang/go.git", CodeResultRepository, "golang", "go", 0, 0},
+		{"pull request", "https://github.com/golang/go/pull/42", CodeResultPullRequest, "golang", "go", 42, 0},
+		{"issue list", "https://github.com/golang/go/issues", CodeResultRepository, "golang", "go", 0, 0},
+		{"reserved path", "https://github.com/topics/golang", CodeResultPage, "", "", 0, 0},
+		{"code host page", "https://github.com/pricing", CodeResultPage, "", "", 0, 0},
+		{"gitlab merge request", "https://gitlab.com/group/sub/project/-/merge_requests/7", CodeResultPullRequest, "group/sub", "project", 7, 0},
+		{"gitlab issue", "https://gitlab.com/group/project/-/issues/3", CodeResultIssue, "group", "project", 3, 0},
+		{"question", "https://unix.stackexchange.com/questions/99/title", CodeResultQuestion, "", "", 0, 99},
+		{"question short link", "https://stackoverflow.com/q/123", CodeResultQuestion, "", "", 0, 123},
+		{"question list", "https://stackoverflow.com/questions/tagged/go", CodeResultPage, "", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := parseCodeResult(SearchResult{URL: tt.url})
+			require.True(t, ok)
+			assert.Equal(t, tt.typ, code.Type)
+			assert.Equal(t, tt.owner, code.Owner)
+			assert.Equal(t, tt.repo, code.Repo)
+			assert.Equal(t, tt.number, code.Number)
+			assert.Equal(t, tt.questionID, code.QuestionID)
+		})
+	}
+
+	_, ok := parseCodeResult(SearchResult{URL: "://"})
+	assert.False(t, ok)
+}
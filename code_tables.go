@@ -0,0 +1,221 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CodeTables are the country and language codes the API accepts, and the
+// aliases that are mapped to them
+type CodeTables struct {
+	// Countries are the supported country codes, e.g. "GB"
+	Countries []string `json:"countries,omitempty"`
+
+	// SearchLangs are the supported search language codes, e.g. "jp"
+	SearchLangs []string `json:"search_langs,omitempty"`
+
+	// UILangs are the supported UI language codes, e.g. "ja-JP"
+	UILangs []string `json:"ui_langs,omitempty"`
+
+	// CountryAliases maps upper-case country codes to supported ones, e.g. "UK" to "GB"
+	CountryAliases map[string]string `json:"country_aliases,omitempty"`
+
+	// SearchLangAliases maps lower-case language codes to supported search languages, e.g. "ja" to "jp"
+	SearchLangAliases map[string]string `json:"search_lang_aliases,omitempty"`
+}
+
+// DefaultCodeTables returns a copy of the codes bundled with the library
+func DefaultCodeTables() *CodeTables {
+	return &CodeTables{
+		Countries:         append([]string(nil), countryCodes...),
+		SearchLangs:       append([]string(nil), searchLangCodes...),
+		UILangs:           append([]string(nil), uiLangCodes...),
+		CountryAliases:    maps.Clone(countryAliases),
+		SearchLangAliases: maps.Clone(searchLangAliases),
+	}
+}
+
+// CodeTableSource loads the country and language codes to validate against,
+// e.g. from a dataset that is updated without rebuilding the client. Code
+// lists the source leaves empty fall back to the bundled codes, and its
+// aliases are added to the bundled aliases.
+type CodeTableSource interface {
+	LoadCodeTables(ctx context.Context) (*CodeTables, error)
+}
+
+// CodeTableSourceFunc adapts a function to the CodeTableSource interface
+type CodeTableSourceFunc func(ctx context.Context) (*CodeTables, error)
+
+// LoadCodeTables calls f(ctx)
+func (f CodeTableSourceFunc) LoadCodeTables(ctx context.Context) (*CodeTables, error) {
+	return f(ctx)
+}
+
+// codeTables are CodeTables prepared for lookups
+type codeTables struct {
+	countries         codeSet
+	searchLangs       codeSet
+	uiLangs           codeSet
+	countryAliases    map[string]string
+	searchLangAliases map[string]string
+}
+
+// bundledCodeTables returns the codes bundled with the library, prepared on first use
+var bundledCodeTables = sync.OnceValue(func() *codeTables {
+	tables, err := compileCodeTables(nil)
+	if err != nil {
+		panic(err)
+	}
+	return tables
+})
+
+// compileCodeTables prepares overrides on top of the bundled codes for
+// lookups. Aliases must map to supported codes.
+func compileCodeTables(overrides *CodeTables) (*codeTables, error) {
+	merged := DefaultCodeTables()
+	if overrides != nil {
+		if len(overrides.Countries) > 0 {
+			merged.Countries = overrides.Countries
+		}
+		if len(overrides.SearchLangs) > 0 {
+			merged.SearchLangs = overrides.SearchLangs
+		}
+		if len(overrides.UILangs) > 0 {
+			merged.UILangs = overrides.UILangs
+		}
+		maps.Copy(merged.CountryAliases, overrides.CountryAliases)
+		maps.Copy(merged.SearchLangAliases, overrides.SearchLangAliases)
+	}
+
+	tables := &codeTables{
+		countries:         newCodeSet(merged.Countries...),
+		searchLangs:       newCodeSet(merged.SearchLangs...),
+		uiLangs:           newCodeSet(merged.UILangs...),
+		countryAliases:    make(map[string]string, len(merged.CountryAliases)),
+		searchLangAliases: make(map[string]string, len(merged.SearchLangAliases)),
+	}
+	for alias, code := range merged.CountryAliases {
+		if _, ok := tables.countries.canonical(code); !ok {
+			return nil, fmt.Errorf("%w: country alias %q maps to unsupported code %q", ErrInvalidParameters, alias, code)
+		}
+		tables.countryAliases[strings.ToUpper(alias)] = code
+	}
+	for alias, code := range merged.SearchLangAliases {
+		if _, ok := tables.searchLangs.canonical(code); !ok {
+			return nil, fmt.Errorf("%w: search language alias %q maps to unsupported code %q", ErrInvalidParameters, alias, code)
+		}
+		tables.searchLangAliases[strings.ToLower(alias)] = code
+	}
+	return tables, nil
+}
+
+// normalizeCountry maps a country code to the form the API expects
+func (t *codeTables) normalizeCountry(code string) (string, bool) {
+	return normalizeCode(code, t.countries, t.countryAliases, strings.ToUpper)
+}
+
+// normalizeSearchLang maps a search language code to the form the API expects
+func (t *codeTables) normalizeSearchLang(code string) (string, bool) {
+	return normalizeCode(code, t.searchLangs, t.searchLangAliases, strings.ToLower)
+}
+
+// normalizeUILang maps a UI language code to the form the API expects
+func (t *codeTables) normalizeUILang(code string) (string, bool) {
+	return normalizeCode(strings.ReplaceAll(code, "_", "-"), t.uiLangs, nil, func(s string) string { return s })
+}
+
+// codeTablesRetryInterval is how long the bundled codes are used after the
+// CodeTableSource failed to load before it is tried again
+const codeTablesRetryInterval = time.Minute
+
+// codeTableCache holds the code tables of a client, loaded from its
+// CodeTableSource on first use and replaced by RefreshCodeTables
+type codeTableCache struct {
+	mu     sync.Mutex
+	tables atomic.Pointer[codeTables]
+
+	// retryAt is when to load the source again after it failed
+	retryAt time.Time
+}
+
+// codeTables returns the code tables to validate against. Without a
+// CodeTableSource these are the bundled codes. The source is loaded on first
+// use, independently of the cancellation of ctx but bounded by the client
+// timeout, if any; if that fails the bundled codes are used, with a warning,
+// and the source is tried again after codeTablesRetryInterval. The source is
+// loaded once: concurrent requests wait for the load, so a slow source
+// delays the first requests of the client.
+func (c *Client) codeTables(ctx context.Context) *codeTables {
+	source := c.config.CodeTableSource
+	if source == nil {
+		return bundledCodeTables()
+	}
+	if tables := c.codes.tables.Load(); tables != nil {
+		return tables
+	}
+
+	c.codes.mu.Lock()
+	defer c.codes.mu.Unlock()
+	if tables := c.codes.tables.Load(); tables != nil {
+		return tables
+	}
+	if time.Now().Before(c.codes.retryAt) {
+		return bundledCodeTables()
+	}
+
+	// The codes serve every later request, so a canceled request must not
+	// fail the load
+	ctx = context.WithoutCancel(ctx)
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+	tables, err := loadCodeTables(ctx, source)
+	if err != nil {
+		c.warn(Warning{
+			Code:    WarningCodeCodeTables,
+			Message: fmt.Sprintf("using bundled country and language codes: %v", err),
+		})
+		c.codes.retryAt = time.Now().Add(codeTablesRetryInterval)
+		return bundledCodeTables()
+	}
+	c.codes.tables.Store(tables)
+	return tables
+}
+
+// RefreshCodeTables reloads the country and language codes from the
+// CodeTableSource (see WithCodeTableSource), so validation follows changes to
+// the dataset without rebuilding the client. Requests in flight keep the
+// codes they started with. If loading fails the current codes stay in use
+// and the error is returned. Without a CodeTableSource it does nothing.
+func (c *Client) RefreshCodeTables(ctx context.Context) error {
+	source := c.config.CodeTableSource
+	if source == nil {
+		return nil
+	}
+
+	c.codes.mu.Lock()
+	defer c.codes.mu.Unlock()
+
+	tables, err := loadCodeTables(ctx, source)
+	if err != nil {
+		return err
+	}
+	c.codes.tables.Store(tables)
+	return nil
+}
+
+// loadCodeTables loads and prepares the code tables of source
+func loadCodeTables(ctx context.Context, source CodeTableSource) (*codeTables, error) {
+	overrides, err := source.LoadCodeTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load code tables: %w", err)
+	}
+	return compileCodeTables(overrides)
+}
@@ -0,0 +1,218 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompileCodeTables tests preparing code tables on top of the bundled codes
+func TestCompileCodeTables(t *testing.T) {
+	tables, err := compileCodeTables(&CodeTables{
+		Countries:         []string{"GB", "IE"},
+		SearchLangAliases: map[string]string{"EN-IE": "en-gb"},
+	})
+	require.NoError(t, err)
+
+	// Overridden countries replace the bundled ones
+	normalized, ok := tables.normalizeCountry("ie")
+	assert.True(t, ok)
+	assert.Equal(t, "IE", normalized)
+	_, ok = tables.normalizeCountry("JP")
+	assert.False(t, ok)
+
+	// Bundled aliases are kept and new ones added
+	normalized, ok = tables.normalizeCountry("uk")
+	assert.True(t, ok)
+	assert.Equal(t, "GB", normalized)
+	normalized, ok = tables.normalizeSearchLang("en-IE")
+	assert.True(t, ok)
+	assert.Equal(t, "en-gb", normalized)
+
+	// Empty lists fall back to the bundled codes
+	normalized, ok = tables.normalizeUILang("ja_jp")
+	assert.True(t, ok)
+	assert.Equal(t, "ja-JP", normalized)
+}
+
+// TestCompileCodeTablesInvalidAlias tests rejecting aliases to unsupported codes
+func TestCompileCodeTablesInvalidAlias(t *testing.T) {
+	_, err := compileCodeTables(&CodeTables{CountryAliases: map[string]string{"EL": "GR"}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.Contains(t, err.Error(), `country alias "EL" maps to unsupported code "GR"`)
+
+	// The bundled alias "UK" maps to "GB", which the overrides drop
+	_, err = compileCodeTables(&CodeTables{Countries: []string{"US"}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// TestDefaultCodeTables tests that the bundled codes are returned as a copy
+func TestDefaultCodeTables(t *testing.T) {
+	tables := DefaultCodeTables()
+	assert.Contains(t, tables.Countries, "US")
+	assert.Equal(t, "jp", tables.SearchLangAliases["ja"])
+
+	tables.Countries[0] = "XX"
+	tables.CountryAliases["UK"] = "XX"
+	assert.NotEqual(t, "XX", DefaultCodeTables().Countries[0])
+	assert.Equal(t, "GB", DefaultCodeTables().CountryAliases["UK"])
+}
+
+// TestCodeTableSourceLazy tests that the source is loaded once, on first use
+func TestCodeTableSourceLazy(t *testing.T) {
+	var loads atomic.Int32
+	source := CodeTableSourceFunc(func(ctx context.Context) (*CodeTables, error) {
+		loads.Add(1)
+		return &CodeTables{Countries: []string{"ALL", "GB", "US", "XK"}}, nil
+	})
+
+	client, err := NewClient("test-api-key", WithCodeTableSource(source), WithStrictCodes(true))
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), loads.Load())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			params := &WebSearchParams{Country: "xk"}
+			assert.NoError(t, client.normalizeSearchCodes(context.Background(), params))
+			assert.Equal(t, "XK", params.Country)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), loads.Load())
+
+	// The package-level helpers keep using the bundled codes
+	assert.False(t, IsSupportedCountry("XK"))
+}
+
+// TestCodeTableSourceFallback tests falling back to the bundled codes when the source fails
+func TestCodeTableSourceFallback(t *testing.T) {
+	var warnings []Warning
+	fail := true
+	source := CodeTableSourceFunc(func(ctx context.Context) (*CodeTables, error) {
+		if fail {
+			return nil, errors.New("dataset unavailable")
+		}
+		return &CodeTables{Countries: []string{"ALL", "GB", "US", "XK"}}, nil
+	})
+
+	client, err := NewClient("test-api-key",
+		WithCodeTableSource(source),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	require.NoError(t, err)
+
+	tables := client.codeTables(context.Background())
+	assert.Same(t, bundledCodeTables(), tables)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodeCodeTables, warnings[0].Code)
+	assert.Contains(t, warnings[0].Message, "dataset unavailable")
+
+	// The failure is not retried on every request
+	client.codeTables(context.Background())
+	assert.Len(t, warnings, 1)
+
+	// A failed refresh keeps the current codes
+	err = client.RefreshCodeTables(context.Background())
+	assert.ErrorContains(t, err, "dataset unavailable")
+	assert.Same(t, tables, client.codeTables(context.Background()))
+
+	// The bundled codes are not cached, so the source is tried again later
+	fail = false
+	client.codes.retryAt = time.Now()
+	_, ok := client.codeTables(context.Background()).normalizeCountry("XK")
+	assert.True(t, ok)
+	assert.Len(t, warnings, 1)
+}
+
+// TestCodeTableSourceCanceledRequest tests that the first load does not
+// depend on the cancellation of the request that triggers it
+func TestCodeTableSourceCanceledRequest(t *testing.T) {
+	source := CodeTableSourceFunc(func(ctx context.Context) (*CodeTables, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return &CodeTables{Countries: []string{"ALL", "GB", "US", "XK"}}, nil
+	})
+
+	client, err := NewClient("test-api-key", WithCodeTableSource(source))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, ok := client.codeTables(ctx).normalizeCountry("XK")
+	assert.True(t, ok)
+}
+
+// TestCodeTableSourceNoTimeout tests loading the source with a client without timeout
+func TestCodeTableSourceNoTimeout(t *testing.T) {
+	source := CodeTableSourceFunc(func(ctx context.Context) (*CodeTables, error) {
+		if _, ok := ctx.Deadline(); ok {
+			return nil, errors.New("unexpected deadline")
+		}
+		return &CodeTables{Countries: []string{"ALL", "GB", "US", "XK"}}, nil
+	})
+
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithTimeout(0),
+		WithCodeTableSource(source),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	require.NoError(t, err)
+
+	_, ok := client.codeTables(context.Background()).normalizeCountry("XK")
+	assert.True(t, ok)
+	assert.Empty(t, warnings)
+}
+
+// TestRefreshCodeTables tests replacing the codes without rebuilding the client
+func TestRefreshCodeTables(t *testing.T) {
+	countries := []string{"ALL", "GB", "US"}
+	source := CodeTableSourceFunc(func(ctx context.Context) (*CodeTables, error) {
+		return &CodeTables{Countries: countries}, nil
+	})
+
+	client, err := NewClient("test-api-key", WithCodeTableSource(source), WithStrictCodes(true))
+	require.NoError(t, err)
+
+	err = client.normalizeSearchCodes(context.Background(), &WebSearchParams{Country: "XK"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	countries = append(countries, "XK")
+	require.NoError(t, client.RefreshCodeTables(context.Background()))
+	assert.NoError(t, client.normalizeSearchCodes(context.Background(), &WebSearchParams{Country: "XK"}))
+
+	// An invalid dataset is rejected
+	countries = []string{"US"}
+	err = client.RefreshCodeTables(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.NoError(t, client.normalizeSearchCodes(context.Background(), &WebSearchParams{Country: "XK"}))
+}
+
+// TestRefreshCodeTablesWithoutSource tests that refreshing without a source does nothing
+func TestRefreshCodeTablesWithoutSource(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	assert.NoError(t, client.RefreshCodeTables(context.Background()))
+	assert.Same(t, bundledCodeTables(), client.codeTables(context.Background()))
+}
+
+// TestWithCodeTableSource tests the option
+func TestWithCodeTableSource(t *testing.T) {
+	config := &ClientConfig{}
+	assert.ErrorIs(t, WithCodeTableSource(nil)(config), ErrInvalidParameters)
+
+	source := CodeTableSourceFunc(func(ctx context.Context) (*CodeTables, error) { return nil, nil })
+	require.NoError(t, WithCodeTableSource(source)(config))
+	assert.NotNil(t, config.CodeTableSource)
+}
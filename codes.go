@@ -0,0 +1,270 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Warning is a non-fatal problem noticed by the client, such as an input
+// that was corrected before sending
+type Warning struct {
+	// Code identifies the kind of warning
+	Code string
+
+	// Message describes the warning
+	Message string
+}
+
+// Warning codes
+const (
+	// WarningCodeAlias is reported when a country or language code was mapped to the code the API expects
+	WarningCodeAlias = "code_alias"
+
+	// WarningCodeUnsupported is reported when a country or language code is not known to be supported
+	WarningCodeUnsupported = "unsupported_code"
+
+	// WarningCodeEscalationFailed is reported when the follow-up request for breaking news failed
+	WarningCodeEscalationFailed = "escalation_failed"
+
+	// WarningCodeRateLimitStore is reported when the RateLimitStore could not be updated
+	WarningCodeRateLimitStore = "rate_limit_store"
+
+	// WarningCodeAuditFailed is reported when an audit record could not be written
+	WarningCodeAuditFailed = "audit_failed"
+
+	// WarningCodePreviewFailed is reported when result previews could not be rendered
+	WarningCodePreviewFailed = "preview_failed"
+
+	// WarningCodeURLCheckFailed is reported when result URLs could not be checked
+	WarningCodeURLCheckFailed = "url_check_failed"
+
+	// WarningCodeDeprecated is reported when the API announces that an endpoint is deprecated or will be sunset
+	WarningCodeDeprecated = "deprecated"
+
+	// WarningCodeDecodeFailed is reported in soft-fail mode when a section of a response could not be decoded
+	WarningCodeDecodeFailed = "decode_failed"
+
+	// WarningCodeProfileSearchFailed is reported when FindProfiles could not search a platform
+	WarningCodeProfileSearchFailed = "profile_search_failed"
+
+	// WarningCodeCodeTables is reported when the CodeTableSource could not be loaded and the bundled codes are used
+	WarningCodeCodeTables = "code_tables"
+)
+
+// WarningHandler receives warnings. It is called synchronously from the
+// goroutine making the request and must be safe for concurrent use.
+type WarningHandler func(Warning)
+
+// countryCodes are the country codes accepted by the API
+var countryCodes = []string{
+	"ALL", "AR", "AU", "AT", "BE", "BR", "CA", "CL", "DK", "FI", "FR", "DE",
+	"HK", "IN", "ID", "IT", "JP", "KR", "MY", "MX", "NL", "NZ", "NO", "CN",
+	"PL", "PT", "PH", "RU", "SA", "ZA", "ES", "SE", "CH", "TW", "TR", "GB",
+	"US",
+}
+
+// searchLangCodes are the search language codes accepted by the API.
+// Note that Japanese is "jp", not "ja".
+var searchLangCodes = []string{
+	"ar", "eu", "bn", "bg", "ca", "zh-hans", "zh-hant", "hr", "cs", "da", "nl",
+	"en", "en-gb", "et", "fi", "fr", "gl", "de", "gu", "he", "hi", "hu", "is",
+	"it", "jp", "kn", "ko", "lv", "lt", "ms", "ml", "mr", "nb", "pl", "pt-br",
+	"pt-pt", "pa", "ro", "ru", "sr", "sk", "sl", "es", "sv", "ta", "te", "th",
+	"tr", "uk", "vi",
+}
+
+// uiLangCodes are the UI language codes accepted by the API
+var uiLangCodes = []string{
+	"es-AR", "en-AU", "de-AT", "nl-BE", "fr-BE", "pt-BR", "en-CA", "fr-CA",
+	"es-CL", "da-DK", "fi-FI", "fr-FR", "de-DE", "zh-HK", "en-IN", "en-ID",
+	"it-IT", "ja-JP", "ko-KR", "en-MY", "es-MX", "nl-NL", "en-NZ", "no-NO",
+	"zh-CN", "pl-PL", "en-PH", "ru-RU", "en-ZA", "es-ES", "sv-SE", "fr-CH",
+	"de-CH", "zh-TW", "tr-TR", "en-GB", "en-US", "es-US",
+}
+
+// uiLangMatcher finds the closest supported UI language for a locale
+var uiLangMatcher, uiLangMatches = newUILangMatcher()
+
+// newUILangMatcher creates a matcher over the supported UI languages and the
+// codes its matches map to. en-US comes first so it is preferred for English
+// and as the fallback, and Norwegian Bokmål maps to the API's "no-NO".
+func newUILangMatcher() (language.Matcher, []string) {
+	codes := append([]string{"en-US"}, uiLangCodes...)
+	tags := make([]language.Tag, 0, len(codes)+1)
+	for _, code := range codes {
+		tags = append(tags, language.MustParse(code))
+	}
+	tags = append(tags, language.MustParse("nb-NO"))
+	codes = append(codes, "no-NO")
+	return language.NewMatcher(tags), codes
+}
+
+// countryAliases maps common country codes to the ones the API expects
+var countryAliases = map[string]string{
+	"UK": "GB",
+}
+
+// searchLangAliases maps common (mostly ISO 639-1 and BCP 47) language codes
+// to the ones the API expects
+var searchLangAliases = map[string]string{
+	"ja":    "jp",
+	"zh":    "zh-hans",
+	"zh-cn": "zh-hans",
+	"zh-sg": "zh-hans",
+	"zh-tw": "zh-hant",
+	"zh-hk": "zh-hant",
+	"no":    "nb",
+	"iw":    "he",
+	"en-us": "en",
+	"en-uk": "en-gb",
+	"pt":    "pt-br",
+	"ja-jp": "jp",
+	"ko-kr": "ko",
+	"de-de": "de",
+	"fr-fr": "fr",
+	"es-es": "es",
+}
+
+// codeSet is a case-insensitive set of codes, remembering their canonical form
+type codeSet map[string]string
+
+// newCodeSet creates a codeSet from canonical codes
+func newCodeSet(codes ...string) codeSet {
+	set := make(codeSet, len(codes))
+	for _, code := range codes {
+		set[strings.ToLower(code)] = code
+	}
+	return set
+}
+
+// canonical returns the canonical form of code, if it is in the set
+func (s codeSet) canonical(code string) (string, bool) {
+	canonical, ok := s[strings.ToLower(code)]
+	return canonical, ok
+}
+
+// IsSupportedCountry reports whether the API accepts the country code (case-insensitive)
+func IsSupportedCountry(code string) bool {
+	_, ok := bundledCodeTables().countries.canonical(code)
+	return ok
+}
+
+// IsSupportedSearchLang reports whether the API accepts the search language code (case-insensitive)
+func IsSupportedSearchLang(code string) bool {
+	_, ok := bundledCodeTables().searchLangs.canonical(code)
+	return ok
+}
+
+// IsSupportedUILang reports whether the API accepts the UI language code (case-insensitive)
+func IsSupportedUILang(code string) bool {
+	_, ok := bundledCodeTables().uiLangs.canonical(code)
+	return ok
+}
+
+// NormalizeCountry maps a country code to the form the API expects, e.g.
+// "uk" to "GB". The boolean is false if the code is not supported.
+func NormalizeCountry(code string) (string, bool) {
+	return bundledCodeTables().normalizeCountry(code)
+}
+
+// NormalizeSearchLang maps a search language code to the form the API
+// expects, e.g. "ja" to "jp". The boolean is false if the code is not supported.
+func NormalizeSearchLang(code string) (string, bool) {
+	return bundledCodeTables().normalizeSearchLang(code)
+}
+
+// NormalizeUILang maps a UI language code to the form the API expects, e.g.
+// "ja_jp" to "ja-JP". The boolean is false if the code is not supported.
+func NormalizeUILang(code string) (string, bool) {
+	return bundledCodeTables().normalizeUILang(code)
+}
+
+// UILangFromLocale returns the supported UI language code closest to a
+// BCP 47 locale, e.g. "ja-JP" for language.Japanese. It returns an error
+// wrapping ErrInvalidParameters if no supported UI language matches.
+func UILangFromLocale(tag language.Tag) (string, error) {
+	if tag.IsRoot() {
+		return "", fmt.Errorf("%w: locale is undefined", ErrInvalidParameters)
+	}
+
+	_, index, confidence := uiLangMatcher.Match(tag)
+	if confidence < language.High {
+		return "", fmt.Errorf("%w: no supported UI language matches locale %q", ErrInvalidParameters, tag)
+	}
+	return uiLangMatches[index], nil
+}
+
+// normalizeCode returns the canonical form of code, resolving aliases
+func normalizeCode(code string, supported codeSet, aliases map[string]string, fold func(string) string) (string, bool) {
+	code = strings.TrimSpace(code)
+	if canonical, ok := supported.canonical(code); ok {
+		return canonical, true
+	}
+	if alias, ok := aliases[fold(code)]; ok {
+		return alias, true
+	}
+	return code, false
+}
+
+// codeField is a country or language code parameter to normalize
+type codeField struct {
+	name      string
+	value     *string
+	normalize func(string) (string, bool)
+}
+
+// normalizeSearchCodes maps the country and language codes of params to the
+// forms the API expects
+func (c *Client) normalizeSearchCodes(ctx context.Context, params *WebSearchParams) error {
+	tables := c.codeTables(ctx)
+	return c.normalizeCodes(
+		codeField{"country", &params.Country, tables.normalizeCountry},
+		codeField{"search language", &params.SearchLang, tables.normalizeSearchLang},
+		codeField{"UI language", &params.UILang, tables.normalizeUILang},
+	)
+}
+
+// normalizeCodes maps codes to the forms the API expects, reporting
+// corrections and unsupported codes as warnings. In strict mode unsupported
+// codes are an error.
+func (c *Client) normalizeCodes(fields ...codeField) error {
+	for _, field := range fields {
+		if *field.value == "" {
+			continue
+		}
+
+		normalized, ok := field.normalize(*field.value)
+		if !ok {
+			if c.config.StrictCodes {
+				return fmt.Errorf("%w: unsupported %s %q", ErrInvalidParameters, field.name, *field.value)
+			}
+			c.warn(Warning{
+				Code:    WarningCodeUnsupported,
+				Message: fmt.Sprintf("%s %q is not known to be supported by the API", field.name, *field.value),
+			})
+			continue
+		}
+
+		if normalized != *field.value {
+			if !strings.EqualFold(normalized, *field.value) {
+				c.warn(Warning{
+					Code:    WarningCodeAlias,
+					Message: fmt.Sprintf("%s %q was mapped to %q", field.name, *field.value, normalized),
+				})
+			}
+			*field.value = normalized
+		}
+	}
+
+	return nil
+}
+
+// warn reports a warning to the configured handler, if any
+func (c *Client) warn(warning Warning) {
+	if c.config.WarningHandler != nil {
+		c.config.WarningHandler(warning)
+	}
+}
@@ -0,0 +1,150 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+// TestNormalizeCodes tests mapping codes to the forms the API expects
+func TestNormalizeCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize func(string) (string, bool)
+		input     string
+		expected  string
+		supported bool
+	}{
+		{"country", NormalizeCountry, "JP", "JP", true},
+		{"country lower case", NormalizeCountry, "jp", "JP", true},
+		{"country alias", NormalizeCountry, "uk", "GB", true},
+		{"country all", NormalizeCountry, "all", "ALL", true},
+		{"country unsupported", NormalizeCountry, "XX", "XX", false},
+		{"search lang", NormalizeSearchLang, "jp", "jp", true},
+		{"search lang alias", NormalizeSearchLang, "ja", "jp", true},
+		{"search lang BCP 47", NormalizeSearchLang, "zh-TW", "zh-hant", true},
+		{"search lang case", NormalizeSearchLang, "EN-GB", "en-gb", true},
+		{"search lang unsupported", NormalizeSearchLang, "tlh", "tlh", false},
+		{"ui lang", NormalizeUILang, "ja-JP", "ja-JP", true},
+		{"ui lang case", NormalizeUILang, "ja-jp", "ja-JP", true},
+		{"ui lang underscore", NormalizeUILang, "ja_JP", "ja-JP", true},
+		{"ui lang unsupported", NormalizeUILang, "ja", "ja", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, ok := tt.normalize(tt.input)
+			assert.Equal(t, tt.expected, normalized)
+			assert.Equal(t, tt.supported, ok)
+		})
+	}
+}
+
+// TestIsSupportedCodes tests the support checks
+func TestIsSupportedCodes(t *testing.T) {
+	assert.True(t, IsSupportedCountry(DefaultCountry))
+	assert.True(t, IsSupportedSearchLang(DefaultSearchLang))
+	assert.True(t, IsSupportedUILang(DefaultUILang))
+
+	assert.False(t, IsSupportedCountry("UK"))
+	assert.False(t, IsSupportedSearchLang("ja"))
+	assert.False(t, IsSupportedUILang("en"))
+}
+
+// TestWebSearchCodeNormalization tests that codes are normalized with warnings
+func TestWebSearchCodeNormalization(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{
+			"country":     r.URL.Query().Get("country"),
+			"search_lang": r.URL.Query().Get("search_lang"),
+			"ui_lang":     r.URL.Query().Get("ui_lang"),
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithDefaultCountry("UK"),
+		WithWarningHandler(func(w Warning) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, w)
+		}),
+	)
+	require.NoError(t, err)
+
+	params := &WebSearchParams{SearchLang: "ja", UILang: "xx-YY"}
+	_, err = client.WebSearch(context.Background(), "ramen", params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "GB", query["country"])
+	assert.Equal(t, "jp", query["search_lang"])
+	assert.Equal(t, "xx-YY", query["ui_lang"]) // sent anyway
+
+	require.Len(t, warnings, 3)
+	assert.Equal(t, WarningCodeAlias, warnings[0].Code)
+	assert.Contains(t, warnings[0].Message, `country "UK" was mapped to "GB"`)
+	assert.Equal(t, WarningCodeAlias, warnings[1].Code)
+	assert.Equal(t, WarningCodeUnsupported, warnings[2].Code)
+
+	// The caller's params are not modified
+	assert.Equal(t, "ja", params.SearchLang)
+}
+
+// TestWithStrictCodes tests rejecting unsupported codes
+func TestWithStrictCodes(t *testing.T) {
+	client, err := NewClient("test-api-key", WithStrictCodes(true))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "ramen", &WebSearchParams{Country: "XX"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.Contains(t, err.Error(), `unsupported country "XX"`)
+}
+
+// TestUILangFromLocale tests mapping BCP 47 locales to UI language codes
+func TestUILangFromLocale(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"ja-JP", "ja-JP"},
+		{"ja", "ja-JP"},
+		{"en", "en-US"},
+		{"en-GB", "en-GB"},
+		{"en-150", "en-GB"},
+		{"fr-CA", "fr-CA"},
+		{"zh-Hant-HK", "zh-HK"},
+		{"es-419", "es-AR"},
+		{"nb", "no-NO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			uiLang, err := UILangFromLocale(language.MustParse(tt.locale))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, uiLang)
+			assert.True(t, IsSupportedUILang(uiLang))
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := UILangFromLocale(language.Arabic)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("undefined", func(t *testing.T) {
+		_, err := UILangFromLocale(language.Und)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
@@ -0,0 +1,156 @@
+package bravesearch
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// compressionMinSamples is the number of gzipped responses of an
+	// endpoint measured before adaptive compression decides on it
+	compressionMinSamples = 5
+
+	// compressionProbeInterval is how often an endpoint that gets
+	// uncompressed responses requests gzip again, to keep its stats current
+	compressionProbeInterval = 50
+
+	// compressionByteCost is the assumed transfer time per byte (about
+	// 10 MB/s), which weighs the bytes gzip saves against its decompression time
+	compressionByteCost = 100 * time.Nanosecond
+)
+
+// CompressionStat describes the cost and benefit of gzip for the responses
+// of an endpoint. Stats are JSON-serializable, so they can be persisted and
+// passed to WithAdaptiveCompression to reuse them across restarts.
+type CompressionStat struct {
+	// Endpoint is the path of the endpoint
+	Endpoint string `json:"endpoint"`
+
+	// Responses is the number of gzipped responses measured
+	Responses int `json:"responses"`
+
+	// CompressedBytes is the total size of the gzipped responses on the wire
+	CompressedBytes int64 `json:"compressed_bytes"`
+
+	// DecompressedBytes is the total size of the gzipped responses once decompressed
+	DecompressedBytes int64 `json:"decompressed_bytes"`
+
+	// DecompressTime is the total time spent decompressing
+	DecompressTime time.Duration `json:"decompress_time"`
+
+	// Gzip reports whether gzip is requested for the endpoint. It is false
+	// once adaptive compression found that gzip costs more than it saves.
+	Gzip bool `json:"gzip"`
+
+	// skipped counts the requests made without gzip since the last probe
+	skipped int
+}
+
+// Ratio returns the average compressed size as a fraction of the
+// decompressed size, or 1 if nothing was measured
+func (s CompressionStat) Ratio() float64 {
+	if s.DecompressedBytes == 0 {
+		return 1
+	}
+	return float64(s.CompressedBytes) / float64(s.DecompressedBytes)
+}
+
+// worthwhile reports whether the transfer time gzip saves exceeds the time
+// spent decompressing, judging by the stats measured so far
+func (s CompressionStat) worthwhile() bool {
+	if s.Responses < compressionMinSamples {
+		return true
+	}
+	saved := time.Duration(s.DecompressedBytes-s.CompressedBytes) * compressionByteCost
+	return saved > s.DecompressTime
+}
+
+// bodyStats describes how a response body was transferred
+type bodyStats struct {
+	wireBytes      int
+	gzipped        bool
+	decompressTime time.Duration
+}
+
+// compressionTracker measures gzip per endpoint and, if adaptive, decides
+// whether to request it
+type compressionTracker struct {
+	adaptive bool
+
+	mu    sync.Mutex
+	stats map[string]*CompressionStat
+}
+
+// newCompressionTracker creates a tracker, seeded with previously persisted stats
+func newCompressionTracker(adaptive bool, seed []CompressionStat) *compressionTracker {
+	t := &compressionTracker{adaptive: adaptive, stats: make(map[string]*CompressionStat)}
+	for _, stat := range seed {
+		stat.skipped = 0
+		stat.Gzip = !adaptive || stat.worthwhile()
+		t.stats[stat.Endpoint] = &stat
+	}
+	return t
+}
+
+// acceptEncoding returns the Accept-Encoding to request endpoint with
+func (t *compressionTracker) acceptEncoding(endpoint string) string {
+	if !t.adaptive {
+		return MIMETypeGzip
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stat, ok := t.stats[endpoint]
+	if !ok || stat.Gzip {
+		return MIMETypeGzip
+	}
+	stat.skipped++
+	if stat.skipped%compressionProbeInterval == 0 {
+		return MIMETypeGzip
+	}
+	return MIMETypeIdentity
+}
+
+// record adds the measurements of a response from endpoint
+func (t *compressionTracker) record(endpoint string, decompressedBytes int, body bodyStats) {
+	if !body.gzipped {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stat, ok := t.stats[endpoint]
+	if !ok {
+		stat = &CompressionStat{Endpoint: endpoint, Gzip: true}
+		t.stats[endpoint] = stat
+	}
+	stat.Responses++
+	stat.CompressedBytes += int64(body.wireBytes)
+	stat.DecompressedBytes += int64(decompressedBytes)
+	stat.DecompressTime += body.decompressTime
+	stat.Gzip = !t.adaptive || stat.worthwhile()
+}
+
+// snapshot returns copies of the stats, ordered by endpoint
+func (t *compressionTracker) snapshot() []CompressionStat {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]CompressionStat, 0, len(t.stats))
+	for _, stat := range t.stats {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Endpoint < stats[j].Endpoint
+	})
+	return stats
+}
+
+// CompressionStats returns the gzip measurements per endpoint, including
+// whether adaptive compression currently requests gzip for each
+func (c *Client) CompressionStats() []CompressionStat {
+	return c.compression.snapshot()
+}
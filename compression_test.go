@@ -0,0 +1,117 @@
+package bravesearch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompressionTracker tests deciding on gzip per endpoint
+func TestCompressionTracker(t *testing.T) {
+	tracker := newCompressionTracker(true, nil)
+	assert.Equal(t, MIMETypeGzip, tracker.acceptEncoding(SuggestEndpoint))
+
+	// Small responses that take longer to decompress than they save
+	for range compressionMinSamples {
+		tracker.record(SuggestEndpoint, 120, bodyStats{wireBytes: 100, gzipped: true, decompressTime: time.Millisecond})
+	}
+	// Large responses that compress well
+	for range compressionMinSamples {
+		tracker.record(WebSearchEndpoint, 100000, bodyStats{wireBytes: 20000, gzipped: true, decompressTime: time.Millisecond})
+	}
+	// Uncompressed responses are not measured
+	tracker.record("/other", 100, bodyStats{wireBytes: 100})
+
+	assert.Equal(t, MIMETypeIdentity, tracker.acceptEncoding(SuggestEndpoint))
+	assert.Equal(t, MIMETypeGzip, tracker.acceptEncoding(WebSearchEndpoint))
+
+	stats := tracker.snapshot()
+	require.Len(t, stats, 2)
+	assert.Equal(t, SuggestEndpoint, stats[0].Endpoint)
+	assert.False(t, stats[0].Gzip)
+	assert.Equal(t, compressionMinSamples, stats[0].Responses)
+	assert.Equal(t, WebSearchEndpoint, stats[1].Endpoint)
+	assert.True(t, stats[1].Gzip)
+	assert.InDelta(t, 0.2, stats[1].Ratio(), 0.001)
+
+	// Skipped endpoints are probed now and then
+	probes := 0
+	for range compressionProbeInterval * 2 {
+		if tracker.acceptEncoding(SuggestEndpoint) == MIMETypeGzip {
+			probes++
+		}
+	}
+	assert.Equal(t, 2, probes)
+}
+
+// TestCompressionTrackerSeed tests reusing persisted stats
+func TestCompressionTrackerSeed(t *testing.T) {
+	persisted := []CompressionStat{{
+		Endpoint:          SuggestEndpoint,
+		Responses:         10,
+		CompressedBytes:   1000,
+		DecompressedBytes: 1200,
+		DecompressTime:    10 * time.Millisecond,
+		Gzip:              true,
+	}}
+	data, err := json.Marshal(persisted)
+	require.NoError(t, err)
+	var loaded []CompressionStat
+	require.NoError(t, json.Unmarshal(data, &loaded))
+
+	tracker := newCompressionTracker(true, loaded)
+	assert.Equal(t, MIMETypeIdentity, tracker.acceptEncoding(SuggestEndpoint))
+
+	// Without adaptive compression gzip is always requested
+	tracker = newCompressionTracker(false, loaded)
+	assert.Equal(t, MIMETypeGzip, tracker.acceptEncoding(SuggestEndpoint))
+	assert.True(t, tracker.snapshot()[0].Gzip)
+
+	assert.Equal(t, 1.0, CompressionStat{}.Ratio())
+}
+
+// TestAdaptiveCompression tests measuring gzipped responses through the client
+func TestAdaptiveCompression(t *testing.T) {
+	var identity atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := []byte(`{"type": "search", "web": {"results": [{"title": "` + strings.Repeat("go ", 1000) + `"}]}}`)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get(HeaderAcceptEncoding) != MIMETypeGzip {
+			identity.Add(1)
+			_, _ = w.Write(body)
+			return
+		}
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		_, _ = gz.Write(body)
+		require.NoError(t, gz.Close())
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithAdaptiveCompression(nil))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, response.GetWebResults(), 1)
+
+	stats := client.CompressionStats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, WebSearchEndpoint, stats[0].Endpoint)
+	assert.Equal(t, 1, stats[0].Responses)
+	assert.Less(t, stats[0].CompressedBytes, stats[0].DecompressedBytes)
+	assert.True(t, stats[0].Gzip)
+	assert.Equal(t, int32(0), identity.Load())
+}
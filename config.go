@@ -89,7 +89,8 @@ func WithConfig(config *Config) ClientOption {
 
 // ValidateConfig validates the client configuration
 func ValidateConfig(config *ClientConfig) error {
-	if config.APIKey == "" {
+	// A custom Authenticator may not need the API key
+	if config.APIKey == "" && config.Authenticator == nil {
 		return ErrMissingAPIKey
 	}
 	
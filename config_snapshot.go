@@ -0,0 +1,164 @@
+package bravesearch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfigSnapshot is a read-only view of the effective configuration of a
+// Client, after defaults were applied, with secrets masked. It can be logged
+// or compared (e.g. as JSON) to check what clients across services use.
+// Pluggable components are identified by their Go type.
+type ConfigSnapshot struct {
+	APIKey  string `json:"api_key"`
+	BaseURL string `json:"base_url"`
+
+	// EndpointOverrides maps the default paths of overridden endpoints to their overrides
+	EndpointOverrides map[string]string `json:"endpoint_overrides,omitempty"`
+
+	APIVersion           string        `json:"api_version,omitempty"`
+	Timeout              time.Duration `json:"timeout"`
+	MaxRetries           int           `json:"max_retries"`
+	UserAgent            string        `json:"user_agent"`
+	DefaultCountry       string        `json:"default_country"`
+	DefaultSearchLang    string        `json:"default_search_lang"`
+	DefaultUILang        string        `json:"default_ui_lang"`
+	NoDefaults           bool          `json:"no_defaults"`
+	NoRetention          bool          `json:"no_retention"`
+	QueryLimits          QueryLimits   `json:"query_limits"`
+	QueryNormalization   string        `json:"query_normalization"`
+	StrictCodes          bool          `json:"strict_codes"`
+	EscalateBreakingNews bool          `json:"escalate_breaking_news"`
+	StableResults        bool          `json:"stable_results"`
+	EmptyResultsError    bool          `json:"empty_results_error"`
+	SoftFail             bool          `json:"soft_fail"`
+	AdaptiveCompression  bool          `json:"adaptive_compression"`
+
+	// The HeaderPolicy, listing the names but not the values of its extra headers
+	TokenHeader         string   `json:"token_header"`
+	DisableCacheControl bool     `json:"disable_cache_control"`
+	Headers             []string `json:"headers,omitempty"`
+
+	HTTPClient         string `json:"http_client"`
+	CookieJar          bool   `json:"cookie_jar"`
+	Authenticator      string `json:"authenticator"`
+	RequestSigning     bool   `json:"request_signing"`
+	Logger             bool   `json:"logger"`
+	WarningHandler     bool   `json:"warning_handler"`
+	QueryScrubber      string `json:"query_scrubber,omitempty"`
+	LocationResolver   string `json:"location_resolver,omitempty"`
+	SourceRater        string `json:"source_rater,omitempty"`
+	Previewer          string `json:"previewer,omitempty"`
+	PreviewConcurrency int    `json:"preview_concurrency,omitempty"`
+	URLChecker         string `json:"url_checker,omitempty"`
+	URLCheckAction     string `json:"url_check_action,omitempty"`
+	QueryRewriter      string `json:"query_rewriter,omitempty"`
+	CodeTableSource    string `json:"code_table_source,omitempty"`
+	RateLimitStore     string `json:"rate_limit_store,omitempty"`
+	RateLimitPerSecond int    `json:"rate_limit_per_second,omitempty"`
+	RetryBudget        bool   `json:"retry_budget"`
+	Auditor            string `json:"auditor,omitempty"`
+	FeedbackStore      string `json:"feedback_store"`
+	ShadowSearcher     string `json:"shadow_searcher,omitempty"`
+	FaultInjection     bool   `json:"fault_injection"`
+}
+
+// Config returns a snapshot of the effective configuration of the client
+func (c *Client) Config() ConfigSnapshot {
+	config := c.config
+	snapshot := ConfigSnapshot{
+		APIKey:               maskSecret(config.APIKey),
+		BaseURL:              config.BaseURL,
+		APIVersion:           config.APIVersion,
+		Timeout:              c.http.Timeout,
+		MaxRetries:           config.MaxRetries,
+		UserAgent:            config.UserAgent,
+		DefaultCountry:       config.DefaultCountry,
+		DefaultSearchLang:    config.DefaultSearchLang,
+		DefaultUILang:        config.DefaultUILang,
+		NoDefaults:           config.NoDefaults,
+		NoRetention:          config.NoRetention,
+		QueryLimits:          config.QueryLimits,
+		QueryNormalization:   config.QueryNormalization.String(),
+		StrictCodes:          config.StrictCodes,
+		EscalateBreakingNews: config.EscalateBreakingNews,
+		StableResults:        config.StableResults,
+		EmptyResultsError:    config.EmptyResultsError,
+		SoftFail:             config.SoftFail,
+		AdaptiveCompression:  config.AdaptiveCompression,
+		TokenHeader:          config.HeaderPolicy.TokenHeader,
+		DisableCacheControl:  config.HeaderPolicy.DisableCacheControl,
+		HTTPClient:           "default",
+		CookieJar:            config.CookieJar != nil,
+		Authenticator:        typeName(config.Authenticator),
+		RequestSigning:       config.RequestSigner != nil,
+		Logger:               config.Logger != nil,
+		WarningHandler:       config.WarningHandler != nil,
+		QueryScrubber:        typeName(config.QueryScrubber),
+		LocationResolver:     typeName(config.LocationResolver),
+		SourceRater:          typeName(config.SourceRater),
+		Previewer:            typeName(config.Previewer),
+		URLChecker:           typeName(config.URLChecker),
+		QueryRewriter:        typeName(config.QueryRewriter),
+		CodeTableSource:      typeName(config.CodeTableSource),
+		RateLimitStore:       typeName(config.RateLimitStore),
+		Auditor:              typeName(config.Auditor),
+		FeedbackStore:        typeName(config.FeedbackStore),
+		ShadowSearcher:       typeName(config.ShadowSearcher),
+		RetryBudget:          config.RetryBudget != nil,
+		FaultInjection:       config.FaultPolicy != nil,
+	}
+
+	for endpoint, path := range config.EndpointOverrides {
+		if snapshot.EndpointOverrides == nil {
+			snapshot.EndpointOverrides = make(map[string]string)
+		}
+		snapshot.EndpointOverrides[endpoint.String()] = path
+	}
+
+	if snapshot.TokenHeader == "" {
+		snapshot.TokenHeader = HeaderSubscriptionToken
+	}
+	for name := range config.HeaderPolicy.Headers {
+		snapshot.Headers = append(snapshot.Headers, name)
+	}
+	sort.Strings(snapshot.Headers)
+
+	if config.HTTPClient != nil {
+		snapshot.HTTPClient = "custom"
+	}
+	if config.Previewer != nil {
+		snapshot.PreviewConcurrency = config.PreviewConcurrency
+	}
+	if config.URLChecker != nil {
+		snapshot.URLCheckAction = config.URLCheckAction.String()
+	}
+	if config.RateLimitStore != nil {
+		snapshot.RateLimitPerSecond = config.RateLimitPerSecond
+	}
+
+	return snapshot
+}
+
+// maskSecret masks all but the last four characters of a secret, or all of
+// it if it is too short to reveal any
+func maskSecret(secret string) string {
+	const visible = 4
+	if secret == "" {
+		return ""
+	}
+	if len(secret) < 3*visible {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", len(secret)-visible) + secret[len(secret)-visible:]
+}
+
+// typeName returns the Go type of a configured component, or "" if unset
+func typeName(component any) string {
+	if component == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", component)
+}
@@ -0,0 +1,76 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClientConfig tests snapshotting the effective configuration
+func TestClientConfig(t *testing.T) {
+	client, err := NewClient("BSA-secret-api-key")
+	require.NoError(t, err)
+
+	config := client.Config()
+	assert.Equal(t, "**************-key", config.APIKey)
+	assert.Equal(t, BaseURL, config.BaseURL)
+	assert.Equal(t, time.Duration(DefaultTimeout)*time.Second, config.Timeout)
+	assert.Equal(t, DefaultMaxRetries, config.MaxRetries)
+	assert.Equal(t, DefaultUserAgent, config.UserAgent)
+	assert.Equal(t, DefaultCountry, config.DefaultCountry)
+	assert.Equal(t, DefaultQueryLimits(), config.QueryLimits)
+	assert.Equal(t, "nfc", config.QueryNormalization)
+	assert.Equal(t, HeaderSubscriptionToken, config.TokenHeader)
+	assert.Equal(t, "default", config.HTTPClient)
+	assert.Equal(t, "bravesearch.SubscriptionTokenAuthenticator", config.Authenticator)
+	assert.Equal(t, "*bravesearch.RegexpScrubber", config.QueryScrubber)
+	assert.Equal(t, "*bravesearch.MemoryFeedbackStore", config.FeedbackStore)
+	assert.Empty(t, config.URLChecker)
+	assert.Empty(t, config.URLCheckAction)
+
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "BSA-secret")
+}
+
+// TestClientConfigOptions tests that options show in the snapshot
+func TestClientConfigOptions(t *testing.T) {
+	checker := &BlocklistURLChecker{}
+	client, err := NewClient("short",
+		WithHTTPClient(&http.Client{Timeout: 5 * time.Second}),
+		WithAppInfo("myapp", "1.2"),
+		WithHeaderPolicy(HeaderPolicy{Headers: http.Header{"X-Secret": {"value"}, "X-Team": {"search"}}}),
+		WithURLChecker(checker, URLCheckDrop),
+		WithQueryNormalization(QueryNormalizationNone),
+		WithAPIVersion("2023-01-01"),
+		WithNoDefaults(true),
+	)
+	require.NoError(t, err)
+
+	config := client.Config()
+	assert.Equal(t, "*****", config.APIKey)
+	assert.Equal(t, 5*time.Second, config.Timeout)
+	assert.Equal(t, "custom", config.HTTPClient)
+	assert.Contains(t, config.UserAgent, "myapp/1.2")
+	assert.Equal(t, []string{"X-Secret", "X-Team"}, config.Headers)
+	assert.Equal(t, "*bravesearch.BlocklistURLChecker", config.URLChecker)
+	assert.Equal(t, "drop", config.URLCheckAction)
+	assert.Equal(t, "none", config.QueryNormalization)
+	assert.Equal(t, "2023-01-01", config.APIVersion)
+	assert.True(t, config.NoDefaults)
+
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"value"`)
+}
+
+// TestMaskSecret tests masking secrets
+func TestMaskSecret(t *testing.T) {
+	assert.Equal(t, "", maskSecret(""))
+	assert.Equal(t, "***", maskSecret("abc"))
+	assert.Equal(t, "********9012", maskSecret("123456789012"))
+}
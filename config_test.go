@@ -97,4 +97,11 @@ func TestValidateConfig(t *testing.T) {
 	err = ValidateConfig(invalidConfig)
 	assert.Error(t, err)
 	assert.Equal(t, ErrMissingAPIKey, err)
+
+	// Test missing API key with a custom authenticator
+	authConfig := &ClientConfig{
+		Authenticator: BearerTokenAuthenticator{Token: "gateway-token"},
+	}
+	err = ValidateConfig(authConfig)
+	assert.NoError(t, err)
 }
@@ -7,6 +7,21 @@ const (
 
 	// WebSearchEndpoint is the endpoint for web search
 	WebSearchEndpoint = "/web/search"
+
+	// SuggestEndpoint is the endpoint for query suggestions
+	SuggestEndpoint = "/suggest/search"
+
+	// RichEndpoint is the endpoint for instant answers (rich results)
+	RichEndpoint = "/web/rich"
+
+	// ImageSearchEndpoint is the endpoint for image search
+	ImageSearchEndpoint = "/images/search"
+
+	// NewsSearchEndpoint is the endpoint for news search
+	NewsSearchEndpoint = "/news/search"
+
+	// VideoSearchEndpoint is the endpoint for video search
+	VideoSearchEndpoint = "/videos/search"
 )
 
 // SafeSearch options
@@ -37,6 +52,16 @@ const (
 	DefaultUserAgent    = "go-brave-search/1.0"
 	DefaultTextDecor    = true
 	DefaultSpellCheck   = true
+	DefaultSuggestCount = 5
+	MaxSuggestCount     = 20
+	DefaultImageCount   = 50
+	MaxImageCount       = 200
+	DefaultNewsCount    = 20
+	MaxNewsCount        = 50
+	DefaultVideoCount   = 20
+	MaxVideoCount       = 50
+	MaxCount            = 20
+	MaxOffset           = 9
 )
 
 // HTTP Headers
@@ -46,6 +71,9 @@ const (
 	HeaderUserAgent          = "User-Agent"
 	HeaderSubscriptionToken  = "X-Subscription-Token"
 	HeaderCacheControl       = "Cache-Control"
+	HeaderAuthorization      = "Authorization"
+	HeaderSignature          = "X-Signature"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
 	HeaderLocLatitude        = "X-Loc-Lat"
 	HeaderLocLongitude       = "X-Loc-Long"
 	HeaderLocTimezone        = "X-Loc-Timezone"
@@ -54,6 +82,8 @@ const (
 	HeaderLocStateName       = "X-Loc-State-Name"
 	HeaderLocCountry         = "X-Loc-Country"
 	HeaderLocPostalCode      = "X-Loc-Postal-Code"
+	HeaderAPIVersion         = "Api-Version"
+	HeaderCookie             = "Cookie"
 )
 
 // Response Headers
@@ -62,12 +92,16 @@ const (
 	HeaderRateLimitPolicy    = "X-RateLimit-Policy"
 	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
 	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderDeprecation        = "Deprecation"
+	HeaderSunset             = "Sunset"
+	HeaderLink               = "Link"
 )
 
 // MIME types
 const (
 	MIMETypeJSON           = "application/json"
 	MIMETypeGzip           = "gzip"
+	MIMETypeIdentity       = "identity"
 )
 
 // Result filters
@@ -0,0 +1,189 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Conversation roles
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Turn is a message of a conversation
+type Turn struct {
+	// Role is who sent the message, RoleUser or RoleAssistant
+	Role string
+
+	// Content is the text of the message
+	Content string
+}
+
+// QueryRewriter turns a message of a conversation into a standalone search
+// query, resolving references to earlier turns ("when was it released?").
+// Implementations, such as one prompting a language model, must be safe for
+// concurrent use.
+type QueryRewriter interface {
+	RewriteQuery(ctx context.Context, history []Turn, query string) (string, error)
+}
+
+// QueryRewriterFunc is an adapter to allow the use of ordinary functions as QueryRewriters
+type QueryRewriterFunc func(ctx context.Context, history []Turn, query string) (string, error)
+
+// RewriteQuery calls f(ctx, history, query)
+func (f QueryRewriterFunc) RewriteQuery(ctx context.Context, history []Turn, query string) (string, error) {
+	return f(ctx, history, query)
+}
+
+// QueryRewrite records how a conversational query was turned into the query
+// that was searched for
+type QueryRewrite struct {
+	// Original is the message as the user wrote it
+	Original string
+
+	// Query is the standalone query searched for
+	Query string
+}
+
+// Rewritten reports whether the query differs from the original message
+func (r *QueryRewrite) Rewritten() bool {
+	return r.Query != r.Original
+}
+
+// HeuristicRewriter is the default QueryRewriter. It leaves queries that
+// look self-contained unchanged. Follow-ups that refer back to the
+// conversation (containing a pronoun such as "it", starting with "and" or
+// "what about", or of at most two words) get the keywords of the latest user
+// turn appended. It is a cheap approximation; plug in a model-backed
+// QueryRewriter for better rewrites.
+type HeuristicRewriter struct{}
+
+// RewriteQuery implements QueryRewriter
+func (HeuristicRewriter) RewriteQuery(ctx context.Context, history []Turn, query string) (string, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	words := conversationWords(query)
+
+	followUp := len(words) <= 2
+	for _, word := range words {
+		if referenceWords[word] {
+			followUp = true
+			break
+		}
+	}
+	rest := query
+	for _, connector := range followUpConnectors {
+		if trimmed, ok := cutPrefixFold(query, connector+" "); ok {
+			followUp = true
+			rest = trimmed
+			break
+		}
+	}
+	if !followUp {
+		return query, nil
+	}
+
+	var previous string
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == RoleUser && strings.TrimSpace(history[i].Content) != "" {
+			previous = history[i].Content
+			break
+		}
+	}
+	if previous == "" {
+		return query, nil
+	}
+
+	present := make(map[string]bool, len(words))
+	for _, word := range words {
+		present[word] = true
+	}
+	terms := []string{strings.TrimRight(rest, "?")}
+	for _, word := range conversationWords(previous) {
+		if !present[word] && !conversationStopWords[word] && !referenceWords[word] {
+			present[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(terms, " ")), " "), nil
+}
+
+// ContextualizeQuery turns query, the latest message of a conversation, into
+// a standalone search query with rewriter, or the HeuristicRewriter if nil
+func ContextualizeQuery(ctx context.Context, rewriter QueryRewriter, history []Turn, query string) (*QueryRewrite, error) {
+	if rewriter == nil {
+		rewriter = HeuristicRewriter{}
+	}
+
+	rewritten, err := rewriter.RewriteQuery(ctx, history, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite query: %w", err)
+	}
+	rewritten = strings.TrimSpace(rewritten)
+	if rewritten == "" {
+		rewritten = query
+	}
+
+	return &QueryRewrite{Original: query, Query: rewritten}, nil
+}
+
+// ConversationSearch performs a web search for the latest message of a
+// conversation, first rewriting it into a standalone query with the
+// configured QueryRewriter (see WithQueryRewriter). The rewrite is recorded
+// in the Rewrite field of the response.
+func (c *Client) ConversationSearch(ctx context.Context, history []Turn, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	rewrite, err := ContextualizeQuery(ctx, c.config.QueryRewriter, history, query)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.WebSearch(ctx, rewrite.Query, params)
+	if err != nil {
+		return nil, err
+	}
+	response.Rewrite = rewrite
+	return response, nil
+}
+
+// conversationWords returns the lowercased words of text
+func conversationWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+}
+
+// cutPrefixFold is strings.CutPrefix ignoring case
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// followUpConnectors start messages that continue the previous question
+var followUpConnectors = []string{"and what about", "what about", "how about", "and"}
+
+// referenceWords refer back to something mentioned earlier in a conversation
+var referenceWords = map[string]bool{
+	"it": true, "its": true, "it's": true, "they": true, "them": true, "their": true,
+	"this": true, "that": true, "these": true, "those": true, "he": true, "she": true,
+	"him": true, "her": true, "his": true,
+}
+
+// conversationStopWords are not carried over from earlier turns
+var conversationStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "in": true, "on": true, "at": true,
+	"to": true, "for": true, "from": true, "by": true, "with": true, "about": true,
+	"and": true, "or": true, "is": true, "are": true, "was": true, "were": true,
+	"be": true, "been": true, "do": true, "does": true, "did": true, "can": true,
+	"could": true, "should": true, "would": true, "will": true, "i": true, "me": true,
+	"my": true, "you": true, "your": true, "we": true, "our": true, "what": true,
+	"which": true, "who": true, "whom": true, "when": true, "where": true, "why": true,
+	"how": true, "please": true, "tell": true, "show": true, "find": true, "search": true,
+}
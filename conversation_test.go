@@ -0,0 +1,112 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHeuristicRewriter tests rewriting follow-up messages into standalone queries
+func TestHeuristicRewriter(t *testing.T) {
+	history := []Turn{
+		{Role: RoleUser, Content: "Who created the Go programming language?"},
+		{Role: RoleAssistant, Content: "Go was designed at Google by Robert Griesemer, Rob Pike and Ken Thompson."},
+	}
+
+	tests := []struct {
+		name     string
+		history  []Turn
+		query    string
+		expected string
+	}{
+		{"pronoun", history, "When was it released?", "When was it released created go programming language"},
+		{"connector", history, "What about Rust?", "Rust created go programming language"},
+		{"short", history, "license", "license created go programming language"},
+		{"standalone", history, "best pizza in Naples", "best pizza in Naples"},
+		{"no history", nil, "when was it released", "when was it released"},
+		{"no user turn", history[1:], "when was it released", "when was it released"},
+		{"terms not repeated", history, "is it open source", "is it open source created go programming language"},
+		{"earlier terms not repeated", history, "and go modules", "go modules created programming language"},
+		{"whitespace", history, "  best  pizza in   Naples ", "best pizza in Naples"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rewritten, err := HeuristicRewriter{}.RewriteQuery(context.Background(), tt.history, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rewritten)
+		})
+	}
+}
+
+// TestContextualizeQuery tests recording rewrites and rewriter failures
+func TestContextualizeQuery(t *testing.T) {
+	rewrite, err := ContextualizeQuery(context.Background(), nil, nil, "golang")
+	require.NoError(t, err)
+	assert.Equal(t, &QueryRewrite{Original: "golang", Query: "golang"}, rewrite)
+	assert.False(t, rewrite.Rewritten())
+
+	prefix := QueryRewriterFunc(func(ctx context.Context, history []Turn, query string) (string, error) {
+		return "golang " + query, nil
+	})
+	rewrite, err = ContextualizeQuery(context.Background(), prefix, nil, "generics")
+	require.NoError(t, err)
+	assert.Equal(t, "golang generics", rewrite.Query)
+	assert.True(t, rewrite.Rewritten())
+
+	// An empty rewrite keeps the original
+	empty := QueryRewriterFunc(func(ctx context.Context, history []Turn, query string) (string, error) {
+		return " ", nil
+	})
+	rewrite, err = ContextualizeQuery(context.Background(), empty, nil, "generics")
+	require.NoError(t, err)
+	assert.Equal(t, "generics", rewrite.Query)
+
+	failing := QueryRewriterFunc(func(ctx context.Context, history []Turn, query string) (string, error) {
+		return "", errors.New("model unavailable")
+	})
+	_, err = ContextualizeQuery(context.Background(), failing, nil, "generics")
+	assert.ErrorContains(t, err, "model unavailable")
+}
+
+// TestConversationSearch tests searching for the rewritten query
+func TestConversationSearch(t *testing.T) {
+	var searched string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searched = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	history := []Turn{{Role: RoleUser, Content: "golang generics"}}
+	response, err := client.ConversationSearch(context.Background(), history, "and performance", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "performance golang generics", searched)
+	require.NotNil(t, response.Rewrite)
+	assert.Equal(t, "and performance", response.Rewrite.Original)
+	assert.Equal(t, "performance golang generics", response.Rewrite.Query)
+
+	_, err = client.ConversationSearch(context.Background(), history, " ", nil)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL),
+		WithQueryRewriter(QueryRewriterFunc(func(ctx context.Context, history []Turn, query string) (string, error) {
+			return "rewritten", nil
+		})))
+	require.NoError(t, err)
+	_, err = client.ConversationSearch(context.Background(), history, "and performance", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "rewritten", searched)
+
+	_, err = NewClient("test-api-key", WithQueryRewriter(nil))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
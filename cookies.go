@@ -0,0 +1,16 @@
+package bravesearch
+
+import "net/http"
+
+// withCookieJar returns client with jar as its cookie jar, copying it rather
+// than modifying an HTTP client the caller may share. With a nil jar,
+// cookies set by responses are dropped and none are sent, so searches
+// cannot be linked to each other through cookies.
+func withCookieJar(client *http.Client, jar http.CookieJar) *http.Client {
+	if client.Jar == jar {
+		return client
+	}
+	withJar := *client
+	withJar.Jar = jar
+	return &withJar
+}
@@ -0,0 +1,81 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cookieResponse sets a cookie on every response
+func cookieResponse(r *http.Request) mockResponse {
+	return mockResponse{
+		Header: http.Header{"Set-Cookie": {(&http.Cookie{Name: "session", Value: "tracked", Path: "/"}).String()}},
+		Body:   `{"type": "search"}`,
+	}
+}
+
+// TestNoCookies tests that cookies are neither stored nor sent by default
+func TestNoCookies(t *testing.T) {
+	server := newMockServer(t, cookieResponse)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	httpClient := &http.Client{Jar: jar}
+
+	authenticator := AuthenticatorFunc(func(req *http.Request) error {
+		req.Header.Set(HeaderSubscriptionToken, "test-api-key")
+		req.Header.Set(HeaderCookie, "injected=1")
+		return nil
+	})
+
+	for name, options := range map[string][]ClientOption{
+		"default":              {WithBaseURL(server.URL)},
+		"http client with jar": {WithBaseURL(server.URL), WithHTTPClient(httpClient)},
+		"cookie authenticator": {WithBaseURL(server.URL), WithAuthenticator(authenticator)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient("test-api-key", options...)
+			require.NoError(t, err)
+			assert.False(t, client.Config().CookieJar)
+
+			for range 2 {
+				_, err = client.WebSearch(context.Background(), "golang", nil)
+				require.NoError(t, err)
+				assert.Empty(t, server.lastRequest().Header.Get(HeaderCookie))
+			}
+		})
+	}
+
+	// The caller's HTTP client keeps its jar, which stays empty
+	assert.Same(t, jar, httpClient.Jar)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	assert.Empty(t, jar.Cookies(serverURL))
+}
+
+// TestWithCookieJar tests opting in to a cookie jar
+func TestWithCookieJar(t *testing.T) {
+	server := newMockServer(t, cookieResponse)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithCookieJar(jar))
+	require.NoError(t, err)
+	assert.True(t, client.Config().CookieJar)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Empty(t, server.lastRequest().Header.Get(HeaderCookie))
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "session=tracked", server.lastRequest().Header.Get(HeaderCookie))
+
+	_, err = NewClient("test-api-key", WithCookieJar(nil))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
@@ -0,0 +1,171 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// DefaultCoveragePages is the number of result pages a coverage report
+// samples without CoverageOptions.MaxPages
+const DefaultCoveragePages = 3
+
+// DefaultCoverageTopResults is the number of top results a coverage report
+// keeps without CoverageOptions.TopResults
+const DefaultCoverageTopResults = 10
+
+// CoverageOptions configures a CoverageForDomain
+type CoverageOptions struct {
+	// MaxPages is the number of result pages to sample, from 1 to
+	// MaxOffset+1. It defaults to DefaultCoveragePages.
+	MaxPages int
+
+	// TopResults is the number of top results to keep in the report. It
+	// defaults to DefaultCoverageTopResults.
+	TopResults int
+
+	// Params are the web search parameters. Count, Offset and ResultFilter
+	// are set by the report.
+	Params *WebSearchParams
+}
+
+// DomainCoverage reports how much of a domain the index holds, sampled from
+// site: searches
+type DomainCoverage struct {
+	Domain string
+
+	// Sampled is the number of distinct indexed pages found
+	Sampled int
+
+	// Pages is the number of result pages requested
+	Pages int
+
+	// Exhausted reports whether the API ran out of results, in which case
+	// Sampled is every page the API returns for the domain
+	Exhausted bool
+
+	// Hosts counts the sampled pages per hostname, e.g. to spot subdomains
+	Hosts map[string]int
+
+	// Top holds the top results, in ranking order
+	Top []SearchResult
+
+	// Fetched is the number of sampled pages with a crawl time (page_fetched)
+	Fetched int
+
+	// LastFetched and OldestFetched are the newest and oldest crawl times of
+	// the sampled pages, zero if none is known
+	LastFetched   time.Time
+	OldestFetched time.Time
+}
+
+// FetchedAt returns when the page was last crawled, parsed from page_fetched.
+// Timestamps without a time zone are taken as UTC.
+func (r *SearchResult) FetchedAt() (time.Time, bool) {
+	for _, layout := range pageAgeLayouts {
+		if t, err := time.Parse(layout, r.PageFetched); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CoverageForDomain samples the indexed pages of a domain with paginated
+// "site:" searches, for SEO audits. It requests up to opts.MaxPages pages of
+// MaxCount results, stopping early when the API has no more, and summarizes
+// them in a DomainCoverage. Results from other domains are ignored.
+func (c *Client) CoverageForDomain(ctx context.Context, domain string, opts *CoverageOptions) (*DomainCoverage, error) {
+	if opts == nil {
+		opts = &CoverageOptions{}
+	}
+	domain = hostutil.Normalize(strings.TrimSpace(domain))
+	if domain == "" || strings.ContainsAny(domain, " /:") {
+		return nil, fmt.Errorf("%w: invalid domain %q", ErrInvalidParameters, domain)
+	}
+
+	maxPages := opts.MaxPages
+	if maxPages == 0 {
+		maxPages = DefaultCoveragePages
+	}
+	if maxPages < 0 || maxPages > MaxOffset+1 {
+		return nil, fmt.Errorf("%w: max pages must be between 1 and %d", ErrInvalidParameters, MaxOffset+1)
+	}
+	topResults := opts.TopResults
+	if topResults == 0 {
+		topResults = DefaultCoverageTopResults
+	}
+	if topResults < 0 {
+		return nil, fmt.Errorf("%w: top results must not be negative", ErrInvalidParameters)
+	}
+
+	params := &WebSearchParams{}
+	if opts.Params != nil {
+		*params = *opts.Params
+	}
+	params.Count = MaxCount
+	params.ResultFilter = "web"
+
+	coverage := &DomainCoverage{Domain: domain, Hosts: make(map[string]int)}
+	seen := make(map[string]bool)
+	for params.Offset = 0; params.Offset < maxPages; params.Offset++ {
+		response, err := c.WebSearch(ctx, "site:"+domain, params)
+		if errors.Is(err, ErrNoResults) {
+			coverage.Pages++
+			coverage.Exhausted = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		coverage.Pages++
+
+		for _, result := range response.GetWebResults() {
+			hostname := result.hostname()
+			if seen[result.URL] || !hostutil.MatchesDomain(hostname, domain) {
+				continue
+			}
+			seen[result.URL] = true
+			coverage.add(result, hostname, topResults)
+		}
+
+		if !response.HasMoreResults() {
+			coverage.Exhausted = true
+			break
+		}
+	}
+	return coverage, nil
+}
+
+// add counts a sampled page
+func (d *DomainCoverage) add(result SearchResult, hostname string, topResults int) {
+	d.Sampled++
+	d.Hosts[hostname]++
+	if len(d.Top) < topResults {
+		d.Top = append(d.Top, result)
+	}
+
+	fetched, ok := result.FetchedAt()
+	if !ok {
+		return
+	}
+	d.Fetched++
+	if d.LastFetched.IsZero() || fetched.After(d.LastFetched) {
+		d.LastFetched = fetched
+	}
+	if d.OldestFetched.IsZero() || fetched.Before(d.OldestFetched) {
+		d.OldestFetched = fetched
+	}
+}
+
+// hostname returns the lower-case hostname of the result, preferring meta_url
+func (r *SearchResult) hostname() string {
+	var metaHostname string
+	if r.MetaURL != nil {
+		metaHostname = r.MetaURL.Hostname
+	}
+	return hostutil.Of(metaHostname, r.URL)
+}
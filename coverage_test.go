@@ -0,0 +1,142 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// coveragePages are the pages of a site: search, by offset
+var coveragePages = []string{
+	`{"query": {"original": "site:example.com", "more_results_available": true}, "web": {"results": [
+		{"title": "Home", "url": "https://example.com/", "page_fetched": "2024-05-03T10:00:00Z"},
+		{"title": "Docs", "url": "https://docs.example.com/start", "page_fetched": "2024-04-01T00:00:00"},
+		{"title": "Mirror", "url": "https://example.org/copy"}
+	]}}`,
+	`{"query": {"original": "site:example.com", "more_results_available": false}, "web": {"results": [
+		{"title": "Home", "url": "https://example.com/"},
+		{"title": "Blog", "url": "https://www.example.com/blog", "page_fetched": "2024-05-10"}
+	]}}`,
+}
+
+// TestCoverageForDomain tests sampling the indexed pages of a domain
+func TestCoverageForDomain(t *testing.T) {
+	var requests atomic.Int32
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		query = r.URL.Query()
+		var offset int
+		_, _ = fmt.Sscan(query.Get("offset"), &offset)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(coveragePages[offset]))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	coverage, err := client.CoverageForDomain(context.Background(), "Example.com", &CoverageOptions{
+		MaxPages:   5,
+		TopResults: 2,
+		Params:     &WebSearchParams{Count: 5, Country: "GB"},
+	})
+	require.NoError(t, err)
+
+	// The API ran out of results after the second page
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, "site:example.com", query.Get("q"))
+	assert.Equal(t, "20", query.Get("count"))
+	assert.Equal(t, "GB", query.Get("country"))
+
+	assert.Equal(t, "example.com", coverage.Domain)
+	assert.Equal(t, 2, coverage.Pages)
+	assert.True(t, coverage.Exhausted)
+	assert.Equal(t, 3, coverage.Sampled)
+	assert.Equal(t, map[string]int{"example.com": 1, "docs.example.com": 1, "www.example.com": 1}, coverage.Hosts)
+
+	require.Len(t, coverage.Top, 2)
+	assert.Equal(t, "Home", coverage.Top[0].Title)
+	assert.Equal(t, "Docs", coverage.Top[1].Title)
+
+	assert.Equal(t, 3, coverage.Fetched)
+	assert.Equal(t, time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), coverage.LastFetched)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), coverage.OldestFetched)
+}
+
+// TestCoverageForDomainLimit tests that sampling stops at MaxPages
+func TestCoverageForDomainLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(coveragePages[0]))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	coverage, err := client.CoverageForDomain(context.Background(), "example.com", &CoverageOptions{MaxPages: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.False(t, coverage.Exhausted)
+	assert.Equal(t, 2, coverage.Sampled)
+}
+
+// TestCoverageForDomainEmpty tests a domain without indexed pages
+func TestCoverageForDomainEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithEmptyResultsError(true))
+	require.NoError(t, err)
+
+	coverage, err := client.CoverageForDomain(context.Background(), "example.com", nil)
+	require.NoError(t, err)
+	assert.True(t, coverage.Exhausted)
+	assert.Equal(t, 1, coverage.Pages)
+	assert.Zero(t, coverage.Sampled)
+	assert.True(t, coverage.LastFetched.IsZero())
+}
+
+// TestCoverageForDomainInvalid tests rejecting invalid options
+func TestCoverageForDomainInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		domain string
+		opts   *CoverageOptions
+	}{
+		{"", nil},
+		{"https://example.com", nil},
+		{"example.com", &CoverageOptions{MaxPages: MaxOffset + 2}},
+		{"example.com", &CoverageOptions{TopResults: -1}},
+	} {
+		_, err := client.CoverageForDomain(context.Background(), tt.domain, tt.opts)
+		assert.ErrorIs(t, err, ErrInvalidParameters, tt.domain)
+	}
+}
+
+// TestFetchedAt tests parsing page_fetched
+func TestFetchedAt(t *testing.T) {
+	result := SearchResult{PageFetched: "2024-05-03T10:00:00+02:00"}
+	fetched, ok := result.FetchedAt()
+	assert.True(t, ok)
+	assert.True(t, fetched.Equal(time.Date(2024, 5, 3, 8, 0, 0, 0, time.UTC)))
+
+	_, ok = (&SearchResult{}).FetchedAt()
+	assert.False(t, ok)
+}
@@ -0,0 +1,165 @@
+package bravesearch
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// deprecations records the deprecation warnings announced by the API, one per
+// endpoint, so each announcement is reported once rather than on every request
+type deprecations struct {
+	mu       sync.Mutex
+	warnings map[string]Warning
+
+	// announcements are the header values of the latest warning per
+	// endpoint. They identify an announcement: its message depends on the
+	// time it is rendered.
+	announcements map[string]deprecationAnnouncement
+}
+
+// deprecationAnnouncement is the values of the deprecation headers of a response
+type deprecationAnnouncement struct {
+	deprecation string
+	sunset      string
+	links       string
+}
+
+// Warnings returns the deprecation and sunset warnings the API has announced
+// in the responses received so far, the latest one per endpoint, ordered by
+// endpoint. Each announcement is also reported once to the WarningHandler.
+func (c *Client) Warnings() []Warning {
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+
+	endpoints := make([]string, 0, len(c.deprecations.warnings))
+	for endpoint := range c.deprecations.warnings {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	warnings := make([]Warning, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		warnings = append(warnings, c.deprecations.warnings[endpoint])
+	}
+	return warnings
+}
+
+// noteDeprecation records the Deprecation, Sunset and related Link headers
+// (RFC 9745 and RFC 8594) of a response for endpoint, warning when they are
+// new or have changed
+func (c *Client) noteDeprecation(endpoint string, header http.Header) {
+	warning, ok := deprecationWarning(endpoint, header)
+	if !ok {
+		return
+	}
+	announcement := deprecationAnnouncement{
+		deprecation: strings.Join(header.Values(HeaderDeprecation), ", "),
+		sunset:      strings.Join(header.Values(HeaderSunset), ", "),
+		links:       strings.Join(header.Values(HeaderLink), ", "),
+	}
+
+	c.deprecations.mu.Lock()
+	previous, seen := c.deprecations.announcements[endpoint]
+	if c.deprecations.warnings == nil {
+		c.deprecations.warnings = make(map[string]Warning)
+		c.deprecations.announcements = make(map[string]deprecationAnnouncement)
+	}
+	c.deprecations.warnings[endpoint] = warning
+	c.deprecations.announcements[endpoint] = announcement
+	c.deprecations.mu.Unlock()
+
+	if !seen || previous != announcement {
+		c.warn(warning)
+	}
+}
+
+// deprecationWarning describes the deprecation headers of a response, if any
+func deprecationWarning(endpoint string, header http.Header) (Warning, bool) {
+	deprecation := strings.TrimSpace(header.Get(HeaderDeprecation))
+	sunset := strings.TrimSpace(header.Get(HeaderSunset))
+	if deprecation == "" && sunset == "" {
+		return Warning{}, false
+	}
+
+	message := "endpoint " + endpoint
+	if deprecation != "" {
+		message += " is deprecated"
+		if date, ok := parseDeprecationDate(deprecation); ok {
+			if date.After(time.Now()) {
+				message += " as of " + date.Format(time.DateOnly)
+			} else {
+				message += " since " + date.Format(time.DateOnly)
+			}
+		}
+		if sunset != "" {
+			message += " and"
+		}
+	}
+	if sunset != "" {
+		message += " will be sunset"
+		if date, err := http.ParseTime(sunset); err == nil {
+			message += " on " + date.UTC().Format(time.DateOnly)
+		}
+	}
+
+	var links []string
+	for _, rel := range []string{"deprecation", "sunset"} {
+		if link := linkWithRel(header.Values(HeaderLink), rel); link != "" && !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+	if len(links) > 0 {
+		message += " (see " + strings.Join(links, ", ") + ")"
+	}
+
+	return Warning{Code: WarningCodeDeprecated, Message: message}, true
+}
+
+// parseDeprecationDate parses the value of a Deprecation header, either an
+// RFC 9745 structured date ("@1688169599") or an HTTP date as used by earlier
+// drafts. Values without a date, such as "true", report false.
+func parseDeprecationDate(value string) (time.Time, bool) {
+	if seconds, ok := strings.CutPrefix(value, "@"); ok {
+		unix, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(unix, 0).UTC(), true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date.UTC(), true
+}
+
+// linkWithRel returns the target of the first link with the given relation
+// type in the values of Link headers, such as `<https://example.com>; rel="sunset"`
+func linkWithRel(values []string, rel string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			target = strings.TrimSpace(target)
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, relValue, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, relType := range strings.Fields(strings.Trim(relValue, `"`)) {
+					if strings.EqualFold(relType, rel) {
+						return strings.Trim(target, "<>")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
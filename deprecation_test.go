@@ -0,0 +1,147 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeprecationWarning tests describing deprecation headers
+func TestDeprecationWarning(t *testing.T) {
+	_, ok := deprecationWarning("/res/v1/web/search", http.Header{})
+	assert.False(t, ok)
+
+	header := http.Header{}
+	header.Set(HeaderDeprecation, "@1688169599")
+	header.Set(HeaderSunset, "Wed, 11 Nov 2026 23:59:59 GMT")
+	header.Add(HeaderLink, `<https://api.example.com/changes>; rel="deprecation"; type="text/html"`)
+	header.Add(HeaderLink, `<https://api.example.com/next>; rel="successor-version", <https://api.example.com/sunset>; rel="sunset"`)
+
+	warning, ok := deprecationWarning("/res/v1/web/search", header)
+	require.True(t, ok)
+	assert.Equal(t, WarningCodeDeprecated, warning.Code)
+	assert.Equal(t, "endpoint /res/v1/web/search is deprecated since 2023-06-30 and will be sunset on 2026-11-11 "+
+		"(see https://api.example.com/changes, https://api.example.com/sunset)", warning.Message)
+
+	header = http.Header{}
+	header.Set(HeaderDeprecation, "true")
+	warning, ok = deprecationWarning("/res/v1/web/search", header)
+	require.True(t, ok)
+	assert.Equal(t, "endpoint /res/v1/web/search is deprecated", warning.Message)
+
+	header = http.Header{}
+	header.Set(HeaderSunset, "soon")
+	warning, ok = deprecationWarning("/res/v1/web/search", header)
+	require.True(t, ok)
+	assert.Equal(t, "endpoint /res/v1/web/search will be sunset", warning.Message)
+}
+
+// TestParseDeprecationDate tests parsing the dates of Deprecation headers
+func TestParseDeprecationDate(t *testing.T) {
+	date, ok := parseDeprecationDate("@1688169599")
+	require.True(t, ok)
+	assert.Equal(t, int64(1688169599), date.Unix())
+
+	date, ok = parseDeprecationDate("Sun, 11 Nov 2018 23:59:59 GMT")
+	require.True(t, ok)
+	assert.Equal(t, 2018, date.Year())
+
+	for _, value := range []string{"true", "@soon", ""} {
+		_, ok = parseDeprecationDate(value)
+		assert.False(t, ok, value)
+	}
+}
+
+// TestLinkWithRel tests finding links by relation type
+func TestLinkWithRel(t *testing.T) {
+	values := []string{`<https://a.example>; rel="alternate deprecation"`, `<https://b.example>;REL=sunset`, `broken; rel="sunset"`}
+	assert.Equal(t, "https://a.example", linkWithRel(values, "deprecation"))
+	assert.Equal(t, "https://b.example", linkWithRel(values, "sunset"))
+	assert.Empty(t, linkWithRel(values, "successor-version"))
+}
+
+// TestClientWarnings tests recording and reporting deprecation announcements
+func TestClientWarnings(t *testing.T) {
+	var deprecated bool
+	var apiVersion string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiVersion = r.Header.Get(HeaderAPIVersion)
+		if deprecated {
+			w.Header().Set(HeaderDeprecation, "@1688169599")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithAPIVersion("2023-01-01"),
+		WithWarningHandler(func(warning Warning) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, warning)
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2023-01-01", apiVersion)
+	assert.Empty(t, client.Warnings())
+
+	// Each announcement is reported once
+	deprecated = true
+	for range 3 {
+		_, err = client.WebSearch(context.Background(), "golang", nil)
+		require.NoError(t, err)
+	}
+	require.Len(t, client.Warnings(), 1)
+	assert.Equal(t, WarningCodeDeprecated, client.Warnings()[0].Code)
+	assert.Contains(t, client.Warnings()[0].Message, WebSearchEndpoint)
+
+	var deprecations int
+	for _, warning := range warnings {
+		if warning.Code == WarningCodeDeprecated {
+			deprecations++
+		}
+	}
+	assert.Equal(t, 1, deprecations)
+}
+
+// TestNoteDeprecation tests reporting each announcement once, however its
+// message is rendered
+func TestNoteDeprecation(t *testing.T) {
+	var warnings []Warning
+	client, err := NewClient("test-api-key", WithWarningHandler(func(warning Warning) {
+		warnings = append(warnings, warning)
+	}))
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set(HeaderDeprecation, "@1688169599")
+	client.noteDeprecation(WebSearchEndpoint, header)
+	require.Len(t, warnings, 1)
+
+	// The message was rendered before the deprecation date passed
+	client.deprecations.warnings[WebSearchEndpoint] = Warning{
+		Code:    WarningCodeDeprecated,
+		Message: "endpoint " + WebSearchEndpoint + " is deprecated as of 2023-06-30",
+	}
+	client.noteDeprecation(WebSearchEndpoint, header)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, client.Warnings()[0].Message, "since 2023-06-30")
+
+	// A changed announcement is reported again
+	header.Set(HeaderSunset, "Wed, 11 Nov 2026 23:59:59 GMT")
+	client.noteDeprecation(WebSearchEndpoint, header)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[1].Message, "will be sunset on 2026-11-11")
+}
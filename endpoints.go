@@ -0,0 +1,76 @@
+package bravesearch
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Endpoint identifies an API endpoint, for WithEndpointOverride
+type Endpoint int
+
+// Endpoints
+const (
+	// EndpointWebSearch is the web search endpoint (WebSearchEndpoint)
+	EndpointWebSearch Endpoint = iota
+
+	// EndpointSuggest is the query suggestion endpoint (SuggestEndpoint)
+	EndpointSuggest
+
+	// EndpointRich is the instant answer endpoint (RichEndpoint)
+	EndpointRich
+
+	// EndpointImageSearch is the image search endpoint (ImageSearchEndpoint)
+	EndpointImageSearch
+
+	// EndpointNewsSearch is the news search endpoint (NewsSearchEndpoint)
+	EndpointNewsSearch
+
+	// EndpointVideoSearch is the video search endpoint (VideoSearchEndpoint)
+	EndpointVideoSearch
+)
+
+// endpointPaths are the default paths of the endpoints, relative to the base URL
+var endpointPaths = map[Endpoint]string{
+	EndpointWebSearch:   WebSearchEndpoint,
+	EndpointSuggest:     SuggestEndpoint,
+	EndpointRich:        RichEndpoint,
+	EndpointImageSearch: ImageSearchEndpoint,
+	EndpointNewsSearch:  NewsSearchEndpoint,
+	EndpointVideoSearch: VideoSearchEndpoint,
+}
+
+// String returns the default path of the endpoint
+func (e Endpoint) String() string {
+	if path, ok := endpointPaths[e]; ok {
+		return path
+	}
+	return fmt.Sprintf("Endpoint(%d)", int(e))
+}
+
+// validEndpointOverride reports whether override is a path starting with a
+// slash or an absolute http(s) URL for a known endpoint
+func validEndpointOverride(endpoint Endpoint, override string) bool {
+	if _, ok := endpointPaths[endpoint]; !ok {
+		return false
+	}
+	if strings.HasPrefix(override, "/") {
+		return true
+	}
+
+	parsed, err := url.Parse(override)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// endpointURL returns the URL of endpoint, without query parameters: its
+// override if it is an absolute URL, else its path under the base URL
+func (c *Client) endpointURL(endpoint Endpoint) string {
+	path, ok := c.config.EndpointOverrides[endpoint]
+	if !ok {
+		path = endpointPaths[endpoint]
+	}
+	if strings.Contains(path, "://") {
+		return path
+	}
+	return strings.TrimSuffix(c.config.BaseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,82 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEndpointURL tests resolving endpoint URLs with and without overrides
+func TestEndpointURL(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+	assert.Equal(t, BaseURL+WebSearchEndpoint, client.endpointURL(EndpointWebSearch))
+	assert.Equal(t, BaseURL+SuggestEndpoint, client.endpointURL(EndpointSuggest))
+	assert.Equal(t, BaseURL+RichEndpoint, client.endpointURL(EndpointRich))
+	assert.Equal(t, BaseURL+ImageSearchEndpoint, client.endpointURL(EndpointImageSearch))
+	assert.Equal(t, BaseURL+NewsSearchEndpoint, client.endpointURL(EndpointNewsSearch))
+	assert.Equal(t, BaseURL+VideoSearchEndpoint, client.endpointURL(EndpointVideoSearch))
+
+	client, err = NewClient("test-api-key",
+		WithBaseURL("https://example.com/api/"),
+		WithEndpointOverride(EndpointWebSearch, "/llm/context"),
+		WithEndpointOverride(EndpointSuggest, "https://suggest.example.com/v2/suggest"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/api/llm/context", client.endpointURL(EndpointWebSearch))
+	assert.Equal(t, "https://suggest.example.com/v2/suggest", client.endpointURL(EndpointSuggest))
+	assert.Equal(t, "https://example.com/api/web/rich", client.endpointURL(EndpointRich))
+
+	assert.Equal(t, map[string]string{
+		WebSearchEndpoint: "/llm/context",
+		SuggestEndpoint:   "https://suggest.example.com/v2/suggest",
+	}, client.Config().EndpointOverrides)
+}
+
+// TestWithEndpointOverride tests validating endpoint overrides
+func TestWithEndpointOverride(t *testing.T) {
+	for name, option := range map[string]ClientOption{
+		"relative path":    WithEndpointOverride(EndpointWebSearch, "web/search"),
+		"unknown endpoint": WithEndpointOverride(Endpoint(99), "/web/search"),
+		"scheme":           WithEndpointOverride(EndpointWebSearch, "ftp://example.com/search"),
+		"no host":          WithEndpointOverride(EndpointWebSearch, "https:///search"),
+	} {
+		assert.Equal(t, ErrInvalidParameters, option(&ClientConfig{}), name)
+	}
+
+	assert.Equal(t, "/web/search", EndpointWebSearch.String())
+	assert.Equal(t, "Endpoint(99)", Endpoint(99).String())
+}
+
+// TestEndpointOverrideRequests tests that requests go to the overridden paths
+func TestEndpointOverrideRequests(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/res/v1"),
+		WithEndpointOverride(EndpointWebSearch, "/ai/web/search"),
+		WithEndpointOverride(EndpointSuggest, server.URL+"/other/suggest"),
+	)
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	_, err = client.Suggest(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/res/v1/ai/web/search", "/other/suggest"}, paths)
+}
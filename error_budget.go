@@ -0,0 +1,257 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// errorBudgetRetention is how long request outcomes are kept, and so the
+// longest window of an ErrorBudgetReport
+const errorBudgetRetention = 6 * time.Hour
+
+// errorBudgetBucket is the granularity of the outcome windows
+const errorBudgetBucket = time.Minute
+
+// defaultBurnRateWindows are the windows of an ErrorBudgetReport when none
+// are given: a short window to catch fast burns and longer ones to confirm
+// them and catch slow burns
+var defaultBurnRateWindows = []time.Duration{5 * time.Minute, time.Hour, errorBudgetRetention}
+
+// Failure causes
+const (
+	// FailureCauseAPI is the cause of failures on Brave's side: server
+	// errors, timeouts, network failures and invalid responses
+	FailureCauseAPI = "api"
+
+	// FailureCauseKey is the cause of failures due to the API key or its
+	// subscription: authentication failures, rate limits and plan limits
+	FailureCauseKey = "key"
+
+	// FailureCauseOther is the cause of any other failure, such as requests
+	// the API rejected as invalid
+	FailureCauseOther = "other"
+)
+
+// BudgetWindow is the success rate of the requests to an endpoint in a
+// rolling window
+type BudgetWindow struct {
+	// Window is the length of the window
+	Window time.Duration `json:"window"`
+
+	// Requests is the number of requests in the window
+	Requests int `json:"requests"`
+
+	// Failures is the number of failed requests, of which APIFailures were
+	// Brave's, KeyFailures the API key's and the rest of other causes
+	Failures    int `json:"failures"`
+	APIFailures int `json:"api_failures"`
+	KeyFailures int `json:"key_failures"`
+
+	// SuccessRate is the share of requests that succeeded, 1 without requests
+	SuccessRate float64 `json:"success_rate"`
+
+	// BurnRate is how fast the window spends the error budget: the failure
+	// rate divided by the failure rate the objective allows. At 1 the budget
+	// lasts exactly the SLO period.
+	BurnRate float64 `json:"burn_rate"`
+}
+
+// Cause returns the most common cause of the failures in the window, one of
+// the FailureCause constants, or "" without failures
+func (w BudgetWindow) Cause() string {
+	other := w.Failures - w.APIFailures - w.KeyFailures
+	switch {
+	case w.Failures == 0:
+		return ""
+	case w.APIFailures >= w.KeyFailures && w.APIFailures >= other:
+		return FailureCauseAPI
+	case w.KeyFailures >= other:
+		return FailureCauseKey
+	}
+	return FailureCauseOther
+}
+
+// EndpointBudget is the error budget burn of an endpoint
+type EndpointBudget struct {
+	// Endpoint is the path of the endpoint
+	Endpoint string `json:"endpoint"`
+
+	// Windows are the rolling windows, in the order requested
+	Windows []BudgetWindow `json:"windows"`
+}
+
+// Burning reports whether every window burns the error budget at least at
+// rate, as multi-window burn rate alerts require: the short window shows the
+// burn is ongoing, the long one that it is significant
+func (e EndpointBudget) Burning(rate float64) bool {
+	for _, window := range e.Windows {
+		if window.Requests == 0 || window.BurnRate < rate {
+			return false
+		}
+	}
+	return len(e.Windows) > 0
+}
+
+// ErrorBudgetReport is the error budget burn of each endpoint against an
+// availability objective
+type ErrorBudgetReport struct {
+	// Objective is the target success rate, such as 0.999
+	Objective float64 `json:"objective"`
+
+	// Endpoints are the endpoints requested in the longest window, ordered by path
+	Endpoints []EndpointBudget `json:"endpoints"`
+}
+
+// outcomeBucket counts the outcomes of the requests in one minute
+type outcomeBucket struct {
+	minute      int64
+	requests    int
+	apiFailures int
+	keyFailures int
+	failures    int
+}
+
+// errorBudgetTracker keeps the request outcomes per endpoint in one-minute
+// buckets
+type errorBudgetTracker struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	endpoints map[string][]outcomeBucket
+}
+
+// observe adds the outcome of a request to endpoint
+func (t *errorBudgetTracker) observe(endpoint string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.endpoints == nil {
+		t.endpoints = make(map[string][]outcomeBucket)
+	}
+	buckets, ok := t.endpoints[endpoint]
+	if !ok {
+		buckets = make([]outcomeBucket, errorBudgetRetention/errorBudgetBucket)
+		t.endpoints[endpoint] = buckets
+	}
+
+	minute := t.minute()
+	bucket := &buckets[minute%int64(len(buckets))]
+	if bucket.minute != minute {
+		*bucket = outcomeBucket{minute: minute}
+	}
+	bucket.requests++
+	if err == nil {
+		return
+	}
+	bucket.failures++
+	switch failureCause(err) {
+	case FailureCauseAPI:
+		bucket.apiFailures++
+	case FailureCauseKey:
+		bucket.keyFailures++
+	}
+}
+
+// report computes the success and burn rates of each endpoint in windows
+// against objective
+func (t *errorBudgetTracker) report(objective float64, windows []time.Duration) ErrorBudgetReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := ErrorBudgetReport{Objective: objective, Endpoints: make([]EndpointBudget, 0, len(t.endpoints))}
+	now := t.minute()
+	for endpoint, buckets := range t.endpoints {
+		budget := EndpointBudget{Endpoint: endpoint, Windows: make([]BudgetWindow, len(windows))}
+		requested := false
+		for i, length := range windows {
+			window := windowOutcomes(buckets, now, length)
+			window.Window = length
+			window.SuccessRate = 1
+			if window.Requests > 0 {
+				requested = true
+				failureRate := float64(window.Failures) / float64(window.Requests)
+				window.SuccessRate = 1 - failureRate
+				if objective > 0 && objective < 1 {
+					window.BurnRate = failureRate / (1 - objective)
+				}
+			}
+			budget.Windows[i] = window
+		}
+		if requested {
+			report.Endpoints = append(report.Endpoints, budget)
+		}
+	}
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].Endpoint < report.Endpoints[j].Endpoint
+	})
+	return report
+}
+
+// minute returns the index of the current one-minute bucket
+func (t *errorBudgetTracker) minute() int64 {
+	now := time.Now
+	if t.now != nil {
+		now = t.now
+	}
+	return now().UnixNano() / int64(errorBudgetBucket)
+}
+
+// windowOutcomes sums the buckets of the last length, including the current
+// minute
+func windowOutcomes(buckets []outcomeBucket, now int64, length time.Duration) BudgetWindow {
+	minutes := min(int64(length/errorBudgetBucket), int64(len(buckets)))
+	var window BudgetWindow
+	for _, bucket := range buckets {
+		if bucket.requests == 0 || bucket.minute <= now-minutes || bucket.minute > now {
+			continue
+		}
+		window.Requests += bucket.requests
+		window.Failures += bucket.failures
+		window.APIFailures += bucket.apiFailures
+		window.KeyFailures += bucket.keyFailures
+	}
+	return window
+}
+
+// failureCause attributes a failed request to Brave, the API key or neither
+func failureCause(err error) string {
+	switch ErrorCode(err) {
+	case ErrorCodeServerError, ErrorCodeTimeout, ErrorCodeNetwork, ErrorCodeInvalidResponse:
+		return FailureCauseAPI
+	case ErrorCodeAuthFailed, ErrorCodeMissingAPIKey, ErrorCodeInvalidSubscriptionToken,
+		ErrorCodeRateLimited, ErrorCodeForbidden, ErrorCodeFeatureNotInPlan:
+		return FailureCauseKey
+	}
+	return FailureCauseOther
+}
+
+// observeOutcome records the outcome of a request, unless it was cut short
+// by the caller's context
+func (c *Client) observeOutcome(requestURL string, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	parsed, parseErr := url.Parse(requestURL)
+	if parseErr != nil {
+		return
+	}
+	c.errorBudget.observe(parsed.Path, err)
+}
+
+// ErrorBudgetReport returns the success rate of each endpoint the client has
+// requested in rolling windows, and how fast failures burn the error budget
+// of objective, a target success rate such as 0.999, so services can raise
+// burn rate alerts. Failures are broken down by cause, telling whether Brave
+// or the API key is the cause of elevated failures. The windows default to 5
+// minutes, 1 hour and 6 hours; outcomes are kept for 6 hours in one-minute
+// buckets. Requests canceled by the caller are not counted.
+func (c *Client) ErrorBudgetReport(objective float64, windows ...time.Duration) ErrorBudgetReport {
+	if len(windows) == 0 {
+		windows = defaultBurnRateWindows
+	}
+	return c.errorBudget.report(objective, windows)
+}
@@ -0,0 +1,142 @@
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErrorBudgetTracker tests rolling success and burn rates per endpoint
+func TestErrorBudgetTracker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := errorBudgetTracker{now: func() time.Time { return now }}
+	windows := []time.Duration{5 * time.Minute, time.Hour}
+	assert.Empty(t, tracker.report(0.99, windows).Endpoints)
+
+	// An hour ago: healthy
+	now = now.Add(-50 * time.Minute)
+	for range 90 {
+		tracker.observe(WebSearchEndpoint, nil)
+	}
+	tracker.observe(SuggestEndpoint, nil)
+
+	// Now: Brave fails
+	now = now.Add(50 * time.Minute)
+	for range 5 {
+		tracker.observe(WebSearchEndpoint, nil)
+	}
+	tracker.observe(WebSearchEndpoint, NewAPIError(http.StatusServiceUnavailable, "503 Service Unavailable", ErrServerError))
+	tracker.observe(WebSearchEndpoint, &APIError{StatusCode: http.StatusBadGateway})
+	tracker.observe(WebSearchEndpoint, NewAPIError(http.StatusTooManyRequests, "429 Too Many Requests", ErrRateLimit))
+	tracker.observe(WebSearchEndpoint, context.DeadlineExceeded)
+	tracker.observe(WebSearchEndpoint, NewAPIError(http.StatusBadRequest, "400 Bad Request", nil))
+
+	report := tracker.report(0.99, windows)
+	assert.Equal(t, 0.99, report.Objective)
+	require.Len(t, report.Endpoints, 2)
+	assert.Equal(t, SuggestEndpoint, report.Endpoints[0].Endpoint)
+
+	web := report.Endpoints[1]
+	assert.Equal(t, WebSearchEndpoint, web.Endpoint)
+	require.Len(t, web.Windows, 2)
+
+	short := web.Windows[0]
+	assert.Equal(t, 5*time.Minute, short.Window)
+	assert.Equal(t, 10, short.Requests)
+	assert.Equal(t, 5, short.Failures)
+	assert.Equal(t, 3, short.APIFailures)
+	assert.Equal(t, 1, short.KeyFailures)
+	assert.InDelta(t, 0.5, short.SuccessRate, 1e-9)
+	assert.InDelta(t, 50, short.BurnRate, 1e-9)
+	assert.Equal(t, FailureCauseAPI, short.Cause())
+
+	long := web.Windows[1]
+	assert.Equal(t, 100, long.Requests)
+	assert.InDelta(t, 0.95, long.SuccessRate, 1e-9)
+	assert.InDelta(t, 5, long.BurnRate, 1e-9)
+
+	assert.True(t, web.Burning(4.9))
+	assert.False(t, web.Burning(10))
+
+	// The suggest endpoint has no requests in the short window
+	suggest := report.Endpoints[0]
+	assert.Equal(t, 0, suggest.Windows[0].Requests)
+	assert.Equal(t, 1.0, suggest.Windows[0].SuccessRate)
+	assert.Equal(t, "", suggest.Windows[0].Cause())
+	assert.False(t, suggest.Burning(0))
+
+	// Outcomes expire after the longest window
+	now = now.Add(errorBudgetRetention)
+	assert.Empty(t, tracker.report(0.99, windows).Endpoints)
+	tracker.observe(WebSearchEndpoint, nil)
+	report = tracker.report(0.99, windows)
+	require.Len(t, report.Endpoints, 1)
+	assert.Equal(t, 1, report.Endpoints[0].Windows[1].Requests)
+}
+
+// TestBudgetWindowCause tests attributing failures to a cause
+func TestBudgetWindowCause(t *testing.T) {
+	assert.Equal(t, FailureCauseKey, BudgetWindow{Failures: 3, APIFailures: 1, KeyFailures: 2}.Cause())
+	assert.Equal(t, FailureCauseOther, BudgetWindow{Failures: 3, KeyFailures: 1}.Cause())
+	assert.Equal(t, FailureCauseAPI, BudgetWindow{Failures: 2, APIFailures: 1, KeyFailures: 1}.Cause())
+
+	assert.Equal(t, FailureCauseAPI, failureCause(ErrInvalidResponse))
+	assert.Equal(t, FailureCauseKey, failureCause(NewAPIError(http.StatusUnauthorized, "401 Unauthorized", ErrUnauthorized)))
+	assert.Equal(t, FailureCauseOther, failureCause(NewAPIError(http.StatusUnprocessableEntity, "422 Unprocessable Entity", ErrUnprocessableEntity)))
+}
+
+// TestClientErrorBudgetReport tests tracking the outcomes of requests
+func TestClientErrorBudgetReport(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1)%2 == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(0))
+	require.NoError(t, err)
+
+	for range 4 {
+		_, _ = client.WebSearch(context.Background(), "golang", nil)
+	}
+
+	// Requests canceled by the caller are not counted
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.WebSearch(ctx, "golang", nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	report := client.ErrorBudgetReport(0.9)
+	require.Len(t, report.Endpoints, 1)
+	web := report.Endpoints[0]
+	assert.Equal(t, WebSearchEndpoint, web.Endpoint)
+	require.Len(t, web.Windows, len(defaultBurnRateWindows))
+	for _, window := range web.Windows {
+		assert.Equal(t, 4, window.Requests)
+		assert.Equal(t, 2, window.KeyFailures)
+		assert.InDelta(t, 5, window.BurnRate, 1e-9)
+		assert.Equal(t, FailureCauseKey, window.Cause())
+	}
+	assert.True(t, web.Burning(4.9))
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"key_failures":2`)
+
+	// Windows can be chosen
+	report = client.ErrorBudgetReport(0.9, time.Minute)
+	require.Len(t, report.Endpoints[0].Windows, 1)
+	assert.Equal(t, time.Minute, report.Endpoints[0].Windows[0].Window)
+}
@@ -1,54 +1,174 @@
 package bravesearch
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 )
 
+// Error codes are stable, machine-readable identifiers of errors (see
+// ErrorCode). Unlike error messages they never change, so consumers outside
+// Go can branch on them.
+const (
+	// ErrorCodeMissingAPIKey is the code of ErrMissingAPIKey
+	ErrorCodeMissingAPIKey = "missing_api_key"
+
+	// ErrorCodeAuthFailed is the code of ErrInvalidAPIKey, ErrUnauthorized and 401 responses
+	ErrorCodeAuthFailed = "auth_failed"
+
+	// ErrorCodeRateLimited is the code of ErrRateLimit and 429 responses
+	ErrorCodeRateLimited = "rate_limited"
+
+	// ErrorCodeForbidden is the code of ErrForbidden and 403 responses
+	ErrorCodeForbidden = "forbidden"
+
+	// ErrorCodeNotFound is the code of ErrNotFound and 404 responses
+	ErrorCodeNotFound = "not_found"
+
+	// ErrorCodeServerError is the code of ErrServerError and 5xx responses
+	ErrorCodeServerError = "server_error"
+
+	// ErrorCodeInvalidResponse is the code of ErrInvalidResponse
+	ErrorCodeInvalidResponse = "invalid_response"
+
+	// ErrorCodeInvalidParams is the code of ErrInvalidParameters
+	ErrorCodeInvalidParams = "invalid_params"
+
+	// ErrorCodeQueryTooLong is the code of ErrQueryTooLong
+	ErrorCodeQueryTooLong = "query_too_long"
+
+	// ErrorCodeEmptyQuery is the code of ErrEmptyQuery
+	ErrorCodeEmptyQuery = "empty_query"
+
+	// ErrorCodeUnprocessableEntity is the code of ErrUnprocessableEntity and 422 responses
+	ErrorCodeUnprocessableEntity = "unprocessable_entity"
+
+	// ErrorCodeInvalidSubscriptionToken is the code of ErrSubscriptionTokenInvalid
+	ErrorCodeInvalidSubscriptionToken = "invalid_subscription_token"
+
+	// ErrorCodeInvalidLocation is the code of ErrInvalidLocation
+	ErrorCodeInvalidLocation = "invalid_location"
+
+	// ErrorCodeInvalidSignature is the code of ErrInvalidSignature
+	ErrorCodeInvalidSignature = "invalid_signature"
+
+	// ErrorCodeSignatureExpired is the code of ErrSignatureExpired
+	ErrorCodeSignatureExpired = "signature_expired"
+
+	// ErrorCodeNoResults is the code of ErrNoResults and EmptyResultsError
+	ErrorCodeNoResults = "no_results"
+
+	// ErrorCodeFeatureNotInPlan is the code of ErrFeatureNotInPlan and FeatureNotInPlanError
+	ErrorCodeFeatureNotInPlan = "feature_not_in_plan"
+
+	// ErrorCodeFaultInjectionDisabled is the code of ErrFaultInjectionDisabled
+	ErrorCodeFaultInjectionDisabled = "fault_injection_disabled"
+
+	// ErrorCodeAPIError is the code of API errors with any other status
+	ErrorCodeAPIError = "api_error"
+
+	// ErrorCodeCanceled is the code of requests whose context was canceled
+	ErrorCodeCanceled = "canceled"
+
+	// ErrorCodeTimeout is the code of requests whose context deadline passed
+	ErrorCodeTimeout = "timeout"
+
+	// ErrorCodeNetwork is the code of requests that got no response, such
+	// as when the connection failed
+	ErrorCodeNetwork = "network_error"
+
+	// ErrorCodeUnknown is the code of any other error
+	ErrorCodeUnknown = "unknown"
+)
+
 var (
 	// ErrMissingAPIKey is returned when the API key is missing
-	ErrMissingAPIKey = errors.New("missing API key")
+	ErrMissingAPIKey = newError(ErrorCodeMissingAPIKey, "missing API key")
 
 	// ErrInvalidAPIKey is returned when the API key is invalid
-	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrInvalidAPIKey = newError(ErrorCodeAuthFailed, "invalid API key")
 
 	// ErrRateLimit is returned when the API rate limit is exceeded
-	ErrRateLimit = errors.New("rate limit exceeded")
+	ErrRateLimit = newError(ErrorCodeRateLimited, "rate limit exceeded")
 
 	// ErrUnauthorized is returned when the API returns a 401 Unauthorized
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnauthorized = newError(ErrorCodeAuthFailed, "unauthorized")
 
 	// ErrForbidden is returned when the API returns a 403 Forbidden
-	ErrForbidden = errors.New("forbidden")
+	ErrForbidden = newError(ErrorCodeForbidden, "forbidden")
 
 	// ErrNotFound is returned when the API returns a 404 Not Found
-	ErrNotFound = errors.New("not found")
+	ErrNotFound = newError(ErrorCodeNotFound, "not found")
 
 	// ErrServerError is returned when the API returns a 5xx error
-	ErrServerError = errors.New("server error")
+	ErrServerError = newError(ErrorCodeServerError, "server error")
 
 	// ErrInvalidResponse is returned when the API returns an invalid response
-	ErrInvalidResponse = errors.New("invalid response")
+	ErrInvalidResponse = newError(ErrorCodeInvalidResponse, "invalid response")
 
 	// ErrInvalidParameters is returned when invalid parameters are provided
-	ErrInvalidParameters = errors.New("invalid parameters")
+	ErrInvalidParameters = newError(ErrorCodeInvalidParams, "invalid parameters")
 
-	// ErrQueryTooLong is returned when the query is too long (>400 chars or >50 words)
-	ErrQueryTooLong = errors.New("query too long (max 400 chars or 50 words)")
+	// ErrQueryTooLong is returned when the query exceeds the configured QueryLimits
+	// (by default 400 characters or 50 words)
+	ErrQueryTooLong = newError(ErrorCodeQueryTooLong, "query too long")
 
 	// ErrEmptyQuery is returned when an empty query is provided
-	ErrEmptyQuery = errors.New("query cannot be empty")
+	ErrEmptyQuery = newError(ErrorCodeEmptyQuery, "query cannot be empty")
 
 	// ErrUnprocessableEntity is returned when the API returns a 422 Unprocessable Entity
-	ErrUnprocessableEntity = errors.New("unprocessable entity")
+	ErrUnprocessableEntity = newError(ErrorCodeUnprocessableEntity, "unprocessable entity")
 
 	// ErrSubscriptionTokenInvalid is returned when the subscription token is invalid
-	ErrSubscriptionTokenInvalid = errors.New("invalid subscription token")
+	ErrSubscriptionTokenInvalid = newError(ErrorCodeInvalidSubscriptionToken, "invalid subscription token")
+
+	// ErrInvalidLocation is returned when a Location has invalid fields
+	ErrInvalidLocation = newError(ErrorCodeInvalidLocation, "invalid location")
+
+	// ErrInvalidSignature is returned when a request signature is missing or does not match
+	ErrInvalidSignature = newError(ErrorCodeInvalidSignature, "invalid request signature")
+
+	// ErrSignatureExpired is returned when a request signature timestamp is outside the allowed skew
+	ErrSignatureExpired = newError(ErrorCodeSignatureExpired, "request signature expired")
+
+	// ErrNoResults is returned when a search has no results at all (see EmptyResultsError)
+	ErrNoResults = newError(ErrorCodeNoResults, "no results")
+
+	// ErrFeatureNotInPlan is returned when a request uses a feature, such as
+	// extra snippets or summaries, that the subscription plan does not include
+	// (see FeatureNotInPlanError)
+	ErrFeatureNotInPlan = newError(ErrorCodeFeatureNotInPlan, "feature not in plan")
+
+	// ErrFaultInjectionDisabled is returned by WithFaultInjection in builds without the bravesearch_faults tag
+	ErrFaultInjectionDisabled = newError(ErrorCodeFaultInjectionDisabled, "fault injection requires the bravesearch_faults build tag")
 )
 
+// codedError is a sentinel error with an error code
+type codedError struct {
+	code    string
+	message string
+}
+
+// newError returns a sentinel error with message and code
+func newError(code, message string) error {
+	return &codedError{code: code, message: message}
+}
+
+// Error implements the error interface
+func (e *codedError) Error() string {
+	return e.message
+}
+
+// ErrCode returns the error code
+func (e *codedError) ErrCode() string {
+	return e.code
+}
+
 // APIError represents an error returned by the Brave Search API
 type APIError struct {
 	StatusCode int    `json:"status_code,omitempty"`
@@ -69,6 +189,138 @@ func (e *APIError) Unwrap() error {
 	return e.Err
 }
 
+// ErrCode returns the error code of the wrapped error, or else the code for
+// the status code
+func (e *APIError) ErrCode() string {
+	if e.Err != nil {
+		if code := ErrorCode(e.Err); code != ErrorCodeUnknown {
+			return code
+		}
+	}
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrorCodeAuthFailed
+	case e.StatusCode == http.StatusForbidden:
+		return ErrorCodeForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrorCodeUnprocessableEntity
+	case e.StatusCode >= 500:
+		return ErrorCodeServerError
+	}
+	return ErrorCodeAPIError
+}
+
+// EmptyResultsError is returned for searches that succeeded but found
+// nothing, as opposed to requests that failed or responses that could not be
+// parsed. It carries the API's hints on what to do next.
+type EmptyResultsError struct {
+	// Query is the query as the API understood it
+	Query string
+
+	// Altered is the spellchecked query the API searched for, if any
+	Altered string
+
+	// BadResults is set when the API considers the results of the query poor
+	BadResults bool
+
+	// ShouldFallback is set when the API suggests falling back to another
+	// source of results
+	ShouldFallback bool
+
+	// Response is the empty response
+	Response *WebSearchResponse
+}
+
+// Error implements the error interface
+func (e *EmptyResultsError) Error() string {
+	var hints []string
+	if e.BadResults {
+		hints = append(hints, "bad results")
+	}
+	if e.ShouldFallback {
+		hints = append(hints, "should fall back")
+	}
+	if len(hints) == 0 {
+		return ErrNoResults.Error()
+	}
+	return fmt.Sprintf("%s (%s)", ErrNoResults, strings.Join(hints, ", "))
+}
+
+// Unwrap returns ErrNoResults
+func (e *EmptyResultsError) Unwrap() error {
+	return ErrNoResults
+}
+
+// ErrCode returns ErrorCodeNoResults
+func (e *EmptyResultsError) ErrCode() string {
+	return ErrorCodeNoResults
+}
+
+// FeatureNotInPlanError is returned when the API rejects a request because
+// it uses features the subscription plan does not include
+type FeatureNotInPlanError struct {
+	// Features are the plan-gated parameters the request used, such as
+	// "extra_snippets" or "summary"
+	Features []string
+
+	// Detail is the explanation given by the API, if any
+	Detail string
+}
+
+// Error implements the error interface, with guidance on how to resolve it
+func (e *FeatureNotInPlanError) Error() string {
+	msg := ErrFeatureNotInPlan.Error()
+	if len(e.Features) > 0 {
+		msg += ": " + strings.Join(e.Features, ", ")
+	}
+	if e.Detail != "" {
+		msg += " (" + e.Detail + ")"
+	}
+	return msg + "; remove the parameters from the request or upgrade the subscription plan (e.g. to Data for AI Pro)"
+}
+
+// Unwrap returns ErrFeatureNotInPlan
+func (e *FeatureNotInPlanError) Unwrap() error {
+	return ErrFeatureNotInPlan
+}
+
+// ErrCode returns ErrorCodeFeatureNotInPlan
+func (e *FeatureNotInPlanError) ErrCode() string {
+	return ErrorCodeFeatureNotInPlan
+}
+
+// planGatedParams are the request parameters that only some plans support
+var planGatedParams = []string{"extra_snippets", "summary", "enable_rich_callback"}
+
+// featureNotInPlan returns a FeatureNotInPlanError if body is an API error
+// reporting an option that is not in the plan
+func featureNotInPlan(req *http.Request, body []byte) (*FeatureNotInPlanError, bool) {
+	var apiErr struct {
+		Error struct {
+			Code   string `json:"code"`
+			Detail string `json:"detail"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Error.Code != "OPTION_NOT_IN_PLAN" {
+		return nil, false
+	}
+
+	err := &FeatureNotInPlanError{Detail: apiErr.Error.Detail}
+	if req != nil && req.URL != nil {
+		query := req.URL.Query()
+		for _, param := range planGatedParams {
+			if value := query.Get(param); value != "" && value != "false" && value != "0" {
+				err.Features = append(err.Features, param)
+			}
+		}
+	}
+	return err, true
+}
+
 // NewAPIError creates a new APIError
 func NewAPIError(statusCode int, message string, err error) *APIError {
 	return &APIError{
@@ -86,14 +338,19 @@ func NewHTTPError(resp *http.Response) *APIError {
 		err = ErrUnauthorized
 	case http.StatusForbidden:
 		err = ErrForbidden
+		if planErr, ok := featureNotInPlan(resp.Request, readErrorBody(resp)); ok {
+			err = planErr
+		}
 	case http.StatusNotFound:
 		err = ErrNotFound
 	case http.StatusTooManyRequests:
 		err = ErrRateLimit
 	case http.StatusUnprocessableEntity:
-		body, _ := io.ReadAll(resp.Body)
+		body := readErrorBody(resp)
 		if strings.Contains(string(body), "SUBSCRIPTION_TOKEN_INVALID") {
 			err = ErrSubscriptionTokenInvalid
+		} else if planErr, ok := featureNotInPlan(resp.Request, body); ok {
+			err = planErr
 		} else {
 			err = ErrUnprocessableEntity
 		}
@@ -112,6 +369,44 @@ func NewHTTPError(resp *http.Response) *APIError {
 	}
 }
 
+// readErrorBody reads the body of an error response, if any
+func readErrorBody(resp *http.Response) []byte {
+	if resp.Body == nil {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return body
+}
+
+// ErrorCode returns the stable code of err: that of the outermost error in
+// its chain with an ErrCode method, such as APIError or the sentinel errors,
+// or else ErrorCodeCanceled, ErrorCodeTimeout, ErrorCodeNetwork or
+// ErrorCodeUnknown. It returns "" for a nil error.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var coded interface{ ErrCode() string }
+	if errors.As(err, &coded) {
+		return coded.ErrCode()
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorCodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorCodeTimeout
+		}
+		return ErrorCodeNetwork
+	}
+	return ErrorCodeUnknown
+}
+
 // IsRateLimitError checks if the error is a rate limit error
 func IsRateLimitError(err error) bool {
 	var apiErr *APIError
@@ -149,3 +444,13 @@ func IsUnprocessableEntity(err error) bool {
 	}
 	return errors.Is(err, ErrUnprocessableEntity)
 }
+
+// IsFeatureNotInPlan checks if the error reports a feature the subscription plan does not include
+func IsFeatureNotInPlan(err error) bool {
+	return errors.Is(err, ErrFeatureNotInPlan)
+}
+
+// IsEmptyResultsError checks if the error reports a search without results
+func IsEmptyResultsError(err error) bool {
+	return errors.Is(err, ErrNoResults)
+}
@@ -1,11 +1,18 @@
 package bravesearch
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestAPIError tests the APIError type
@@ -108,6 +115,47 @@ func TestNewHTTPError(t *testing.T) {
 	assert.Contains(t, apiErr.Err.Error(), "unexpected status code: 418")
 }
 
+// TestFeatureNotInPlan tests detecting requests for features the plan does not include
+func TestFeatureNotInPlan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"type": "ErrorResponse", "error": {"code": "OPTION_NOT_IN_PLAN", "detail": "The option 'extra_snippets' is not available in your plan.", "status": 422}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", &WebSearchParams{ExtraSnippets: true, Spellcheck: Bool(false)})
+	require.Error(t, err)
+	assert.True(t, IsFeatureNotInPlan(err))
+	assert.True(t, IsUnprocessableEntity(err))
+
+	var planErr *FeatureNotInPlanError
+	require.ErrorAs(t, err, &planErr)
+	assert.Equal(t, []string{"extra_snippets"}, planErr.Features)
+	assert.Equal(t, "The option 'extra_snippets' is not available in your plan.", planErr.Detail)
+	assert.Contains(t, err.Error(), "upgrade the subscription plan")
+
+	// Other errors are left alone
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Status:     "403 Forbidden",
+		Body:       io.NopCloser(strings.NewReader(`{"error": {"code": "FORBIDDEN"}}`)),
+	}
+	assert.Equal(t, ErrForbidden, NewHTTPError(resp).Err)
+
+	resp = &http.Response{
+		StatusCode: http.StatusForbidden,
+		Status:     "403 Forbidden",
+		Body:       io.NopCloser(strings.NewReader(`{"error": {"code": "OPTION_NOT_IN_PLAN"}}`)),
+	}
+	apiErr := NewHTTPError(resp)
+	assert.True(t, IsFeatureNotInPlan(apiErr))
+	assert.Equal(t, "feature not in plan; remove the parameters from the request or upgrade the subscription plan (e.g. to Data for AI Pro)", apiErr.Err.Error())
+}
+
 // TestIsRateLimitError tests the rate limit error detection
 func TestIsRateLimitError(t *testing.T) {
 	// Test direct error
@@ -226,3 +274,54 @@ func TestIsServerError(t *testing.T) {
 	}
 	assert.False(t, IsServerError(apiErr))
 }
+
+// TestErrorCode tests the stable codes of errors
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"sentinel", ErrRateLimit, ErrorCodeRateLimited},
+		{"wrapped sentinel", fmt.Errorf("%w: count must be positive", ErrInvalidParameters), ErrorCodeInvalidParams},
+		{"invalid API key", ErrInvalidAPIKey, ErrorCodeAuthFailed},
+		{"API error with sentinel", NewAPIError(http.StatusTooManyRequests, "429 Too Many Requests", ErrRateLimit), ErrorCodeRateLimited},
+		{"API error with status only", NewAPIError(http.StatusUnauthorized, "401 Unauthorized", errors.New("custom")), ErrorCodeAuthFailed},
+		{"API error with server status", NewAPIError(http.StatusBadGateway, "502 Bad Gateway", nil), ErrorCodeServerError},
+		{"API error with other status", NewAPIError(http.StatusTeapot, "418 I'm a teapot", nil), ErrorCodeAPIError},
+		{"empty results", &EmptyResultsError{}, ErrorCodeNoResults},
+		{"feature not in plan", NewAPIError(http.StatusForbidden, "403 Forbidden", &FeatureNotInPlanError{}), ErrorCodeFeatureNotInPlan},
+		{"canceled", fmt.Errorf("failed to send request: %w", context.Canceled), ErrorCodeCanceled},
+		{"deadline", context.DeadlineExceeded, ErrorCodeTimeout},
+		{"network", &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection refused")}, ErrorCodeNetwork},
+		{"unknown", errors.New("something else"), ErrorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCode(tt.err))
+		})
+	}
+
+	// Messages are unchanged
+	assert.Equal(t, "rate limit exceeded", ErrRateLimit.Error())
+}
+
+// TestErrorCode_Response tests the codes of errors returned for API responses
+func TestErrorCode_Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(0))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeRateLimited, ErrorCode(err))
+
+	_, err = client.WebSearch(context.Background(), "", nil)
+	assert.Equal(t, ErrorCodeEmptyQuery, ErrorCode(err))
+}
@@ -0,0 +1,72 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// WebSearchExactly performs a web search gathering n unique web results. The
+// API may return fewer results than requested, so further pages are fetched
+// until n results with distinct URLs are gathered or the API has no more.
+//
+// The returned response is the first page, with its web results replaced by
+// the gathered ones; it holds fewer than n results if the API was exhausted.
+// params.Offset is the page to start from, and params.Count the page size.
+func (c *Client) WebSearchExactly(ctx context.Context, query string, n int, params *WebSearchParams) (*WebSearchResponse, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: result count %d must be positive", ErrInvalidParameters, n)
+	}
+
+	pageParams := &WebSearchParams{}
+	if params != nil {
+		*pageParams = *params
+	}
+	if pageParams.Count == 0 || pageParams.Count > MaxCount {
+		pageParams.Count = MaxCount
+	}
+
+	var response *WebSearchResponse
+	var results []SearchResult
+	seen := make(map[string]bool)
+
+	for ; pageParams.Offset <= MaxOffset && len(results) < n; pageParams.Offset++ {
+		page, err := c.WebSearch(ctx, query, pageParams)
+		if err != nil {
+			if response != nil && errors.Is(err, ErrNoResults) {
+				break
+			}
+			return nil, err
+		}
+		if response == nil {
+			response = page
+		}
+
+		added := 0
+		for _, result := range page.GetWebResults() {
+			if seen[result.URL] {
+				continue
+			}
+			seen[result.URL] = true
+			results = append(results, result)
+			added++
+		}
+
+		if added == 0 || !page.HasMoreResults() {
+			break
+		}
+	}
+
+	if len(results) > n {
+		results = results[:n]
+	}
+
+	web := Search{Type: "search"}
+	if response.Web != nil {
+		web = *response.Web
+	}
+	web.Results = results
+	response.Web = &web
+
+	return response, nil
+}
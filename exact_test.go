@@ -0,0 +1,167 @@
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedResponse serves pages of web results by offset; more results are
+// available while a page exists after the requested one
+func pagedResponse(pages [][]string) func(r *http.Request) mockResponse {
+	return func(r *http.Request) mockResponse {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		response := WebSearchResponse{
+			Type:  "search",
+			Query: &Query{MoreResultsAvailable: offset+1 < len(pages)},
+			Web:   &Search{Type: "search", FamilyFriendly: true},
+		}
+		if offset < len(pages) {
+			for _, u := range pages[offset] {
+				response.Web.Results = append(response.Web.Results, SearchResult{Title: u, URL: u})
+			}
+		}
+
+		body, err := json.Marshal(response)
+		if err != nil {
+			return mockResponse{Status: http.StatusInternalServerError}
+		}
+		return mockResponse{Body: string(body)}
+	}
+}
+
+// requestedOffsets returns the offsets of the pages requested from server,
+// checking that each request asked for the most results
+func requestedOffsets(t *testing.T, server *mockServer) []int {
+	var offsets []int
+	for _, r := range server.receivedRequests() {
+		assert.Equal(t, strconv.Itoa(MaxCount), r.URL.Query().Get("count"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// resultURLs returns the URLs of the web results of response
+func resultURLs(response *WebSearchResponse) []string {
+	var urls []string
+	for _, result := range response.GetWebResults() {
+		urls = append(urls, result.URL)
+	}
+	return urls
+}
+
+// TestWebSearchExactly tests gathering exactly n unique results over several pages
+func TestWebSearchExactly(t *testing.T) {
+	pages := [][]string{
+		{"https://a.example/", "https://b.example/"},
+		{"https://b.example/", "https://c.example/", "https://d.example/"},
+		{"https://e.example/"},
+	}
+
+	tests := []struct {
+		name     string
+		n        int
+		expected []string
+		offsets  []int
+	}{
+		{"first page suffices", 2, []string{"https://a.example/", "https://b.example/"}, []int{0}},
+		{"duplicates skipped and truncated", 3, []string{"https://a.example/", "https://b.example/", "https://c.example/"}, []int{0, 1}},
+		{"all pages", 5, []string{"https://a.example/", "https://b.example/", "https://c.example/", "https://d.example/", "https://e.example/"}, []int{0, 1, 2}},
+		{"exhausted", 10, []string{"https://a.example/", "https://b.example/", "https://c.example/", "https://d.example/", "https://e.example/"}, []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockServer(t, pagedResponse(pages))
+
+			client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+			require.NoError(t, err)
+
+			response, err := client.WebSearchExactly(context.Background(), "golang", tt.n, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resultURLs(response))
+			assert.Equal(t, tt.offsets, requestedOffsets(t, server))
+			assert.True(t, response.Web.FamilyFriendly)
+		})
+	}
+}
+
+// TestWebSearchExactlyStopsOnRepeatedPage tests that a page without new results ends the search
+func TestWebSearchExactlyStopsOnRepeatedPage(t *testing.T) {
+	var pages [][]string
+	for i := 0; i <= MaxOffset+1; i++ {
+		pages = append(pages, []string{"https://a.example/"})
+	}
+
+	server := newMockServer(t, pagedResponse(pages))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	response, err := client.WebSearchExactly(context.Background(), "golang", 5, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.example/"}, resultURLs(response))
+	assert.Equal(t, []int{0, 1}, requestedOffsets(t, server))
+}
+
+// TestWebSearchExactlyMaxOffset tests that paging stops at the last offset the API accepts
+func TestWebSearchExactlyMaxOffset(t *testing.T) {
+	var pages [][]string
+	for i := 0; i <= MaxOffset+1; i++ {
+		pages = append(pages, []string{fmt.Sprintf("https://%d.example/", i)})
+	}
+
+	server := newMockServer(t, pagedResponse(pages))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	response, err := client.WebSearchExactly(context.Background(), "golang", 100, &WebSearchParams{Offset: MaxOffset - 1})
+	require.NoError(t, err)
+	assert.Len(t, response.Web.Results, 2)
+	assert.Equal(t, []int{MaxOffset - 1, MaxOffset}, requestedOffsets(t, server))
+}
+
+// TestWebSearchExactlyErrors tests invalid counts and failing pages
+func TestWebSearchExactlyErrors(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.WebSearchExactly(context.Background(), "golang", 0, nil)
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	server := newMockServer(t, func(r *http.Request) mockResponse {
+		return mockResponse{Status: http.StatusUnauthorized}
+	})
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(0))
+	require.NoError(t, err)
+
+	_, err = client.WebSearchExactly(context.Background(), "golang", 5, nil)
+	assert.True(t, IsAuthError(err))
+}
+
+// TestWebSearchExactlyEmptyResultsError tests that an empty page ends the
+// search when empty results are errors
+func TestWebSearchExactlyEmptyResultsError(t *testing.T) {
+	pages := [][]string{{"https://a.example/"}, {}}
+
+	server := newMockServer(t, pagedResponse(pages))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithEmptyResultsError(true))
+	require.NoError(t, err)
+
+	response, err := client.WebSearchExactly(context.Background(), "golang", 5, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.example/"}, resultURLs(response))
+
+	_, err = client.WebSearchExactly(context.Background(), "golang", 5, &WebSearchParams{Offset: 1})
+	assert.ErrorIs(t, err, ErrNoResults)
+}
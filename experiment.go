@@ -0,0 +1,181 @@
+package bravesearch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ExperimentArm is a search configuration compared in an Experiment, such
+// as a parameter preset or a Goggle
+type ExperimentArm struct {
+	Name    string
+	Options []SearchOption
+
+	// Weight is the share of units assigned to the arm relative to the
+	// other arms; zero counts as one
+	Weight int
+}
+
+// ExperimentAssignment tags a response with the experiment arm that made it
+type ExperimentAssignment struct {
+	Experiment string
+	Arm        string
+}
+
+// ArmMetrics are the results of an experiment arm so far
+type ArmMetrics struct {
+	Arm string
+
+	// Searches is the number of searches made, Errors the number of those
+	// that failed and EmptyResults the number without web results
+	Searches     int
+	Errors       int
+	EmptyResults int
+
+	// MeanResults is the mean number of web results of successful searches
+	MeanResults float64
+
+	// MeanLatency is the mean duration of the searches
+	MeanLatency time.Duration
+
+	// Feedback aggregates the feedback recorded through the experiment
+	Feedback FeedbackStats
+}
+
+// armMetrics accumulates the metrics of an arm
+type armMetrics struct {
+	ArmMetrics
+	results int
+	latency time.Duration
+}
+
+// Experiment is an A/B test of search configurations. Units, typically user
+// or session IDs, are assigned to arms by a hash of the experiment name and
+// the unit, so a unit stays in its arm across searches and processes, and
+// separate experiments split units independently. An Experiment is safe for
+// concurrent use; its metrics live in memory only.
+type Experiment struct {
+	client *Client
+	name   string
+	arms   []ExperimentArm
+
+	// weight is the sum of the weights of the arms
+	weight uint64
+
+	mu      sync.Mutex
+	metrics []armMetrics
+}
+
+// NewExperiment creates an Experiment over arms, which must have distinct,
+// non-empty names and non-negative weights
+func (c *Client) NewExperiment(name string, arms ...ExperimentArm) (*Experiment, error) {
+	if name == "" || len(arms) == 0 {
+		return nil, fmt.Errorf("%w: an experiment needs a name and at least one arm", ErrInvalidParameters)
+	}
+
+	experiment := &Experiment{client: c, name: name, metrics: make([]armMetrics, len(arms))}
+	names := make(map[string]bool, len(arms))
+	for i, arm := range arms {
+		if arm.Name == "" || names[arm.Name] {
+			return nil, fmt.Errorf("%w: arm names must be distinct and non-empty", ErrInvalidParameters)
+		}
+		names[arm.Name] = true
+		if arm.Weight < 0 {
+			return nil, fmt.Errorf("%w: arm %q has a negative weight", ErrInvalidParameters, arm.Name)
+		}
+		if arm.Weight == 0 {
+			arm.Weight = 1
+		}
+		if _, err := NewSearchParams(arm.Options...); err != nil {
+			return nil, fmt.Errorf("arm %q: %w", arm.Name, err)
+		}
+
+		experiment.arms = append(experiment.arms, arm)
+		experiment.weight += uint64(arm.Weight)
+		experiment.metrics[i].Arm = arm.Name
+	}
+	return experiment, nil
+}
+
+// Assign returns the name of the arm of unit
+func (e *Experiment) Assign(unit string) string {
+	return e.arms[e.assign(unit)].Name
+}
+
+// Search performs a web search with the configuration of the arm of unit,
+// tagging the response with the arm and counting it in the arm's metrics
+func (e *Experiment) Search(ctx context.Context, unit, query string) (*WebSearchResponse, error) {
+	index := e.assign(unit)
+
+	start := time.Now()
+	response, err := e.client.WebSearchWithOptions(ctx, query, e.arms[index].Options...)
+	latency := time.Since(start)
+
+	e.mu.Lock()
+	metrics := &e.metrics[index]
+	metrics.Searches++
+	metrics.latency += latency
+	switch {
+	case err != nil:
+		metrics.Errors++
+	case response.Web == nil || len(response.Web.Results) == 0:
+		metrics.EmptyResults++
+	default:
+		metrics.results += len(response.Web.Results)
+	}
+	e.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	response.Experiment = &ExperimentAssignment{Experiment: e.name, Arm: e.arms[index].Name}
+	return response, nil
+}
+
+// RecordFeedback records feedback on a result shown to unit with the
+// client's RecordFeedback and counts it in the metrics of the unit's arm
+func (e *Experiment) RecordFeedback(ctx context.Context, unit, queryID, resultURL string, signal FeedbackSignal) error {
+	if err := e.client.RecordFeedback(ctx, queryID, resultURL, signal); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.metrics[e.assign(unit)].Feedback.add(signal)
+	return nil
+}
+
+// Metrics returns the metrics of each arm, in the order the arms were given
+func (e *Experiment) Metrics() []ArmMetrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	metrics := make([]ArmMetrics, len(e.metrics))
+	for i, arm := range e.metrics {
+		metrics[i] = arm.ArmMetrics
+		if arm.Searches > 0 {
+			metrics[i].MeanLatency = arm.latency / time.Duration(arm.Searches)
+		}
+		if succeeded := arm.Searches - arm.Errors; succeeded > 0 {
+			metrics[i].MeanResults = float64(arm.results) / float64(succeeded)
+		}
+	}
+	return metrics
+}
+
+// assign returns the index of the arm of unit
+func (e *Experiment) assign(unit string) int {
+	sum := sha256.Sum256([]byte(e.name + "\x00" + unit))
+	bucket := binary.BigEndian.Uint64(sum[:8]) % e.weight
+	for i, arm := range e.arms {
+		if bucket < uint64(arm.Weight) {
+			return i
+		}
+		bucket -= uint64(arm.Weight)
+	}
+	return len(e.arms) - 1
+}
@@ -0,0 +1,132 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// experimentResponse returns as many web results as requested, failing for a count of 13
+func experimentResponse(r *http.Request) mockResponse {
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if count == 13 {
+		return mockResponse{Status: http.StatusInternalServerError}
+	}
+	urls := make([]string, count)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+	return mockResponse{Body: mockWebResults(urls...)}
+}
+
+// TestExperimentAssign tests that units are assigned deterministically by weight
+func TestExperimentAssign(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	experiment, err := client.NewExperiment("count",
+		ExperimentArm{Name: "control"},
+		ExperimentArm{Name: "treatment", Weight: 3},
+	)
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for i := range 4000 {
+		unit := fmt.Sprintf("user-%d", i)
+		arm := experiment.Assign(unit)
+		assert.Equal(t, arm, experiment.Assign(unit))
+		counts[arm]++
+	}
+	assert.InDelta(t, 1000, counts["control"], 100)
+	assert.InDelta(t, 3000, counts["treatment"], 100)
+
+	// Another experiment splits the same units independently
+	other, err := client.NewExperiment("goggle", ExperimentArm{Name: "control"}, ExperimentArm{Name: "treatment", Weight: 3})
+	require.NoError(t, err)
+	differ := 0
+	for i := range 100 {
+		unit := fmt.Sprintf("user-%d", i)
+		if experiment.Assign(unit) != other.Assign(unit) {
+			differ++
+		}
+	}
+	assert.Positive(t, differ)
+}
+
+// TestExperimentSearch tests tagging responses and collecting arm metrics
+func TestExperimentSearch(t *testing.T) {
+	client, err := NewClient("test-api-key", WithBaseURL(newMockServer(t, experimentResponse).URL), WithRetries(0))
+	require.NoError(t, err)
+
+	experiment, err := client.NewExperiment("count",
+		ExperimentArm{Name: "five", Options: []SearchOption{WithCount(5)}},
+		ExperimentArm{Name: "broken", Options: []SearchOption{WithCount(13)}},
+	)
+	require.NoError(t, err)
+
+	units := map[string]string{}
+	for i := 0; len(units) < 2; i++ {
+		unit := fmt.Sprintf("user-%d", i)
+		if _, ok := units[experiment.Assign(unit)]; !ok {
+			units[experiment.Assign(unit)] = unit
+		}
+	}
+
+	for range 2 {
+		response, err := experiment.Search(context.Background(), units["five"], "golang")
+		require.NoError(t, err)
+		assert.Equal(t, &ExperimentAssignment{Experiment: "count", Arm: "five"}, response.Experiment)
+		assert.Len(t, response.Web.Results, 5)
+	}
+	_, err = experiment.Search(context.Background(), units["broken"], "golang")
+	assert.Error(t, err)
+
+	require.NoError(t, experiment.RecordFeedback(context.Background(), units["five"], "q1", "https://example.com/0", FeedbackLike))
+	assert.Error(t, experiment.RecordFeedback(context.Background(), units["five"], "q1", "https://example.com/0", "share"))
+
+	metrics := experiment.Metrics()
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "five", metrics[0].Arm)
+	assert.Equal(t, 2, metrics[0].Searches)
+	assert.Zero(t, metrics[0].Errors)
+	assert.Equal(t, 5.0, metrics[0].MeanResults)
+	assert.Positive(t, metrics[0].MeanLatency)
+	assert.Equal(t, FeedbackStats{Likes: 1}, metrics[0].Feedback)
+
+	assert.Equal(t, "broken", metrics[1].Arm)
+	assert.Equal(t, 1, metrics[1].Searches)
+	assert.Equal(t, 1, metrics[1].Errors)
+	assert.Zero(t, metrics[1].MeanResults)
+
+	// The feedback also reaches the client's feedback store
+	stats, err := client.QueryFeedback(context.Background(), "q1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Likes)
+}
+
+// TestNewExperimentInvalid tests rejecting invalid experiments
+func TestNewExperimentInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.NewExperiment("", ExperimentArm{Name: "a"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewExperiment("e")
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewExperiment("e", ExperimentArm{Name: "a"}, ExperimentArm{Name: "a"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewExperiment("e", ExperimentArm{Name: "a", Weight: -1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewExperiment("e", ExperimentArm{Name: "a", Options: []SearchOption{WithCount(0)}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.Contains(t, err.Error(), `arm "a"`)
+}
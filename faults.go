@@ -0,0 +1,54 @@
+package bravesearch
+
+import (
+	"fmt"
+	"time"
+)
+
+// FaultPolicy describes the faults injected into API requests for chaos
+// testing. Probabilities range from 0 (never) to 1 (always) and are applied
+// independently per attempt, in order: delay, drop, error status, corruption.
+type FaultPolicy struct {
+	// DelayProbability is the chance of delaying a request by up to MaxDelay
+	DelayProbability float64
+	MaxDelay         time.Duration
+
+	// DropProbability is the chance of failing a request with a network error
+	DropProbability float64
+
+	// ErrorProbability is the chance of answering with ErrorStatus
+	// (default 503 Service Unavailable) instead of sending the request
+	ErrorProbability float64
+	ErrorStatus      int
+
+	// CorruptProbability is the chance of truncating the response body, so it
+	// fails to parse
+	CorruptProbability float64
+
+	// Seed makes the injected faults reproducible; zero picks a random seed
+	Seed uint64
+}
+
+// Validate checks that the probabilities and durations are in range
+func (p *FaultPolicy) Validate() error {
+	for _, probability := range []struct {
+		name  string
+		value float64
+	}{
+		{"delay", p.DelayProbability},
+		{"drop", p.DropProbability},
+		{"error", p.ErrorProbability},
+		{"corrupt", p.CorruptProbability},
+	} {
+		if probability.value < 0 || probability.value > 1 {
+			return fmt.Errorf("%w: %s probability %v is out of range [0, 1]", ErrInvalidParameters, probability.name, probability.value)
+		}
+	}
+	if p.MaxDelay < 0 {
+		return fmt.Errorf("%w: max delay must not be negative", ErrInvalidParameters)
+	}
+	if p.ErrorStatus != 0 && (p.ErrorStatus < 400 || p.ErrorStatus > 599) {
+		return fmt.Errorf("%w: error status %d is not an HTTP error status", ErrInvalidParameters, p.ErrorStatus)
+	}
+	return nil
+}
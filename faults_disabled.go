@@ -0,0 +1,20 @@
+//go:build !bravesearch_faults
+
+package bravesearch
+
+import "net/http"
+
+// WithFaultInjection injects the faults of policy into every API request.
+// It is only available in builds with the bravesearch_faults build tag;
+// this build fails with ErrFaultInjectionDisabled, so faults can never reach
+// production by accident.
+func WithFaultInjection(policy FaultPolicy) ClientOption {
+	return func(c *ClientConfig) error {
+		return ErrFaultInjectionDisabled
+	}
+}
+
+// withFaults returns client unchanged; fault injection is disabled in this build
+func withFaults(client *http.Client, policy *FaultPolicy) *http.Client {
+	return client
+}
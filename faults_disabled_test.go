@@ -0,0 +1,15 @@
+//go:build !bravesearch_faults
+
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithFaultInjectionDisabled tests that fault injection is unavailable in regular builds
+func TestWithFaultInjectionDisabled(t *testing.T) {
+	_, err := NewClient("test-api-key", WithFaultInjection(FaultPolicy{DropProbability: 1}))
+	assert.ErrorIs(t, err, ErrFaultInjectionDisabled)
+}
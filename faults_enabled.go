@@ -0,0 +1,136 @@
+//go:build bravesearch_faults
+
+package bravesearch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errInjectedFault is the network error of dropped requests
+var errInjectedFault = errors.New("injected fault: connection dropped")
+
+// WithFaultInjection injects the faults of policy into every API request.
+// It is only available in builds with the bravesearch_faults build tag;
+// other builds fail with ErrFaultInjectionDisabled, so faults can never
+// reach production by accident.
+func WithFaultInjection(policy FaultPolicy) ClientOption {
+	return func(c *ClientConfig) error {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		c.FaultPolicy = &policy
+		return nil
+	}
+}
+
+// withFaults returns a copy of client whose transport injects the faults of policy
+func withFaults(client *http.Client, policy *FaultPolicy) *http.Client {
+	seed := policy.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	faulty := *client
+	faulty.Transport = &faultTransport{
+		base:   base,
+		policy: *policy,
+		rand:   rand.New(rand.NewPCG(seed, seed)),
+	}
+	return &faulty
+}
+
+// faultTransport is an http.RoundTripper injecting faults
+type faultTransport struct {
+	base   http.RoundTripper
+	policy FaultPolicy
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	delay := time.Duration(0)
+	if t.hit(t.policy.DelayProbability) && t.policy.MaxDelay > 0 {
+		delay = time.Duration(t.rand.Int64N(int64(t.policy.MaxDelay) + 1))
+	}
+	drop := t.hit(t.policy.DropProbability)
+	fail := t.hit(t.policy.ErrorProbability)
+	corrupt := t.hit(t.policy.CorruptProbability)
+	t.mu.Unlock()
+
+	if delay > 0 {
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+
+	if drop {
+		return nil, errInjectedFault
+	}
+
+	if fail {
+		status := t.policy.ErrorStatus
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"Content-Type": {MIMETypeJSON}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"type": "ErrorResponse", "error": {"detail": "injected fault"}}`))),
+			Request:    req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !corrupt {
+		return resp, err
+	}
+
+	// Keep only the first half of the body
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body[:len(body)/2]))
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// hit reports whether a fault with the given probability happens; t.mu must be held
+func (t *faultTransport) hit(probability float64) bool {
+	return probability > 0 && t.rand.Float64() < probability
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
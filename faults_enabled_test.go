@@ -0,0 +1,83 @@
+//go:build bravesearch_faults
+
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// faultTestResponse answers every search successfully
+func faultTestResponse(r *http.Request) mockResponse {
+	return mockResponse{Body: `{"type": "search", "web": {"type": "search", "results": []}}`}
+}
+
+// TestFaultInjectionDrop tests dropped requests
+func TestFaultInjectionDrop(t *testing.T) {
+	server := newMockServer(t, faultTestResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(0),
+		WithFaultInjection(FaultPolicy{DropProbability: 1}))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	assert.ErrorIs(t, err, errInjectedFault)
+	assert.Zero(t, server.requestCount(""))
+}
+
+// TestFaultInjectionErrorStatus tests injected error responses
+func TestFaultInjectionErrorStatus(t *testing.T) {
+	server := newMockServer(t, faultTestResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(0),
+		WithFaultInjection(FaultPolicy{ErrorProbability: 1, ErrorStatus: http.StatusTooManyRequests}))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	assert.True(t, IsRateLimitError(err))
+	assert.Zero(t, server.requestCount(""))
+}
+
+// TestFaultInjectionCorrupt tests corrupted response bodies
+func TestFaultInjectionCorrupt(t *testing.T) {
+	server := newMockServer(t, faultTestResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL),
+		WithFaultInjection(FaultPolicy{CorruptProbability: 1}))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+	assert.Equal(t, 1, server.requestCount(""))
+}
+
+// TestFaultInjectionDelay tests delayed requests and that delays honor the context
+func TestFaultInjectionDelay(t *testing.T) {
+	server := newMockServer(t, faultTestResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL),
+		WithFaultInjection(FaultPolicy{DelayProbability: 1, MaxDelay: time.Hour, Seed: 1}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.WebSearch(ctx, "golang", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestFaultInjectionNoFaults tests that an empty policy passes requests through
+func TestFaultInjectionNoFaults(t *testing.T) {
+	server := newMockServer(t, faultTestResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithFaultInjection(FaultPolicy{}))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.requestCount(""))
+}
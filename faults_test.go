@@ -0,0 +1,23 @@
+package bravesearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFaultPolicyValidate tests fault policy validation
+func TestFaultPolicyValidate(t *testing.T) {
+	assert.NoError(t, (&FaultPolicy{}).Validate())
+	assert.NoError(t, (&FaultPolicy{DelayProbability: 1, MaxDelay: time.Second, ErrorProbability: 0.5, ErrorStatus: 429}).Validate())
+
+	for _, policy := range []FaultPolicy{
+		{DelayProbability: 1.5},
+		{DropProbability: -0.1},
+		{MaxDelay: -time.Second},
+		{ErrorStatus: 200},
+	} {
+		assert.ErrorIs(t, policy.Validate(), ErrInvalidParameters)
+	}
+}
@@ -0,0 +1,91 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Features is a set of SERP features, the sections that appeared in a response
+type Features uint
+
+// SERP features
+const (
+	FeatureWeb Features = 1 << iota
+	FeatureNews
+	FeatureVideos
+	FeatureInfobox
+	FeatureFAQ
+	FeatureDiscussions
+	FeatureLocations
+	FeatureSummarizer
+	FeatureRichResult
+)
+
+// featureNames are the names of the features, in bit order
+var featureNames = []string{
+	"web", "news", "videos", "infobox", "faq", "discussions", "locations", "summarizer", "rich",
+}
+
+// Features returns the sections that appeared in the response with content,
+// so SERP-feature presence can be logged without walking every section
+func (r *WebSearchResponse) Features() Features {
+	var features Features
+	if r == nil {
+		return features
+	}
+
+	if r.Web != nil && len(r.Web.Results) > 0 {
+		features |= FeatureWeb
+	}
+	if r.News != nil && len(r.News.Results) > 0 {
+		features |= FeatureNews
+	}
+	if r.Videos != nil && len(r.Videos.Results) > 0 {
+		features |= FeatureVideos
+	}
+	if r.Infobox != nil && (len(r.Infobox.Results) > 0 || r.Infobox.Data != nil) {
+		features |= FeatureInfobox
+	}
+	if r.FAQ != nil && len(r.FAQ.Results) > 0 {
+		features |= FeatureFAQ
+	}
+	if r.Discussions != nil && len(r.Discussions.Results) > 0 {
+		features |= FeatureDiscussions
+	}
+	if r.Locations != nil && len(r.Locations.Results) > 0 {
+		features |= FeatureLocations
+	}
+	if r.Summarizer != nil {
+		features |= FeatureSummarizer
+	}
+	if _, ok := r.InstantAnswer(); ok {
+		features |= FeatureRichResult
+	}
+	return features
+}
+
+// Has reports whether every feature of f is in the set
+func (fs Features) Has(f Features) bool {
+	return fs&f == f
+}
+
+// Names returns the names of the features in the set, e.g. "news"
+func (fs Features) Names() []string {
+	names := []string{}
+	for i, name := range featureNames {
+		if fs&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// String returns the names of the features, separated by commas
+func (fs Features) String() string {
+	return strings.Join(fs.Names(), ",")
+}
+
+// MarshalJSON encodes the features as a list of names
+func (fs Features) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fs.Names())
+}
@@ -0,0 +1,51 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFeatures tests summarizing which sections appeared
+func TestFeatures(t *testing.T) {
+	response := &WebSearchResponse{
+		Web:         &Search{Results: []SearchResult{{URL: "https://go.dev/"}}},
+		News:        &News{},
+		Videos:      &Videos{Results: []VideoResult{{URL: "https://video.example/"}}},
+		Infobox:     &GraphInfobox{Data: map[string]any{"title": "Go"}},
+		FAQ:         &FAQ{Results: []QA{{Question: "What is Go?"}}},
+		Discussions: &Discussions{Results: []any{map[string]any{}}},
+		Summarizer:  &Summarizer{Type: "summarizer"},
+		Rich:        &RichCallback{Hint: RichHint{Vertical: RichVerticalWeather, CallbackKey: "key"}},
+	}
+
+	features := response.Features()
+	assert.True(t, features.Has(FeatureWeb))
+	assert.False(t, features.Has(FeatureNews))
+	assert.True(t, features.Has(FeatureVideos|FeatureInfobox))
+	assert.False(t, features.Has(FeatureVideos|FeatureLocations))
+	assert.Equal(t, []string{"web", "videos", "infobox", "faq", "discussions", "summarizer", "rich"}, features.Names())
+	assert.Equal(t, "web,videos,infobox,faq,discussions,summarizer,rich", features.String())
+
+	data, err := json.Marshal(map[string]Features{"features": FeatureNews | FeatureLocations})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"features": ["news", "locations"]}`, string(data))
+
+	assert.Equal(t, Features(0), (*WebSearchResponse)(nil).Features())
+	assert.Equal(t, []string{}, Features(0).Names())
+	assert.Equal(t, "", Features(0).String())
+}
+
+// TestFeaturesFromResponse tests features of a decoded API response
+func TestFeaturesFromResponse(t *testing.T) {
+	data, err := os.ReadFile("testdata/web_search_response.json")
+	require.NoError(t, err)
+
+	var response WebSearchResponse
+	require.NoError(t, json.Unmarshal(data, &response))
+	assert.True(t, response.Features().Has(FeatureWeb))
+	assert.Equal(t, FeatureRichResult, Features(1)<<(len(featureNames)-1), "every feature needs a name")
+}
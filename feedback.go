@@ -0,0 +1,269 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// FeedbackSignal is a quality signal a user gave a search result
+type FeedbackSignal string
+
+const (
+	// FeedbackClick is recorded when the user opened the result
+	FeedbackClick FeedbackSignal = "click"
+
+	// FeedbackLike is recorded when the user marked the result as helpful
+	FeedbackLike FeedbackSignal = "like"
+
+	// FeedbackDismiss is recorded when the user hid or rejected the result
+	FeedbackDismiss FeedbackSignal = "dismiss"
+)
+
+// Feedback is a signal on a result of a search
+type Feedback struct {
+	// QueryID identifies the search in the application, e.g. a request ID
+	QueryID string
+
+	URL string
+
+	// Domain is the hostname of URL, lowercased and without "www."
+	Domain string
+
+	Signal FeedbackSignal
+	Time   time.Time
+}
+
+// FeedbackStats are aggregated signals of a domain or a query
+type FeedbackStats struct {
+	Clicks     int
+	Likes      int
+	Dismissals int
+}
+
+// Total returns the number of signals
+func (s FeedbackStats) Total() int {
+	return s.Clicks + s.Likes + s.Dismissals
+}
+
+// Score rates the signals from 0 (dismissed) to 1 (liked) for re-ranking.
+// A like counts twice as much as a click, a dismissal against both; the
+// score is smoothed towards 0.5, which it is without signals, so a few
+// signals do not swing it to an extreme.
+func (s FeedbackStats) Score() float64 {
+	positive := float64(s.Clicks + 2*s.Likes)
+	negative := float64(2 * s.Dismissals)
+	return (positive + 1) / (positive + negative + 2)
+}
+
+// add counts a signal
+func (s *FeedbackStats) add(signal FeedbackSignal) {
+	switch signal {
+	case FeedbackClick:
+		s.Clicks++
+	case FeedbackLike:
+		s.Likes++
+	case FeedbackDismiss:
+		s.Dismissals++
+	}
+}
+
+// FeedbackStore records result feedback and aggregates it per domain and per
+// query. Implementations must be safe for concurrent use.
+type FeedbackStore interface {
+	Record(ctx context.Context, feedback Feedback) error
+
+	// DomainStats and QueryStats return zero stats for domains and queries
+	// without feedback
+	DomainStats(ctx context.Context, domain string) (FeedbackStats, error)
+	QueryStats(ctx context.Context, queryID string) (FeedbackStats, error)
+}
+
+// DefaultFeedbackQueryTTL is how long a MemoryFeedbackStore keeps the stats
+// of a query by default
+const DefaultFeedbackQueryTTL = 24 * time.Hour
+
+// MemoryFeedbackStore is a FeedbackStore keeping the aggregates in memory.
+// It keeps one entry per domain seen. Query stats expire a query TTL after
+// the first signal on the query (DefaultFeedbackQueryTTL unless
+// WithFeedbackQueryTTL sets it), so per-request query IDs don't grow the
+// store without bound.
+//
+// It is also a SourceRater rating domains by their feedback score (label
+// "feedback"), so feedback can re-rank the results of later searches:
+//
+//	store := bravesearch.NewMemoryFeedbackStore()
+//	client, err := bravesearch.NewClient(apiKey,
+//	    bravesearch.WithFeedbackStore(store),
+//	    bravesearch.WithSourceRater(store),
+//	)
+type MemoryFeedbackStore struct {
+	mu       sync.Mutex
+	domains  map[string]*FeedbackStats
+	queries  map[string]*feedbackQuery
+	queryTTL time.Duration
+	now      func() time.Time
+
+	// expiries are the queries in order of their expiry
+	expiries []feedbackQueryExpiry
+}
+
+// feedbackQuery is the stats of a query and their expiry time
+type feedbackQuery struct {
+	stats     FeedbackStats
+	expiresAt time.Time
+}
+
+// feedbackQueryExpiry is the expiry time of a query
+type feedbackQueryExpiry struct {
+	queryID   string
+	expiresAt time.Time
+}
+
+// MemoryFeedbackStoreOption is a function that can be used to configure a
+// MemoryFeedbackStore
+type MemoryFeedbackStoreOption func(*MemoryFeedbackStore)
+
+// WithFeedbackQueryTTL sets how long query stats are kept after the first
+// signal on the query. A ttl that is not positive is ignored.
+func WithFeedbackQueryTTL(ttl time.Duration) MemoryFeedbackStoreOption {
+	return func(s *MemoryFeedbackStore) {
+		if ttl > 0 {
+			s.queryTTL = ttl
+		}
+	}
+}
+
+// NewMemoryFeedbackStore creates an in-process FeedbackStore
+func NewMemoryFeedbackStore(options ...MemoryFeedbackStoreOption) *MemoryFeedbackStore {
+	store := &MemoryFeedbackStore{
+		domains:  make(map[string]*FeedbackStats),
+		queries:  make(map[string]*feedbackQuery),
+		queryTTL: DefaultFeedbackQueryTTL,
+		now:      time.Now,
+	}
+	for _, option := range options {
+		option(store)
+	}
+	return store
+}
+
+// Record implements FeedbackStore
+func (s *MemoryFeedbackStore) Record(ctx context.Context, feedback Feedback) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expireQueries(now)
+
+	stats, ok := s.domains[feedback.Domain]
+	if !ok {
+		stats = &FeedbackStats{}
+		s.domains[feedback.Domain] = stats
+	}
+	stats.add(feedback.Signal)
+
+	query, ok := s.queries[feedback.QueryID]
+	if !ok {
+		query = &feedbackQuery{expiresAt: now.Add(s.queryTTL)}
+		s.queries[feedback.QueryID] = query
+		s.expiries = append(s.expiries, feedbackQueryExpiry{queryID: feedback.QueryID, expiresAt: query.expiresAt})
+	}
+	query.stats.add(feedback.Signal)
+	return nil
+}
+
+// expireQueries removes the stats of the queries expired at now. Queries
+// expire in the order they were added, so only the oldest are checked.
+func (s *MemoryFeedbackStore) expireQueries(now time.Time) {
+	expired := 0
+	for expired < len(s.expiries) && !now.Before(s.expiries[expired].expiresAt) {
+		delete(s.queries, s.expiries[expired].queryID)
+		expired++
+	}
+	s.expiries = s.expiries[expired:]
+}
+
+// DomainStats implements FeedbackStore
+func (s *MemoryFeedbackStore) DomainStats(ctx context.Context, domain string) (FeedbackStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stats, ok := s.domains[domain]; ok {
+		return *stats, nil
+	}
+	return FeedbackStats{}, nil
+}
+
+// QueryStats implements FeedbackStore
+func (s *MemoryFeedbackStore) QueryStats(ctx context.Context, queryID string) (FeedbackStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if query, ok := s.queries[queryID]; ok && s.now().Before(query.expiresAt) {
+		return query.stats, nil
+	}
+	return FeedbackStats{}, nil
+}
+
+// RateSource implements SourceRater. Domains without feedback are not rated.
+func (s *MemoryFeedbackStore) RateSource(hostname string) *SourceScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.domains[feedbackDomain(hostname)]
+	if !ok {
+		return nil
+	}
+	return &SourceScore{Score: stats.Score(), Label: "feedback"}
+}
+
+// RecordFeedback records a signal the user gave the result at resultURL of
+// the search identified by queryID in the FeedbackStore (see
+// WithFeedbackStore; by default an in-memory one)
+func (c *Client) RecordFeedback(ctx context.Context, queryID, resultURL string, signal FeedbackSignal) error {
+	switch signal {
+	case FeedbackClick, FeedbackLike, FeedbackDismiss:
+	default:
+		return fmt.Errorf("%w: unknown feedback signal %q", ErrInvalidParameters, signal)
+	}
+	if queryID == "" {
+		return fmt.Errorf("%w: feedback needs a query ID", ErrInvalidParameters)
+	}
+	u, err := url.Parse(resultURL)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("%w: invalid result URL %q", ErrInvalidParameters, resultURL)
+	}
+
+	feedback := Feedback{
+		QueryID: queryID,
+		URL:     resultURL,
+		Domain:  feedbackDomain(u.Hostname()),
+		Signal:  signal,
+		Time:    time.Now(),
+	}
+	if err := c.config.FeedbackStore.Record(ctx, feedback); err != nil {
+		return fmt.Errorf("failed to record feedback: %w", err)
+	}
+	return nil
+}
+
+// DomainFeedback returns the aggregated feedback on results from domain
+func (c *Client) DomainFeedback(ctx context.Context, domain string) (FeedbackStats, error) {
+	return c.config.FeedbackStore.DomainStats(ctx, feedbackDomain(domain))
+}
+
+// QueryFeedback returns the aggregated feedback on the results of the search
+// identified by queryID
+func (c *Client) QueryFeedback(ctx context.Context, queryID string) (FeedbackStats, error) {
+	return c.config.FeedbackStore.QueryStats(ctx, queryID)
+}
+
+// feedbackDomain normalizes a hostname to the domain feedback is aggregated by
+func feedbackDomain(hostname string) string {
+	return hostutil.WithoutWWW(hostname)
+}
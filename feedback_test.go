@@ -0,0 +1,144 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecordFeedback tests aggregating feedback per domain and per query
+func TestRecordFeedback(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, client.RecordFeedback(ctx, "q1", "https://www.go.dev/doc", FeedbackClick))
+	require.NoError(t, client.RecordFeedback(ctx, "q1", "https://go.dev/blog", FeedbackLike))
+	require.NoError(t, client.RecordFeedback(ctx, "q1", "https://spam.example/go", FeedbackDismiss))
+	require.NoError(t, client.RecordFeedback(ctx, "q2", "https://GO.dev/", FeedbackClick))
+
+	stats, err := client.DomainFeedback(ctx, "www.go.dev")
+	require.NoError(t, err)
+	assert.Equal(t, FeedbackStats{Clicks: 2, Likes: 1}, stats)
+	assert.Equal(t, 3, stats.Total())
+
+	stats, err = client.QueryFeedback(ctx, "q1")
+	require.NoError(t, err)
+	assert.Equal(t, FeedbackStats{Clicks: 1, Likes: 1, Dismissals: 1}, stats)
+
+	stats, err = client.QueryFeedback(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Zero(t, stats)
+}
+
+// TestRecordFeedbackInvalid tests rejecting invalid feedback
+func TestRecordFeedbackInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, client.RecordFeedback(ctx, "q1", "https://go.dev/", "share"), ErrInvalidParameters)
+	assert.ErrorIs(t, client.RecordFeedback(ctx, "", "https://go.dev/", FeedbackClick), ErrInvalidParameters)
+	assert.ErrorIs(t, client.RecordFeedback(ctx, "q1", "not a url", FeedbackClick), ErrInvalidParameters)
+}
+
+// TestFeedbackStore tests recording feedback in a custom store
+func TestFeedbackStore(t *testing.T) {
+	var recorded []Feedback
+	store := &recordingFeedbackStore{MemoryFeedbackStore: NewMemoryFeedbackStore(), recorded: &recorded}
+	client, err := NewClient("test-api-key", WithFeedbackStore(store))
+	require.NoError(t, err)
+
+	require.NoError(t, client.RecordFeedback(context.Background(), "q1", "https://go.dev/", FeedbackLike))
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "q1", recorded[0].QueryID)
+	assert.Equal(t, "go.dev", recorded[0].Domain)
+	assert.Equal(t, FeedbackLike, recorded[0].Signal)
+	assert.False(t, recorded[0].Time.IsZero())
+
+	store.err = errors.New("store unavailable")
+	err = client.RecordFeedback(context.Background(), "q1", "https://go.dev/", FeedbackLike)
+	assert.ErrorContains(t, err, "store unavailable")
+
+	_, err = NewClient("test-api-key", WithFeedbackStore(nil))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// recordingFeedbackStore records feedback before passing it on, or fails with err
+type recordingFeedbackStore struct {
+	*MemoryFeedbackStore
+	recorded *[]Feedback
+	err      error
+}
+
+// Record implements FeedbackStore
+func (s *recordingFeedbackStore) Record(ctx context.Context, feedback Feedback) error {
+	if s.err != nil {
+		return s.err
+	}
+	*s.recorded = append(*s.recorded, feedback)
+	return s.MemoryFeedbackStore.Record(ctx, feedback)
+}
+
+// TestFeedbackStatsScore tests scoring aggregated feedback
+func TestFeedbackStatsScore(t *testing.T) {
+	assert.Equal(t, 0.5, FeedbackStats{}.Score())
+	assert.Equal(t, 0.5, FeedbackStats{Likes: 1, Dismissals: 1}.Score())
+	assert.InDelta(t, 0.75, FeedbackStats{Clicks: 2}.Score(), 0.001)
+	assert.InDelta(t, 0.25, FeedbackStats{Dismissals: 1}.Score(), 0.001)
+	assert.Greater(t, FeedbackStats{Likes: 10}.Score(), FeedbackStats{Clicks: 10}.Score())
+}
+
+// TestMemoryFeedbackStoreRateSource tests re-ranking sources by feedback
+func TestMemoryFeedbackStoreRateSource(t *testing.T) {
+	store := NewMemoryFeedbackStore()
+	client, err := NewClient("test-api-key", WithFeedbackStore(store), WithSourceRater(store))
+	require.NoError(t, err)
+
+	require.NoError(t, client.RecordFeedback(context.Background(), "q1", "https://go.dev/", FeedbackLike))
+	require.NoError(t, client.RecordFeedback(context.Background(), "q1", "https://spam.example/", FeedbackDismiss))
+
+	liked := store.RateSource("www.go.dev")
+	require.NotNil(t, liked)
+	assert.Equal(t, "feedback", liked.Label)
+	assert.Greater(t, liked.Score, store.RateSource("spam.example").Score)
+	assert.Nil(t, store.RateSource("unknown.example"))
+}
+
+// TestMemoryFeedbackStoreQueryTTL tests expiring the stats of queries
+func TestMemoryFeedbackStoreQueryTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryFeedbackStore(WithFeedbackQueryTTL(time.Hour))
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, store.Record(ctx, Feedback{QueryID: "q1", Domain: "go.dev", Signal: FeedbackClick}))
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, store.Record(ctx, Feedback{QueryID: "q2", Domain: "go.dev", Signal: FeedbackLike}))
+
+	stats, err := store.QueryStats(ctx, "q1")
+	require.NoError(t, err)
+	assert.Equal(t, FeedbackStats{Clicks: 1}, stats)
+
+	// q1 expires an hour after its first signal, q2 and the domain stats stay
+	now = now.Add(30 * time.Minute)
+	stats, err = store.QueryStats(ctx, "q1")
+	require.NoError(t, err)
+	assert.Zero(t, stats)
+
+	require.NoError(t, store.Record(ctx, Feedback{QueryID: "q2", Domain: "go.dev", Signal: FeedbackClick}))
+	assert.NotContains(t, store.queries, "q1")
+	assert.Len(t, store.expiries, 1)
+
+	stats, err = store.QueryStats(ctx, "q2")
+	require.NoError(t, err)
+	assert.Equal(t, FeedbackStats{Clicks: 1, Likes: 1}, stats)
+
+	stats, err = store.DomainStats(ctx, "go.dev")
+	require.NoError(t, err)
+	assert.Equal(t, FeedbackStats{Clicks: 2, Likes: 1}, stats)
+}
@@ -0,0 +1,70 @@
+package bravesearch
+
+import (
+	"strings"
+	"time"
+)
+
+// freshnessDateLayout is the date format of freshness ranges
+const freshnessDateLayout = time.DateOnly
+
+// FreshnessRange returns the freshness value for results discovered between
+// from and to, inclusive ("2024-01-01to2024-01-31"). The API reads the dates
+// of a range as UTC days, so the times are converted to UTC first: the range
+// covers the UTC days from and to fall on. The dates are swapped if from is
+// after to.
+func FreshnessRange(from, to time.Time) string {
+	fromDate, toDate := from.UTC().Format(freshnessDateLayout), to.UTC().Format(freshnessDateLayout)
+	if fromDate > toDate {
+		fromDate, toDate = toDate, fromDate
+	}
+	return fromDate + "to" + toDate
+}
+
+// ParseFreshnessRange parses a freshness range as returned by
+// FreshnessRange, returning its dates in UTC
+func ParseFreshnessRange(freshness string) (from, to time.Time, ok bool) {
+	fromDate, toDate, found := strings.Cut(freshness, "to")
+	if !found {
+		return time.Time{}, time.Time{}, false
+	}
+	from, err := time.Parse(freshnessDateLayout, fromDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	to, err = time.Parse(freshnessDateLayout, toDate)
+	if err != nil || to.Before(from) {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// FreshnessToday returns the freshness range for the current calendar day in
+// loc (UTC if nil), such as "today in Asia/Tokyo". Unlike FreshnessDay, which
+// covers the past 24 hours as seen by the API, it follows the caller's day:
+// the range covers the UTC days from the start of the day in loc until now.
+func FreshnessToday(loc *time.Location) string {
+	return freshnessPastDays(time.Now(), loc, 1)
+}
+
+// FreshnessPastDays returns the freshness range for the last days calendar
+// days in loc (UTC if nil), including today. Use it instead of FreshnessWeek
+// (7) and friends to avoid off-by-one-day windows for users far from UTC.
+// As the API only takes UTC dates, the range covers the UTC days from the
+// start of the first day in loc until now.
+func FreshnessPastDays(loc *time.Location, days int) string {
+	return freshnessPastDays(time.Now(), loc, days)
+}
+
+// freshnessPastDays implements FreshnessPastDays relative to now
+func freshnessPastDays(now time.Time, loc *time.Location, days int) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if days < 1 {
+		days = 1
+	}
+	now = now.In(loc)
+	start := time.Date(now.Year(), now.Month(), now.Day()-(days-1), 0, 0, 0, 0, loc)
+	return FreshnessRange(start, now)
+}
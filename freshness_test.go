@@ -0,0 +1,57 @@
+package bravesearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFreshnessRange tests formatting date range freshness values
+func TestFreshnessRange(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024-01-01to2024-01-31", FreshnessRange(from, to))
+	assert.Equal(t, "2024-01-01to2024-01-31", FreshnessRange(to, from))
+
+	// Dates are taken in UTC, the API's time base
+	tokyo := time.FixedZone("JST", 9*60*60)
+	assert.Equal(t, "2024-01-31to2024-01-31", FreshnessRange(to.In(tokyo), to.In(tokyo)))
+}
+
+// TestParseFreshnessRange tests parsing date range freshness values
+func TestParseFreshnessRange(t *testing.T) {
+	from, to, ok := ParseFreshnessRange("2024-01-01to2024-01-31")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), to)
+
+	for _, freshness := range []string{FreshnessWeek, "2024-01-31to2024-01-01", "2024-01-01to", "yesterdaytotoday"} {
+		_, _, ok := ParseFreshnessRange(freshness)
+		assert.False(t, ok, freshness)
+	}
+}
+
+// TestFreshnessPastDays tests calendar day windows in the caller's time zone
+func TestFreshnessPastDays(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	losAngeles := time.FixedZone("PST", -8*60*60)
+
+	// 2024-03-02 08:00 in Tokyo: the day started at 2024-03-01 15:00 UTC and
+	// the UTC day of March 2nd has not begun yet
+	now := time.Date(2024, 3, 2, 8, 0, 0, 0, tokyo)
+	assert.Equal(t, "2024-03-01to2024-03-01", freshnessPastDays(now, tokyo, 1))
+	assert.Equal(t, "2024-02-24to2024-03-01", freshnessPastDays(now, tokyo, 7))
+
+	// 2024-03-01 20:00 in Los Angeles is already March 2nd in UTC, and the
+	// day started at 2024-03-01 08:00 UTC
+	now = time.Date(2024, 3, 1, 20, 0, 0, 0, losAngeles)
+	assert.Equal(t, "2024-03-01to2024-03-02", freshnessPastDays(now, losAngeles, 1))
+	assert.Equal(t, "2024-03-01to2024-03-02", freshnessPastDays(now, losAngeles, 0))
+	assert.Equal(t, "2024-02-24to2024-03-02", freshnessPastDays(now, losAngeles, 7))
+	assert.Equal(t, "2024-03-02to2024-03-02", freshnessPastDays(now, nil, 1))
+
+	_, _, ok := ParseFreshnessRange(FreshnessToday(tokyo))
+	assert.True(t, ok)
+}
@@ -2,7 +2,10 @@ module github.com/cnosuke/go-brave-search
 
 go 1.24.0
 
-require github.com/stretchr/testify v1.8.4
+require (
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.30.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,66 @@
+package bravesearch
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HeaderPolicy adjusts the headers of outgoing requests for API gateways
+// with specific header requirements. Header names in a HeaderPolicy are sent
+// with exactly the given casing rather than in canonical form.
+type HeaderPolicy struct {
+	// TokenHeader is the name of the header carrying the subscription token,
+	// X-Subscription-Token by default. It applies to the default
+	// Authenticator only.
+	TokenHeader string
+
+	// DisableCacheControl omits the "Cache-Control: no-cache" header
+	DisableCacheControl bool
+
+	// Headers are added to every request, replacing standard headers of the
+	// same name
+	Headers http.Header
+}
+
+// Validate checks that the header names and values can be sent
+func (p HeaderPolicy) Validate() error {
+	if p.TokenHeader != "" && !isUserAgentToken(p.TokenHeader) {
+		return fmt.Errorf("%w: invalid token header name %q", ErrInvalidParameters, p.TokenHeader)
+	}
+	for name, values := range p.Headers {
+		if !isUserAgentToken(name) {
+			return fmt.Errorf("%w: invalid header name %q", ErrInvalidParameters, name)
+		}
+		if strings.EqualFold(name, HeaderCookie) {
+			return fmt.Errorf("%w: cookies are never sent (see WithCookieJar)", ErrInvalidParameters)
+		}
+		for _, value := range values {
+			if strings.ContainsAny(value, "\r\n\x00") {
+				return fmt.Errorf("%w: invalid value for header %q", ErrInvalidParameters, name)
+			}
+		}
+	}
+	return nil
+}
+
+// apply sets the policy headers on header
+func (p HeaderPolicy) apply(header http.Header) {
+	if p.DisableCacheControl {
+		header.Del(HeaderCacheControl)
+	}
+	for name, values := range p.Headers {
+		setExactHeader(header, name, values...)
+	}
+}
+
+// setExactHeader sets a header keeping the casing of name, replacing any
+// header of the same name in another casing
+func setExactHeader(header http.Header, name string, values ...string) {
+	for key := range header {
+		if strings.EqualFold(key, name) {
+			delete(header, key)
+		}
+	}
+	header[name] = append([]string(nil), values...)
+}
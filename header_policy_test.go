@@ -0,0 +1,108 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// headerRecorder captures the headers of outgoing requests as sent, keeping their casing
+type headerRecorder struct {
+	header http.Header
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.header = req.Header.Clone()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {MIMETypeJSON}},
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
+// TestHeaderPolicy tests gateway header adjustments
+func TestHeaderPolicy(t *testing.T) {
+	recorder := &headerRecorder{}
+	client, err := NewClient("test-api-key",
+		WithHTTPClient(&http.Client{Transport: recorder}),
+		WithHeaderPolicy(HeaderPolicy{
+			TokenHeader:         "x-brave-token",
+			DisableCacheControl: true,
+			Headers: http.Header{
+				"X-Gateway-Tenant": {"acme"},
+				"user-agent":       {"gateway-client/1.0"},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, client.makeRequest(context.Background(), http.MethodGet, "https://api.example.com/res/v1/web/search?q=go", nil, nil, nil))
+
+	assert.Equal(t, []string{"test-api-key"}, recorder.header["x-brave-token"])
+	assert.Empty(t, recorder.header.Get(HeaderSubscriptionToken))
+	assert.Empty(t, recorder.header.Get(HeaderCacheControl))
+	assert.Equal(t, "acme", recorder.header.Get("X-Gateway-Tenant"))
+	assert.Equal(t, []string{"gateway-client/1.0"}, recorder.header["user-agent"])
+	assert.NotContains(t, recorder.header, HeaderUserAgent)
+	assert.Equal(t, MIMETypeJSON, recorder.header.Get(HeaderAccept))
+}
+
+// TestHeaderPolicyDefaults tests that requests keep the standard headers without a policy
+func TestHeaderPolicyDefaults(t *testing.T) {
+	recorder := &headerRecorder{}
+	client, err := NewClient("test-api-key", WithHTTPClient(&http.Client{Transport: recorder}))
+	require.NoError(t, err)
+
+	require.NoError(t, client.makeRequest(context.Background(), http.MethodGet, "https://api.example.com/res/v1/web/search?q=go", nil, nil, nil))
+	assert.Equal(t, "test-api-key", recorder.header.Get(HeaderSubscriptionToken))
+	assert.Equal(t, "no-cache", recorder.header.Get(HeaderCacheControl))
+}
+
+// TestHeaderPolicyValidate tests rejecting headers that cannot be sent
+func TestHeaderPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy HeaderPolicy
+		valid  bool
+	}{
+		{"empty", HeaderPolicy{}, true},
+		{"token header", HeaderPolicy{TokenHeader: "X-Api-Key"}, true},
+		{"token header with space", HeaderPolicy{TokenHeader: "X Api Key"}, false},
+		{"header name with colon", HeaderPolicy{Headers: http.Header{"X-Tenant:": {"acme"}}}, false},
+		{"header value with newline", HeaderPolicy{Headers: http.Header{"X-Tenant": {"acme\r\nX-Evil: 1"}}}, false},
+		{"cookie header", HeaderPolicy{Headers: http.Header{"cookie": {"session=1"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidParameters)
+			}
+
+			_, err = NewClient("test-api-key", WithHeaderPolicy(tt.policy))
+			assert.Equal(t, tt.valid, err == nil)
+		})
+	}
+}
+
+// TestSubscriptionTokenAuthenticatorHeader tests overriding the token header name
+func TestSubscriptionTokenAuthenticatorHeader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com/", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Api-Key", "stale")
+
+	require.NoError(t, SubscriptionTokenAuthenticator{Token: "secret", Header: "x-api-key"}.Authenticate(req))
+	assert.Equal(t, []string{"secret"}, req.Header["x-api-key"])
+	for key := range req.Header {
+		assert.False(t, strings.EqualFold(key, "X-Api-Key") && key != "x-api-key")
+	}
+}
@@ -0,0 +1,207 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ImageSearchParams holds the parameters for an image search request
+type ImageSearchParams struct {
+	// Country is the country to search images from
+	Country string `url:"country,omitempty"`
+
+	// SearchLang is the language to search images in
+	SearchLang string `url:"search_lang,omitempty"`
+
+	// Count is the number of images, at most MaxImageCount
+	Count int `url:"count,omitempty"`
+
+	// SafeSearch is SafeSearchOff or SafeSearchStrict; image search has no
+	// moderate level. The API default is strict.
+	SafeSearch string `url:"safesearch,omitempty"`
+
+	// Spellcheck nil leaves the API default; see Bool
+	Spellcheck *bool `url:"spellcheck,omitempty"`
+
+	// Size limits images to a size class, one of the ImageSize constants
+	Size string `url:"size,omitempty"`
+
+	// Color limits images to ImageColorColor, ImageColorMonochrome or a
+	// dominant color such as "red"
+	Color string `url:"color,omitempty"`
+
+	// License limits images to a usage right, one of the ImageLicense
+	// constants
+	License string `url:"license,omitempty"`
+
+	// Aspect limits images to an aspect ratio, one of the ImageAspect constants
+	Aspect string `url:"aspect,omitempty"`
+
+	// Extra holds query parameters the library does not model yet. Keys
+	// managed by the library are rejected with ErrInvalidParameters.
+	Extra url.Values `url:"-"`
+}
+
+// Image size filters
+const (
+	ImageSizeSmall     = "small"
+	ImageSizeMedium    = "medium"
+	ImageSizeLarge     = "large"
+	ImageSizeWallpaper = "wallpaper"
+)
+
+// Image color filters
+const (
+	ImageColorColor      = "color"
+	ImageColorMonochrome = "monochrome"
+)
+
+// Image license filters, from the least to the most permissive
+const (
+	ImageLicensePublic             = "public"             // Public domain
+	ImageLicenseShare              = "share"              // Free to share
+	ImageLicenseShareCommercially  = "sharecommercially"  // Free to share commercially
+	ImageLicenseModify             = "modify"             // Free to modify and share
+	ImageLicenseModifyCommercially = "modifycommercially" // Free to modify and share commercially
+)
+
+// Image aspect ratio filters
+const (
+	ImageAspectSquare = "square"
+	ImageAspectWide   = "wide"
+	ImageAspectTall   = "tall"
+)
+
+// ImageSearchResponse represents the response from the Image Search API
+type ImageSearchResponse struct {
+	Type    string        `json:"type"`
+	Query   *Query        `json:"query,omitempty"`
+	Results []ImageResult `json:"results"`
+	Extra   *ImageExtra   `json:"extra,omitempty"`
+}
+
+// ImageResult represents a single image search result
+type ImageResult struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+
+	// URL is the page the image appears on
+	URL string `json:"url"`
+
+	// Source is the hostname of the page
+	Source      string           `json:"source"`
+	PageFetched string           `json:"page_fetched,omitempty"`
+	Thumbnail   *ImageThumbnail  `json:"thumbnail,omitempty"`
+	Properties  *ImageProperties `json:"properties,omitempty"`
+	MetaURL     *MetaURL         `json:"meta_url,omitempty"`
+	Confidence  string           `json:"confidence,omitempty"`
+}
+
+// ImageThumbnail is the thumbnail of an image result, served by Brave
+type ImageThumbnail struct {
+	Src    string `json:"src"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// ImageProperties describes the original image
+type ImageProperties struct {
+	// URL is the original image
+	URL string `json:"url"`
+
+	// Placeholder is a low-resolution placeholder of the image
+	Placeholder string `json:"placeholder,omitempty"`
+
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// imageFormats maps image file extensions to formats
+var imageFormats = map[string]string{
+	".jpg": "jpeg", ".jpeg": "jpeg", ".png": "png", ".gif": "gif", ".webp": "webp",
+	".svg": "svg", ".bmp": "bmp", ".avif": "avif", ".tif": "tiff", ".tiff": "tiff",
+}
+
+// Format returns the file format of the original image, such as "jpeg" or
+// "png", as given by the extension of its URL, or "" if it is unknown
+func (p *ImageProperties) Format() string {
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return ""
+	}
+	return imageFormats[strings.ToLower(path.Ext(u.Path))]
+}
+
+// ImageExtra holds additional information about image search results
+type ImageExtra struct {
+	MightBeOffensive bool `json:"might_be_offensive"`
+}
+
+// ImageURL returns the URL of the original image, falling back to the
+// thumbnail if the original is unknown
+func (r *ImageResult) ImageURL() string {
+	if r.Properties != nil && r.Properties.URL != "" {
+		return r.Properties.URL
+	}
+	if r.Thumbnail != nil {
+		return r.Thumbnail.Src
+	}
+	return ""
+}
+
+// ImageSearch searches images for query. The Image Search API requires a
+// subscription that includes it.
+func (c *Client) ImageSearch(ctx context.Context, query string, params *ImageSearchParams) (*ImageSearchResponse, error) {
+	imageParams := &ImageSearchParams{}
+	if params != nil {
+		*imageParams = *params
+	}
+
+	var response ImageSearchResponse
+	_, err := c.verticalSearch(ctx, query, verticalRequest{
+		endpoint:     EndpointImageSearch,
+		params:       imageParams,
+		extra:        imageParams.Extra,
+		country:      &imageParams.Country,
+		searchLang:   &imageParams.SearchLang,
+		count:        &imageParams.Count,
+		defaultCount: DefaultImageCount,
+		maxCount:     MaxImageCount,
+		validate:     func() error { return validateImageFilters(imageParams) },
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// validateImageFilters checks the SafeSearch, size, license and aspect
+// filters of params
+func validateImageFilters(params *ImageSearchParams) error {
+	switch params.SafeSearch {
+	case "", SafeSearchOff, SafeSearchStrict:
+	default:
+		return fmt.Errorf("%w: image search safesearch must be %q or %q", ErrInvalidParameters, SafeSearchOff, SafeSearchStrict)
+	}
+
+	filters := []struct {
+		name   string
+		value  string
+		values []string
+	}{
+		{"size", params.Size, []string{ImageSizeSmall, ImageSizeMedium, ImageSizeLarge, ImageSizeWallpaper}},
+		{"license", params.License, []string{ImageLicensePublic, ImageLicenseShare, ImageLicenseShareCommercially, ImageLicenseModify, ImageLicenseModifyCommercially}},
+		{"aspect", params.Aspect, []string{ImageAspectSquare, ImageAspectWide, ImageAspectTall}},
+	}
+	for _, filter := range filters {
+		if filter.value != "" && !slices.Contains(filter.values, filter.value) {
+			return fmt.Errorf("%w: image %s must be one of %s", ErrInvalidParameters, filter.name, strings.Join(filter.values, ", "))
+		}
+	}
+	return nil
+}
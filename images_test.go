@@ -0,0 +1,119 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestImageSearch tests the Image Search API
+func TestImageSearch(t *testing.T) {
+	server, query := setupVerticalSearchServer(t, EndpointImageSearch, "image_search_response.json")
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	response, err := client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{
+		Country:    "uk",
+		Count:      100,
+		SafeSearch: SafeSearchOff,
+		Spellcheck: Bool(false),
+		Size:       ImageSizeLarge,
+		Color:      ImageColorMonochrome,
+		License:    ImageLicenseModifyCommercially,
+		Aspect:     ImageAspectWide,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "images", response.Type)
+	require.NotNil(t, response.Query)
+	assert.Equal(t, "gopher", response.Query.Original)
+	require.NotNil(t, response.Extra)
+	assert.False(t, response.Extra.MightBeOffensive)
+	require.Len(t, response.Results, 2)
+
+	image := response.Results[0]
+	assert.Equal(t, "The Go Gopher - The Go Programming Language", image.Title)
+	assert.Equal(t, "https://go.dev/blog/gopher", image.URL)
+	assert.Equal(t, "go.dev", image.Source)
+	assert.Equal(t, &ImageThumbnail{Src: "https://imgs.search.brave.com/gopher-thumb.png", Width: 500, Height: 375}, image.Thumbnail)
+	require.NotNil(t, image.Properties)
+	assert.Equal(t, 1200, image.Properties.Width)
+	assert.Equal(t, 900, image.Properties.Height)
+	assert.Equal(t, "https://go.dev/blog/gopher/header.jpg", image.ImageURL())
+	assert.Equal(t, "jpeg", image.Properties.Format())
+	assert.Equal(t, "go.dev", image.MetaURL.Hostname)
+
+	// Without properties the thumbnail is the best image available
+	assert.Equal(t, "https://imgs.search.brave.com/plush-thumb.png", response.Results[1].ImageURL())
+
+	assert.Equal(t, "gopher", query.Get("q"))
+	assert.Equal(t, "GB", query.Get("country"))
+	assert.Equal(t, DefaultSearchLang, query.Get("search_lang"))
+	assert.Equal(t, "100", query.Get("count"))
+	assert.Equal(t, SafeSearchOff, query.Get("safesearch"))
+	assert.Equal(t, "false", query.Get("spellcheck"))
+	assert.Equal(t, ImageSizeLarge, query.Get("size"))
+	assert.Equal(t, ImageColorMonochrome, query.Get("color"))
+	assert.Equal(t, ImageLicenseModifyCommercially, query.Get("license"))
+	assert.Equal(t, ImageAspectWide, query.Get("aspect"))
+}
+
+// TestImagePropertiesFormat tests the format of original images
+func TestImagePropertiesFormat(t *testing.T) {
+	assert.Equal(t, "png", (&ImageProperties{URL: "https://example.com/a/b.PNG?w=100"}).Format())
+	assert.Equal(t, "webp", (&ImageProperties{URL: "https://example.com/b.webp"}).Format())
+	assert.Equal(t, "", (&ImageProperties{URL: "https://example.com/image"}).Format())
+	assert.Equal(t, "", (&ImageProperties{}).Format())
+}
+
+// TestImageSearchDefaults tests the default Image Search parameters
+func TestImageSearchDefaults(t *testing.T) {
+	server, query := setupVerticalSearchServer(t, EndpointImageSearch, "image_search_response.json")
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCountry, query.Get("country"))
+	assert.Equal(t, "50", query.Get("count"))
+	assert.False(t, query.Has("safesearch"))
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"), WithNoDefaults(true))
+	require.NoError(t, err)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", nil)
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"q": {"gopher"}}, *query)
+}
+
+// TestImageSearchInvalid tests rejecting invalid image search parameters
+func TestImageSearchInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.ImageSearch(context.Background(), "", nil)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{Count: MaxImageCount + 1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{SafeSearch: SafeSearchModerate})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{Size: "huge"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{License: "any"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{Aspect: "round"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.ImageSearch(context.Background(), "gopher", &ImageSearchParams{Extra: url.Values{"count": {"5"}}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
@@ -0,0 +1,85 @@
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Instant answer verticals
+const (
+	RichVerticalCalculator     = "calculator"
+	RichVerticalDefinitions    = "definitions"
+	RichVerticalUnitConversion = "unit_conversion"
+	RichVerticalUnixTimestamp  = "unix_timestamp"
+	RichVerticalCurrency       = "currency"
+	RichVerticalCryptocurrency = "cryptocurrency"
+	RichVerticalStock          = "stock"
+	RichVerticalWeather        = "weather"
+	RichVerticalPackageTracker = "package_tracker"
+)
+
+// RichCallback announces an instant answer (a unit conversion, calculation,
+// weather forecast, ...) for the query. It is only returned for searches
+// with EnableRichCallback set, and the answer itself is fetched with
+// Client.InstantAnswer.
+type RichCallback struct {
+	Type string   `json:"type"`
+	Hint RichHint `json:"hint"`
+}
+
+// RichHint identifies an instant answer
+type RichHint struct {
+	// Vertical is the kind of answer, one of the RichVertical* constants
+	Vertical string `json:"vertical"`
+
+	// CallbackKey fetches the answer from the rich endpoint
+	CallbackKey string `json:"callback_key"`
+}
+
+// InstantAnswer is an instant answer block
+type InstantAnswer struct {
+	// Vertical is the kind of answer, one of the RichVertical* constants
+	Vertical string
+
+	// Data is the answer as returned by the API. Its layout depends on the
+	// vertical; decode it with Decode.
+	Data json.RawMessage
+}
+
+// Decode unmarshals the answer data into v
+func (a *InstantAnswer) Decode(v any) error {
+	return json.Unmarshal(a.Data, v)
+}
+
+// InstantAnswer returns the hint of the instant answer available for the
+// query, if any
+func (r *WebSearchResponse) InstantAnswer() (*RichHint, bool) {
+	if r.Rich == nil || r.Rich.Hint.CallbackKey == "" {
+		return nil, false
+	}
+	return &r.Rich.Hint, true
+}
+
+// InstantAnswer fetches the instant answer announced in a web search
+// response. It returns false if the response announces none; run searches
+// with EnableRichCallback set to receive announcements.
+func (c *Client) InstantAnswer(ctx context.Context, response *WebSearchResponse) (*InstantAnswer, bool, error) {
+	hint, ok := response.InstantAnswer()
+	if !ok {
+		return nil, false, nil
+	}
+
+	values := url.Values{}
+	values.Set("callback_key", hint.CallbackKey)
+	requestURL := c.endpointURL(EndpointRich) + "?" + values.Encode()
+
+	var data json.RawMessage
+	if err := c.makeRequest(ctx, http.MethodGet, requestURL, nil, nil, &data); err != nil {
+		return nil, false, fmt.Errorf("failed to fetch %s instant answer: %w", hint.Vertical, err)
+	}
+
+	return &InstantAnswer{Vertical: hint.Vertical, Data: data}, true, nil
+}
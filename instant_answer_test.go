@@ -0,0 +1,84 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInstantAnswer tests detecting and fetching instant answers
+func TestInstantAnswer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case WebSearchEndpoint:
+			assert.Equal(t, "1", r.URL.Query().Get("enable_rich_callback"))
+			_, _ = w.Write([]byte(`{"type": "search", "rich": {"type": "rich", "hint": {"vertical": "calculator", "callback_key": "abc123"}}}`))
+		case RichEndpoint:
+			assert.Equal(t, "abc123", r.URL.Query().Get("callback_key"))
+			_, _ = w.Write([]byte(`{"type": "rich", "results": [{"subtype": "calculator", "result": "42"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "6 * 7", &WebSearchParams{EnableRichCallback: true})
+	require.NoError(t, err)
+
+	hint, ok := response.InstantAnswer()
+	require.True(t, ok)
+	assert.Equal(t, RichVerticalCalculator, hint.Vertical)
+
+	answer, ok, err := client.InstantAnswer(context.Background(), response)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, RichVerticalCalculator, answer.Vertical)
+
+	var data struct {
+		Results []struct {
+			Result string `json:"result"`
+		} `json:"results"`
+	}
+	require.NoError(t, answer.Decode(&data))
+	require.Len(t, data.Results, 1)
+	assert.Equal(t, "42", data.Results[0].Result)
+}
+
+// TestInstantAnswerMissing tests responses without an instant answer
+func TestInstantAnswerMissing(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	response := &WebSearchResponse{}
+	_, ok := response.InstantAnswer()
+	assert.False(t, ok)
+
+	answer, ok, err := client.InstantAnswer(context.Background(), response)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, answer)
+}
+
+// TestInstantAnswerError tests that fetch failures name the vertical
+func TestInstantAnswerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	response := &WebSearchResponse{Rich: &RichCallback{Hint: RichHint{Vertical: RichVerticalWeather, CallbackKey: "expired"}}}
+	_, _, err = client.InstantAnswer(context.Background(), response)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "weather instant answer")
+}
@@ -0,0 +1,63 @@
+// Package hostutil normalizes the hostnames of Brave Search results and
+// matches them against domains, for the packages of this module.
+package hostutil
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Normalize lowercases hostname and drops the trailing dot of a fully
+// qualified name
+func Normalize(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(hostname), ".")
+}
+
+// WithoutWWW returns the normalized hostname without a leading "www."
+func WithoutWWW(hostname string) string {
+	return strings.TrimPrefix(Normalize(hostname), "www.")
+}
+
+// Of returns the normalized hostname of a result: metaHostname, the hostname
+// of its meta_url, if set, else that of rawURL. It is empty if neither is
+// available.
+func Of(metaHostname, rawURL string) string {
+	if metaHostname != "" {
+		return Normalize(metaHostname)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return Normalize(u.Hostname())
+}
+
+// MatchesDomain reports whether hostname is domain or one of its subdomains.
+// A leading "www." of domain is ignored.
+func MatchesDomain(hostname, domain string) bool {
+	hostname, domain = Normalize(hostname), WithoutWWW(domain)
+	if domain == "" {
+		return false
+	}
+	return hostname == domain || strings.HasSuffix(hostname, "."+domain)
+}
+
+// MatchesAnyDomain reports whether hostname is one of domains or a subdomain of one
+func MatchesAnyDomain(hostname string, domains []string) bool {
+	return slices.ContainsFunc(domains, func(domain string) bool {
+		return MatchesDomain(hostname, domain)
+	})
+}
+
+// Parents returns the normalized hostname and the domains it belongs to,
+// most specific first: "a.example.com", "example.com" and "com"
+func Parents(hostname string) []string {
+	hostname = Normalize(hostname)
+	var parents []string
+	for hostname != "" {
+		parents = append(parents, hostname)
+		_, hostname, _ = strings.Cut(hostname, ".")
+	}
+	return parents
+}
@@ -0,0 +1,39 @@
+package hostutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalize tests normalizing hostnames
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "www.example.com", Normalize("WWW.Example.com."))
+	assert.Equal(t, "example.com", WithoutWWW("WWW.Example.com."))
+	assert.Equal(t, "www2.example.com", WithoutWWW("www2.example.com"))
+}
+
+// TestOf tests taking the hostname of a result
+func TestOf(t *testing.T) {
+	assert.Equal(t, "docs.example.com", Of("Docs.Example.com", "https://example.org/"))
+	assert.Equal(t, "example.org", Of("", "https://Example.org./path"))
+	assert.Equal(t, "", Of("", "://invalid"))
+}
+
+// TestMatchesDomain tests matching hostnames against domains
+func TestMatchesDomain(t *testing.T) {
+	assert.True(t, MatchesDomain("example.com", "example.com"))
+	assert.True(t, MatchesDomain("Docs.Example.com.", "www.example.com"))
+	assert.False(t, MatchesDomain("notexample.com", "example.com"))
+	assert.False(t, MatchesDomain("example.com", ""))
+
+	assert.True(t, MatchesAnyDomain("gist.github.com", []string{"gitlab.com", "github.com"}))
+	assert.False(t, MatchesAnyDomain("github.io", []string{"gitlab.com", "github.com"}))
+	assert.False(t, MatchesAnyDomain("github.com", nil))
+}
+
+// TestParents tests listing the domains a hostname belongs to
+func TestParents(t *testing.T) {
+	assert.Equal(t, []string{"a.example.com", "example.com", "com"}, Parents("A.Example.com."))
+	assert.Empty(t, Parents(""))
+}
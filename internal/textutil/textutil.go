@@ -0,0 +1,34 @@
+// Package textutil cleans up the text of Brave Search results, such as
+// titles and descriptions with <strong> text decorations, for the packages
+// of this module.
+package textutil
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// htmlTagPattern matches HTML tags, such as the <strong> text decorations
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// StripMarkup replaces the HTML tags of text with replacement and unescapes
+// its HTML entities
+func StripMarkup(text, replacement string) string {
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(text, replacement))
+}
+
+// PlainText strips markup from text decorations and collapses whitespace
+func PlainText(text string) string {
+	return strings.Join(strings.Fields(StripMarkup(text, "")), " ")
+}
+
+// FirstNonEmpty returns the first of values that is not blank, trimmed
+func FirstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStripMarkup tests removing tags and unescaping entities
+func TestStripMarkup(t *testing.T) {
+	assert.Equal(t, "Go & Rust", StripMarkup("<strong>Go</strong> &amp; Rust", ""))
+	assert.Equal(t, " Go  Rust", StripMarkup("<strong>Go</strong><br>Rust", " "))
+}
+
+// TestPlainText tests stripping markup and collapsing whitespace
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "The Go programming language", PlainText("  The <strong>Go</strong>\n programming   language "))
+	assert.Equal(t, "", PlainText("<br>"))
+}
+
+// TestFirstNonEmpty tests choosing the first non-blank value
+func TestFirstNonEmpty(t *testing.T) {
+	assert.Equal(t, "b", FirstNonEmpty("", "  ", " b ", "c"))
+	assert.Equal(t, "", FirstNonEmpty("", " "))
+	assert.Equal(t, "", FirstNonEmpty())
+}
@@ -0,0 +1,157 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// JobBoardDomains are the sites a job search boosts: job boards and the
+// applicant tracking systems companies post openings on. A domain also
+// matches its subdomains.
+var JobBoardDomains = []string{
+	"linkedin.com", "indeed.com", "glassdoor.com", "monster.com",
+	"ziprecruiter.com", "wellfound.com", "stackoverflow.com", "dice.com",
+	"greenhouse.io", "lever.co", "workable.com", "smartrecruiters.com",
+	"ashbyhq.com", "myworkdayjobs.com", "weworkremotely.com", "remoteok.com",
+}
+
+// JobsGoggle is the Goggle definition a job search re-ranks results with,
+// boosting JobBoardDomains
+var JobsGoggle = boostGoggle("Jobs", "Boosts job boards", JobBoardDomains)
+
+// JobPosting is a job opening parsed from a web result. Title, Company and
+// Location are parsed from the result title as formatted by common job
+// boards and are empty if the format is not recognized.
+type JobPosting struct {
+	Title    string
+	Company  string
+	Location string
+
+	// Remote reports whether the posting mentions remote work
+	Remote bool
+
+	URL         string
+	Description string
+	Age         string
+
+	// Board is the hostname of the site the posting is on, without "www."
+	Board string
+
+	// OnJobBoard reports whether the posting is on one of JobBoardDomains
+	OnJobBoard bool
+}
+
+// Job title formats of common job boards, tried in order
+var (
+	// "Acme hiring Software Engineer in Berlin, Germany" (LinkedIn)
+	jobHiringPattern = regexp.MustCompile(`^(.+?) hiring (.+?)(?: in (.+))?$`)
+
+	// "Job Application for Software Engineer at Acme" (Greenhouse)
+	jobApplicationPattern = regexp.MustCompile(`(?i)^job application for (.+?) at (.+)$`)
+
+	// "Software Engineer Jobs in Austin, TX" (Glassdoor, Indeed)
+	jobInPattern = regexp.MustCompile(`(?i)^(.+?) jobs? in (.+)$`)
+
+	// "Software Engineer at Acme" or "Software Engineer at Acme in Berlin"
+	jobAtPattern = regexp.MustCompile(`^(.+?) at (.+?)(?: in (.+))?$`)
+
+	// "Software Engineer - Acme - Austin, TX" (Indeed, LinkedIn)
+	jobSeparatorPattern = regexp.MustCompile(`\s+[-–—|]\s+`)
+)
+
+// jobTitleSuffixes are the site names job boards append to titles
+var jobTitleSuffixes = regexp.MustCompile(`(?i)\s*[|\-–—]\s*(linkedin|indeed(\.com)?|glassdoor|monster(\.com)?|ziprecruiter|wellfound|dice|lever|greenhouse|workable)\s*$`)
+
+// WebSearchJobs performs a web search that ranks job boards higher (see
+// JobsGoggle) and returns the web results as JobPostings. Results from
+// other sites are kept, ranked lower.
+func (c *Client) WebSearchJobs(ctx context.Context, query string) ([]JobPosting, error) {
+	response, err := c.WebSearch(ctx, query, &WebSearchParams{
+		ResultFilter: "web",
+		Goggles:      JobsGoggle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var postings []JobPosting
+	for _, result := range response.GetWebResults() {
+		postings = append(postings, parseJobPosting(result))
+	}
+	return postings, nil
+}
+
+// parseJobPosting parses the title, company and location of a web result
+func parseJobPosting(result SearchResult) JobPosting {
+	posting := JobPosting{
+		URL:         result.URL,
+		Description: result.Description,
+		Age:         result.Age,
+		Board:       hostutil.WithoutWWW(result.hostname()),
+	}
+	posting.OnJobBoard = hostutil.MatchesAnyDomain(posting.Board, JobBoardDomains)
+
+	title := strings.TrimSpace(jobTitleSuffixes.ReplaceAllString(result.Title, ""))
+	if m := jobHiringPattern.FindStringSubmatch(title); m != nil {
+		posting.Company, posting.Title, posting.Location = m[1], m[2], m[3]
+	} else if m := jobApplicationPattern.FindStringSubmatch(title); m != nil {
+		posting.Title, posting.Company = m[1], m[2]
+	} else if fields := splitJobTitle(title); len(fields) >= 2 {
+		posting.Title, posting.Company = fields[0], fields[1]
+		if len(fields) >= 3 {
+			posting.Location = fields[2]
+		}
+	} else if m := jobInPattern.FindStringSubmatch(title); m != nil {
+		posting.Title, posting.Location = m[1], m[2]
+	} else if m := jobAtPattern.FindStringSubmatch(title); m != nil {
+		posting.Title, posting.Company, posting.Location = m[1], m[2], m[3]
+	} else {
+		posting.Title = title
+	}
+
+	posting.Remote = mentionsRemote(posting.Title) || mentionsRemote(posting.Location) || mentionsRemote(result.Description)
+	if posting.Company == "" {
+		posting.Company = companyFromATS(result.URL)
+	}
+	return posting
+}
+
+// splitJobTitle splits a title on " - " and similar separators
+func splitJobTitle(title string) []string {
+	var fields []string
+	for _, field := range jobSeparatorPattern.Split(title, -1) {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// remotePattern matches mentions of remote work
+var remotePattern = regexp.MustCompile(`(?i)\b(remote|work from home|wfh)\b`)
+
+// mentionsRemote reports whether text mentions remote work
+func mentionsRemote(text string) bool {
+	return remotePattern.MatchString(text)
+}
+
+// atsDomains host applicant tracking systems that name the company first in the path
+var atsDomains = []string{"lever.co", "greenhouse.io", "ashbyhq.com", "workable.com"}
+
+// companyFromATS returns the company of a posting on an applicant tracking
+// system, whose URLs name it in the path (e.g. jobs.lever.co/acme/...)
+func companyFromATS(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := pathSegments(u.Path)
+	if len(segments) == 0 || !hostutil.MatchesAnyDomain(u.Hostname(), atsDomains) {
+		return ""
+	}
+	return segments[0]
+}
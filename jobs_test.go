@@ -0,0 +1,94 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobsResponse has postings on job boards and an article
+const jobsResponse = `{
+	"type": "search",
+	"web": {"type": "search", "results": [
+		{"title": "Acme hiring Go Engineer in Berlin, Germany | LinkedIn", "url": "https://de.linkedin.com/jobs/view/go-engineer-at-acme-1", "age": "2 days ago"},
+		{"title": "Go Developer - Initech - Austin, TX - Indeed.com", "url": "https://www.indeed.com/viewjob?jk=abc", "description": "Fully remote position."},
+		{"title": "How to hire Go developers", "url": "https://example.com/blog/hiring"}
+	]}
+}`
+
+// TestWebSearchJobs tests the job search
+func TestWebSearchJobs(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(jobsResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	postings, err := client.WebSearchJobs(context.Background(), "go engineer")
+	require.NoError(t, err)
+
+	assert.Equal(t, "go engineer", query.Get("q"))
+	assert.Equal(t, JobsGoggle, query.Get("goggles"))
+	assert.Contains(t, JobsGoggle, "$boost=3,site=linkedin.com\n")
+
+	require.Len(t, postings, 3)
+	assert.Equal(t, JobPosting{
+		Title:      "Go Engineer",
+		Company:    "Acme",
+		Location:   "Berlin, Germany",
+		URL:        "https://de.linkedin.com/jobs/view/go-engineer-at-acme-1",
+		Age:        "2 days ago",
+		Board:      "de.linkedin.com",
+		OnJobBoard: true,
+	}, postings[0])
+
+	assert.Equal(t, "Go Developer", postings[1].Title)
+	assert.Equal(t, "Initech", postings[1].Company)
+	assert.Equal(t, "Austin, TX", postings[1].Location)
+	assert.True(t, postings[1].Remote)
+	assert.Equal(t, "indeed.com", postings[1].Board)
+
+	assert.False(t, postings[2].OnJobBoard)
+}
+
+// TestParseJobPosting tests parsing titles in the formats of common job boards
+func TestParseJobPosting(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		url      string
+		job      string
+		company  string
+		location string
+		remote   bool
+	}{
+		{"hiring", "Globex hiring Data Engineer in Remote | LinkedIn", "https://www.linkedin.com/jobs/view/1", "Data Engineer", "Globex", "Remote", true},
+		{"hiring without location", "Globex hiring Data Engineer", "https://www.linkedin.com/jobs/view/1", "Data Engineer", "Globex", "", false},
+		{"greenhouse", "Job Application for Site Reliability Engineer at Hooli", "https://boards.greenhouse.io/hooli/jobs/1", "Site Reliability Engineer", "Hooli", "", false},
+		{"dashes", "Backend Engineer – Umbrella – London", "https://www.glassdoor.com/job-listing/1", "Backend Engineer", "Umbrella", "London", false},
+		{"jobs in", "Golang Jobs in Tokyo | Glassdoor", "https://www.glassdoor.com/Job/tokyo-golang-jobs", "Golang", "", "Tokyo", false},
+		{"at", "Staff Engineer at Vandelay in New York", "https://example.com/careers/1", "Staff Engineer", "Vandelay", "New York", false},
+		{"ats company", "Senior Go Engineer", "https://jobs.lever.co/acme/1234", "Senior Go Engineer", "acme", "", false},
+		{"plain", "Careers", "https://example.com/careers", "Careers", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posting := parseJobPosting(SearchResult{Title: tt.title, URL: tt.url})
+			assert.Equal(t, tt.job, posting.Title)
+			assert.Equal(t, tt.company, posting.Company)
+			assert.Equal(t, tt.location, posting.Location)
+			assert.Equal(t, tt.remote, posting.Remote)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package bravesearch
+
+import (
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// KnowledgeCard is a normalized view of an infobox that UIs can display
+// regardless of the entity subtype
+type KnowledgeCard struct {
+	Title       string
+	Subtitle    string
+	Description string
+
+	// Attributes maps attribute names to values; AttributeOrder keeps the
+	// order the API listed them in
+	Attributes     map[string]string
+	AttributeOrder []string
+
+	// Images are image URLs, the thumbnail first
+	Images []string
+
+	// Links are official links, such as the website and social profiles
+	Links []KnowledgeCardLink
+}
+
+// KnowledgeCardLink is a named link of a knowledge card
+type KnowledgeCardLink struct {
+	Name string
+	URL  string
+}
+
+// KnowledgeCard renders the infobox as a KnowledgeCard. The subtitle is the
+// label, falling back to the category; the description prefers the long
+// description. Empty attributes and duplicate images and links are dropped.
+func (i *InfoboxResult) KnowledgeCard() *KnowledgeCard {
+	card := &KnowledgeCard{
+		Title:       strings.TrimSpace(i.Title),
+		Subtitle:    textutil.FirstNonEmpty(i.Label, i.Category),
+		Description: textutil.PlainText(textutil.FirstNonEmpty(i.LongDesc, i.Description)),
+		Attributes:  make(map[string]string),
+	}
+
+	for _, attribute := range i.Attributes {
+		if len(attribute) < 2 {
+			continue
+		}
+		name, value := strings.TrimSpace(attribute[0]), textutil.PlainText(attribute[1])
+		if name == "" || value == "" {
+			continue
+		}
+		if _, ok := card.Attributes[name]; !ok {
+			card.AttributeOrder = append(card.AttributeOrder, name)
+		}
+		card.Attributes[name] = value
+	}
+
+	seen := make(map[string]bool)
+	addImage := func(thumbnail *Thumbnail) {
+		if thumbnail == nil {
+			return
+		}
+		src := textutil.FirstNonEmpty(thumbnail.Original, thumbnail.Src)
+		if src != "" && !seen[src] {
+			seen[src] = true
+			card.Images = append(card.Images, src)
+		}
+	}
+	addImage(i.Thumbnail)
+	for _, image := range i.Images {
+		addImage(&image)
+	}
+
+	addLink := func(name, url string) {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			card.Links = append(card.Links, KnowledgeCardLink{Name: name, URL: url})
+		}
+	}
+	addLink("Website", i.WebsiteURL)
+	for _, profile := range i.Profiles {
+		addLink(textutil.FirstNonEmpty(profile.LongName, profile.Name), profile.URL)
+	}
+
+	return card
+}
+
+// KnowledgeCard renders the first infobox of the response, if any
+func (r *WebSearchResponse) KnowledgeCard() (*KnowledgeCard, bool) {
+	if r.Infobox == nil || len(r.Infobox.Results) == 0 {
+		return nil, false
+	}
+	return r.Infobox.Results[0].KnowledgeCard(), true
+}
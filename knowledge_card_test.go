@@ -0,0 +1,59 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKnowledgeCard tests rendering infoboxes as knowledge cards
+func TestKnowledgeCard(t *testing.T) {
+	data := `{
+		"type": "search",
+		"infobox": {
+			"type": "graph",
+			"results": [{
+				"type": "infobox",
+				"subtype": "software",
+				"title": "Go",
+				"url": "https://en.wikipedia.org/wiki/Go_(programming_language)",
+				"category": "Programming language",
+				"description": "Programming language",
+				"long_desc": "Go is a statically typed, <strong>compiled</strong> programming language.",
+				"thumbnail": {"src": "https://imgs.search.brave.com/go.png", "original": "https://go.dev/images/go-logo-blue.svg"},
+				"images": [{"src": "https://imgs.search.brave.com/go.png", "original": "https://go.dev/images/go-logo-blue.svg"}, {"src": "https://imgs.search.brave.com/gopher.png"}],
+				"attributes": [["Designed by", "Robert Griesemer, Rob Pike, Ken Thompson"], ["First appeared", "2009"], ["Empty", ""], ["Invalid"]],
+				"website_url": "https://go.dev/",
+				"profiles": [{"name": "github", "long_name": "GitHub", "url": "https://github.com/golang/go"}, {"name": "website", "url": "https://go.dev/"}]
+			}]
+		}
+	}`
+
+	var response WebSearchResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &response))
+
+	card, ok := response.KnowledgeCard()
+	require.True(t, ok)
+
+	assert.Equal(t, "Go", card.Title)
+	assert.Equal(t, "Programming language", card.Subtitle)
+	assert.Equal(t, "Go is a statically typed, compiled programming language.", card.Description)
+	assert.Equal(t, []string{"Designed by", "First appeared"}, card.AttributeOrder)
+	assert.Equal(t, "2009", card.Attributes["First appeared"])
+	assert.Equal(t, []string{"https://go.dev/images/go-logo-blue.svg", "https://imgs.search.brave.com/gopher.png"}, card.Images)
+	assert.Equal(t, []KnowledgeCardLink{
+		{Name: "Website", URL: "https://go.dev/"},
+		{Name: "GitHub", URL: "https://github.com/golang/go"},
+	}, card.Links)
+}
+
+// TestKnowledgeCardMissing tests responses without an infobox
+func TestKnowledgeCardMissing(t *testing.T) {
+	_, ok := (&WebSearchResponse{}).KnowledgeCard()
+	assert.False(t, ok)
+
+	_, ok = (&WebSearchResponse{Infobox: &GraphInfobox{Type: "graph"}}).KnowledgeCard()
+	assert.False(t, ok)
+}
@@ -0,0 +1,112 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySmoothing is the weight of the newest request in the smoothed
+// latency of an endpoint. At 0.2 the estimate follows a sustained change
+// within about ten requests while single outliers move it little.
+const latencySmoothing = 0.2
+
+// EndpointLatency is the smoothed latency of the requests to an endpoint
+type EndpointLatency struct {
+	// Endpoint is the path of the endpoint
+	Endpoint string `json:"endpoint"`
+
+	// Smoothed is the exponentially smoothed latency
+	Smoothed time.Duration `json:"smoothed"`
+
+	// Last is the latency of the most recent request
+	Last time.Duration `json:"last"`
+
+	// Requests is the number of requests measured
+	Requests int `json:"requests"`
+
+	// Breached reports whether Smoothed exceeds the threshold of the SLOReport
+	Breached bool `json:"breached"`
+}
+
+// SLOReport compares the smoothed latency of each endpoint with a latency budget
+type SLOReport struct {
+	// Threshold is the latency budget
+	Threshold time.Duration `json:"threshold"`
+
+	// Endpoints are the endpoints requested so far, ordered by path
+	Endpoints []EndpointLatency `json:"endpoints"`
+
+	// Breached reports whether any endpoint exceeds the threshold
+	Breached bool `json:"breached"`
+}
+
+// latencyTracker keeps the smoothed latency per endpoint
+type latencyTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointLatency
+}
+
+// observe adds the latency of a request to endpoint
+func (t *latencyTracker) observe(endpoint string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.endpoints == nil {
+		t.endpoints = make(map[string]*EndpointLatency)
+	}
+	stat, ok := t.endpoints[endpoint]
+	if !ok {
+		stat = &EndpointLatency{Endpoint: endpoint, Smoothed: latency}
+		t.endpoints[endpoint] = stat
+	} else {
+		stat.Smoothed += time.Duration(latencySmoothing * float64(latency-stat.Smoothed))
+	}
+	stat.Last = latency
+	stat.Requests++
+}
+
+// report compares the smoothed latencies with threshold
+func (t *latencyTracker) report(threshold time.Duration) SLOReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := SLOReport{Threshold: threshold, Endpoints: make([]EndpointLatency, 0, len(t.endpoints))}
+	for _, stat := range t.endpoints {
+		latency := *stat
+		latency.Breached = latency.Smoothed > threshold
+		report.Breached = report.Breached || latency.Breached
+		report.Endpoints = append(report.Endpoints, latency)
+	}
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].Endpoint < report.Endpoints[j].Endpoint
+	})
+	return report
+}
+
+// observeLatency records the latency of a request, unless it was cut short
+// by the caller's context
+func (c *Client) observeLatency(requestURL string, latency time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	parsed, parseErr := url.Parse(requestURL)
+	if parseErr != nil {
+		return
+	}
+	c.latency.observe(parsed.Path, latency)
+}
+
+// SLOReport returns the smoothed latency of each endpoint the client has
+// requested, flagging those slower than threshold, so services can alert on
+// degraded API latency without exporting raw metrics. The latency of a
+// request is the time spent waiting on the API: sending each attempt and
+// reading the response. Client-side rate limiting waits and the backoff
+// between retries are not included. It is measured for failed requests too,
+// except those canceled by the caller.
+func (c *Client) SLOReport(threshold time.Duration) SLOReport {
+	return c.latency.report(threshold)
+}
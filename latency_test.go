@@ -0,0 +1,108 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLatencyTracker tests smoothing latencies per endpoint
+func TestLatencyTracker(t *testing.T) {
+	var tracker latencyTracker
+	assert.Empty(t, tracker.report(time.Second).Endpoints)
+
+	tracker.observe(WebSearchEndpoint, 100*time.Millisecond)
+	tracker.observe(WebSearchEndpoint, 600*time.Millisecond)
+	tracker.observe(SuggestEndpoint, 50*time.Millisecond)
+
+	report := tracker.report(150 * time.Millisecond)
+	require.Len(t, report.Endpoints, 2)
+	assert.True(t, report.Breached)
+
+	suggest, web := report.Endpoints[0], report.Endpoints[1]
+	assert.Equal(t, SuggestEndpoint, suggest.Endpoint)
+	assert.Equal(t, 50*time.Millisecond, suggest.Smoothed)
+	assert.False(t, suggest.Breached)
+
+	// A single outlier moves the estimate by a fifth of the difference
+	assert.Equal(t, WebSearchEndpoint, web.Endpoint)
+	assert.Equal(t, 200*time.Millisecond, web.Smoothed)
+	assert.Equal(t, 600*time.Millisecond, web.Last)
+	assert.Equal(t, 2, web.Requests)
+	assert.True(t, web.Breached)
+
+	assert.False(t, tracker.report(time.Second).Breached)
+
+	// A sustained change is followed
+	for range 20 {
+		tracker.observe(WebSearchEndpoint, time.Second)
+	}
+	assert.InDelta(t, float64(time.Second), float64(tracker.report(time.Second).Endpoints[1].Smoothed), float64(20*time.Millisecond))
+}
+
+// TestClientSLOReport tests measuring the latency of requests
+func TestClientSLOReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	// Requests canceled by the caller are not measured
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.WebSearch(ctx, "golang", nil)
+	require.Error(t, err)
+
+	report := client.SLOReport(time.Millisecond)
+	require.Len(t, report.Endpoints, 1)
+	assert.Equal(t, WebSearchEndpoint, report.Endpoints[0].Endpoint)
+	assert.Equal(t, 1, report.Endpoints[0].Requests)
+	assert.GreaterOrEqual(t, report.Endpoints[0].Smoothed, 20*time.Millisecond)
+	assert.True(t, report.Breached)
+
+	assert.False(t, client.SLOReport(time.Minute).Breached)
+}
+
+// TestClientSLOReportExcludesBackoff tests that only the time spent waiting
+// on the API is measured
+func TestClientSLOReportExcludesBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(1))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load())
+
+	// The retry waited 100ms of backoff
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	report := client.SLOReport(time.Second)
+	require.Len(t, report.Endpoints, 1)
+	assert.Less(t, report.Endpoints[0].Smoothed, elapsed-90*time.Millisecond)
+}
@@ -0,0 +1,114 @@
+package bravesearch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// Tokenizer counts the tokens of a text for a language model.
+// Implementations must be safe for concurrent use.
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// TokenizerFunc is an adapter to allow the use of ordinary functions as Tokenizers
+type TokenizerFunc func(text string) int
+
+// CountTokens calls f(text)
+func (f TokenizerFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// EstimateTokens is a rough, model-independent token estimate: about four
+// characters per token for ASCII text and one token per character otherwise
+// (e.g. for CJK scripts). Use a real tokenizer when the budget is tight.
+var EstimateTokens Tokenizer = TokenizerFunc(estimateTokens)
+
+// estimateTokens implements EstimateTokens
+func estimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r <= unicode.MaxASCII {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// TokenBudget limits the size of text rendered for a language model
+type TokenBudget struct {
+	// MaxTokens is the maximum number of tokens; zero means no limit
+	MaxTokens int
+
+	// Tokenizer counts tokens; nil uses EstimateTokens
+	Tokenizer Tokenizer
+}
+
+// llmEllipsis marks a truncated snippet
+const llmEllipsis = "…"
+
+// FormatForLLM renders results as a compact numbered context block for a
+// language model, one entry per result:
+//
+//	[1] Title
+//	https://example.com/
+//	Snippet
+//
+// Markup is stripped from titles and snippets. Entries are added in order
+// while they fit the budget; the first entry that does not fit has its
+// snippet shortened at a word boundary if the rest of it fits, and rendering
+// stops there. Entries are counted separately, so each is tokenized only a
+// few times however long the output grows.
+func FormatForLLM(results []SearchResult, budget TokenBudget) string {
+	tokenizer := budget.Tokenizer
+	if tokenizer == nil {
+		tokenizer = EstimateTokens
+	}
+
+	var b strings.Builder
+	used := 0
+	for i, result := range results {
+		separator := ""
+		if i > 0 {
+			separator = "\n\n"
+		}
+
+		header := fmt.Sprintf("%s[%d] %s\n%s", separator, i+1, textutil.PlainText(result.Title), result.URL)
+		snippet := textutil.PlainText(result.Description)
+
+		entry := header
+		if snippet != "" {
+			entry += "\n" + snippet
+		}
+		if budget.MaxTokens <= 0 {
+			b.WriteString(entry)
+			continue
+		}
+		if tokens := tokenizer.CountTokens(entry); used+tokens <= budget.MaxTokens {
+			b.WriteString(entry)
+			used += tokens
+			continue
+		}
+
+		// Binary search the most words of the snippet for which the entry fits
+		words := strings.Fields(snippet)
+		shortened := func(n int) string {
+			return header + "\n" + strings.Join(words[:n], " ") + llmEllipsis
+		}
+		fitting := sort.Search(len(words)-1, func(n int) bool {
+			return used+tokenizer.CountTokens(shortened(n+1)) > budget.MaxTokens
+		})
+		if fitting > 0 {
+			b.WriteString(shortened(fitting))
+		}
+		break
+	}
+
+	return b.String()
+}
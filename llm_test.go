@@ -0,0 +1,73 @@
+package bravesearch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEstimateTokens tests the rough token estimate
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens.CountTokens(""))
+	assert.Equal(t, 1, EstimateTokens.CountTokens("abcd"))
+	assert.Equal(t, 2, EstimateTokens.CountTokens("abcde"))
+	assert.Equal(t, 3, EstimateTokens.CountTokens("日本語"))
+}
+
+// TestFormatForLLM tests rendering results for a language model
+func TestFormatForLLM(t *testing.T) {
+	results := []SearchResult{
+		{Title: "The <strong>Go</strong> Programming Language", URL: "https://go.dev/", Description: "Go is an open source   programming language &amp; more."},
+		{Title: "Go (programming language)", URL: "https://en.wikipedia.org/wiki/Go_(programming_language)"},
+	}
+
+	expected := "[1] The Go Programming Language\nhttps://go.dev/\nGo is an open source programming language & more.\n\n" +
+		"[2] Go (programming language)\nhttps://en.wikipedia.org/wiki/Go_(programming_language)"
+	assert.Equal(t, expected, FormatForLLM(results, TokenBudget{}))
+}
+
+// TestFormatForLLMBudget tests trimming output to the token budget
+func TestFormatForLLMBudget(t *testing.T) {
+	results := []SearchResult{
+		{Title: "one", URL: "https://1.example/", Description: "first snippet"},
+		{Title: "two", URL: "https://2.example/", Description: "alpha beta gamma delta epsilon"},
+		{Title: "three", URL: "https://3.example/", Description: "third snippet"},
+	}
+
+	// Count words as tokens, so the arithmetic is easy to follow
+	words := TokenizerFunc(func(text string) int { return len(strings.Fields(text)) })
+
+	// The first entry is 5 words and the second header is 3 words, leaving
+	// room for two words of the second snippet
+	output := FormatForLLM(results, TokenBudget{MaxTokens: 10, Tokenizer: words})
+	assert.Equal(t, "[1] one\nhttps://1.example/\nfirst snippet\n\n[2] two\nhttps://2.example/\nalpha beta…", output)
+
+	// Entries whose snippet cannot be shortened enough are left out
+	output = FormatForLLM(results, TokenBudget{MaxTokens: 6, Tokenizer: words})
+	assert.Equal(t, "[1] one\nhttps://1.example/\nfirst snippet", output)
+
+	// The budget is never exceeded
+	for budget := 1; budget <= 20; budget++ {
+		output := FormatForLLM(results, TokenBudget{MaxTokens: budget})
+		assert.LessOrEqual(t, EstimateTokens.CountTokens(output), budget)
+	}
+}
+
+// TestFormatForLLMTokenizerCalls tests shortening a long snippet with few tokenizer calls
+func TestFormatForLLMTokenizerCalls(t *testing.T) {
+	results := []SearchResult{
+		{Title: "one", URL: "https://1.example/", Description: "first snippet"},
+		{Title: "two", URL: "https://2.example/", Description: strings.Repeat("word ", 1000)},
+	}
+
+	calls := 0
+	words := TokenizerFunc(func(text string) int {
+		calls++
+		return len(strings.Fields(text))
+	})
+
+	output := FormatForLLM(results, TokenBudget{MaxTokens: 505, Tokenizer: words})
+	assert.Equal(t, 505, words.CountTokens(output))
+	assert.Less(t, calls, 20)
+}
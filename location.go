@@ -0,0 +1,289 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Location describes where the user is, sent to the API as X-Loc-* headers
+// to localize results. All fields are optional, but Latitude and Longitude
+// must be set together.
+//
+// Build one with NewLocation, LocationFromLatLong or LocationFromTimeZone
+// and chain the With* methods:
+//
+//	loc := bravesearch.NewLocation().
+//		WithCity("Tokyo").
+//		WithCountry("JP").
+//		WithTimezone("Asia/Tokyo")
+type Location struct {
+	// Latitude in degrees, between -90 and 90
+	Latitude *float64
+
+	// Longitude in degrees, between -180 and 180
+	Longitude *float64
+
+	// Timezone is an IANA time zone name such as "Asia/Tokyo"
+	Timezone string
+
+	// City is the city name
+	City string
+
+	// State is the ISO 3166-2 subdivision code, up to three characters (e.g. "CA")
+	State string
+
+	// StateName is the full subdivision name (e.g. "California")
+	StateName string
+
+	// Country is the ISO 3166-1 alpha-2 country code (e.g. "US")
+	Country string
+
+	// PostalCode is the postal code
+	PostalCode string
+}
+
+// coordinatePrecision is the number of decimal places sent for coordinates
+// (about 10 cm; more would only be noise)
+const coordinatePrecision = 6
+
+// maxLocationNameLength is the maximum length of free-text location fields
+const maxLocationNameLength = 100
+
+// NewLocation creates an empty Location
+func NewLocation() *Location {
+	return &Location{}
+}
+
+// LocationFromLatLong creates a Location from coordinates
+func LocationFromLatLong(latitude, longitude float64) (*Location, error) {
+	loc := NewLocation().WithLatLong(latitude, longitude)
+	if err := loc.Validate(); err != nil {
+		return nil, err
+	}
+	return loc, nil
+}
+
+// LocationFromTimeZone creates a Location from an IANA time zone name
+func LocationFromTimeZone(timezone string) (*Location, error) {
+	loc := NewLocation().WithTimezone(timezone)
+	if err := loc.Validate(); err != nil {
+		return nil, err
+	}
+	return loc, nil
+}
+
+// WithLatLong sets the coordinates
+func (l *Location) WithLatLong(latitude, longitude float64) *Location {
+	l.Latitude = &latitude
+	l.Longitude = &longitude
+	return l
+}
+
+// WithTimezone sets the IANA time zone name
+func (l *Location) WithTimezone(timezone string) *Location {
+	l.Timezone = strings.TrimSpace(timezone)
+	return l
+}
+
+// WithCity sets the city name
+func (l *Location) WithCity(city string) *Location {
+	l.City = strings.TrimSpace(city)
+	return l
+}
+
+// WithState sets the ISO 3166-2 subdivision code and, optionally, its name
+func (l *Location) WithState(code, name string) *Location {
+	l.State = strings.ToUpper(strings.TrimSpace(code))
+	l.StateName = strings.TrimSpace(name)
+	return l
+}
+
+// WithCountry sets the ISO 3166-1 alpha-2 country code
+func (l *Location) WithCountry(country string) *Location {
+	l.Country = strings.ToUpper(strings.TrimSpace(country))
+	return l
+}
+
+// WithPostalCode sets the postal code
+func (l *Location) WithPostalCode(postalCode string) *Location {
+	l.PostalCode = strings.TrimSpace(postalCode)
+	return l
+}
+
+// IsEmpty reports whether no location field is set
+func (l *Location) IsEmpty() bool {
+	return l == nil || *l == Location{}
+}
+
+// Validate checks every field and reports all problems found. The returned
+// error wraps ErrInvalidLocation.
+func (l *Location) Validate() error {
+	if l == nil {
+		return nil
+	}
+
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvalidLocation}, args...)...))
+	}
+
+	switch {
+	case (l.Latitude == nil) != (l.Longitude == nil):
+		invalid("latitude and longitude must be set together")
+	case l.Latitude != nil:
+		if lat := *l.Latitude; math.IsNaN(lat) || lat < -90 || lat > 90 {
+			invalid("latitude %v is out of range [-90, 90]", lat)
+		}
+		if long := *l.Longitude; math.IsNaN(long) || long < -180 || long > 180 {
+			invalid("longitude %v is out of range [-180, 180]", long)
+		}
+	}
+
+	if l.Timezone != "" {
+		if l.Timezone == "Local" || strings.HasPrefix(l.Timezone, "+") || strings.HasPrefix(l.Timezone, "-") {
+			invalid("timezone %q must be an IANA name such as \"Asia/Tokyo\"", l.Timezone)
+		} else if _, err := time.LoadLocation(l.Timezone); err != nil {
+			invalid("unknown timezone %q (expected an IANA name such as \"Asia/Tokyo\")", l.Timezone)
+		}
+	}
+
+	if l.Country != "" && !isLetters(l.Country, 2, 2) {
+		invalid("country %q must be a two-letter ISO 3166-1 code such as \"US\"", l.Country)
+	}
+
+	if l.State != "" && !isAlphanumeric(l.State, 1, 3) {
+		invalid("state %q must be an ISO 3166-2 subdivision code of up to 3 characters such as \"CA\"", l.State)
+	}
+
+	if l.PostalCode != "" && !isPostalCode(l.PostalCode) {
+		invalid("postal code %q must be up to 10 letters, digits, spaces or dashes", l.PostalCode)
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"city", l.City},
+		{"state name", l.StateName},
+	} {
+		if len(field.value) > maxLocationNameLength {
+			invalid("%s is longer than %d characters", field.name, maxLocationNameLength)
+		} else if strings.ContainsFunc(field.value, unicode.IsControl) {
+			invalid("%s %q contains control characters", field.name, field.value)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Headers validates the location and returns it as X-Loc-* request headers
+func (l *Location) Headers() (http.Header, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	if l == nil {
+		return header, nil
+	}
+
+	set := func(key, value string) {
+		if value != "" {
+			header.Set(key, value)
+		}
+	}
+
+	if l.Latitude != nil {
+		set(HeaderLocLatitude, formatCoordinate(*l.Latitude))
+		set(HeaderLocLongitude, formatCoordinate(*l.Longitude))
+	}
+	set(HeaderLocTimezone, l.Timezone)
+	set(HeaderLocCity, l.City)
+	set(HeaderLocState, l.State)
+	set(HeaderLocStateName, l.StateName)
+	set(HeaderLocCountry, l.Country)
+	set(HeaderLocPostalCode, l.PostalCode)
+
+	return header, nil
+}
+
+// formatCoordinate formats a coordinate with at most coordinatePrecision decimals
+func formatCoordinate(v float64) string {
+	scale := math.Pow10(coordinatePrecision)
+	return strconv.FormatFloat(math.Round(v*scale)/scale, 'f', -1, 64)
+}
+
+// isLetters reports whether s consists of between minLen and maxLen ASCII letters
+func isLetters(s string, minLen, maxLen int) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumeric reports whether s consists of between minLen and maxLen ASCII letters or digits
+func isAlphanumeric(s string, minLen, maxLen int) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isPostalCode reports whether s looks like a postal code
+func isPostalCode(s string) bool {
+	if len(s) > 10 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != ' ' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// LocationResolver derives the location of the end user a request is made
+// for, e.g. by looking up the IP address stored with ContextWithClientIP in a
+// GeoIP database. It may return a nil Location if the user cannot be located.
+// Implementations must be safe for concurrent use.
+type LocationResolver interface {
+	ResolveLocation(ctx context.Context) (*Location, error)
+}
+
+// LocationResolverFunc is an adapter to allow the use of ordinary functions as LocationResolvers
+type LocationResolverFunc func(ctx context.Context) (*Location, error)
+
+// ResolveLocation calls f(ctx)
+func (f LocationResolverFunc) ResolveLocation(ctx context.Context) (*Location, error) {
+	return f(ctx)
+}
+
+// clientIPKey is the context key for the end-user IP address
+type clientIPKey struct{}
+
+// ContextWithClientIP returns a copy of ctx carrying the IP address of the end
+// user a search is made for, for use by a LocationResolver
+func ContextWithClientIP(ctx context.Context, ip netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the end-user IP address stored in ctx, if any
+func ClientIPFromContext(ctx context.Context) (netip.Addr, bool) {
+	ip, ok := ctx.Value(clientIPKey{}).(netip.Addr)
+	return ip, ok && ip.IsValid()
+}
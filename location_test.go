@@ -0,0 +1,203 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLocationHeaders tests converting a location into X-Loc-* headers
+func TestLocationHeaders(t *testing.T) {
+	loc := NewLocation().
+		WithLatLong(35.6894875, 139.6917064123).
+		WithTimezone("Asia/Tokyo").
+		WithCity("Tokyo").
+		WithState("13", "Tokyo").
+		WithCountry("jp").
+		WithPostalCode("160-0023")
+
+	header, err := loc.Headers()
+	require.NoError(t, err)
+	assert.Equal(t, "35.689488", header.Get(HeaderLocLatitude))
+	assert.Equal(t, "139.691706", header.Get(HeaderLocLongitude))
+	assert.Equal(t, "Asia/Tokyo", header.Get(HeaderLocTimezone))
+	assert.Equal(t, "Tokyo", header.Get(HeaderLocCity))
+	assert.Equal(t, "13", header.Get(HeaderLocState))
+	assert.Equal(t, "Tokyo", header.Get(HeaderLocStateName))
+	assert.Equal(t, "JP", header.Get(HeaderLocCountry))
+	assert.Equal(t, "160-0023", header.Get(HeaderLocPostalCode))
+
+	// Unset fields are omitted
+	header, err = NewLocation().WithCountry("US").Headers()
+	require.NoError(t, err)
+	assert.Len(t, header, 1)
+
+	// A nil location has no headers
+	var nilLoc *Location
+	header, err = nilLoc.Headers()
+	require.NoError(t, err)
+	assert.Empty(t, header)
+	assert.True(t, nilLoc.IsEmpty())
+	assert.True(t, NewLocation().IsEmpty())
+	assert.False(t, loc.IsEmpty())
+}
+
+// TestLocationValidate tests location validation errors
+func TestLocationValidate(t *testing.T) {
+	lat := 10.0
+
+	tests := []struct {
+		name     string
+		location *Location
+		message  string
+	}{
+		{"latitude out of range", NewLocation().WithLatLong(91, 0), "latitude 91 is out of range"},
+		{"longitude out of range", NewLocation().WithLatLong(0, -181), "longitude -181 is out of range"},
+		{"NaN coordinate", NewLocation().WithLatLong(math.NaN(), 0), "latitude NaN"},
+		{"latitude only", &Location{Latitude: &lat}, "must be set together"},
+		{"unknown timezone", NewLocation().WithTimezone("Mars/Olympus"), "unknown timezone"},
+		{"offset timezone", NewLocation().WithTimezone("+09:00"), "must be an IANA name"},
+		{"country code", NewLocation().WithCountry("JPN"), "two-letter ISO 3166-1 code"},
+		{"state code", NewLocation().WithState("Tokyo", ""), "ISO 3166-2 subdivision code"},
+		{"postal code", NewLocation().WithPostalCode("1600023/45"), "postal code"},
+		{"city control characters", NewLocation().WithCity("Tokyo\x00"), "control characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.location.Validate()
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidLocation)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+
+	// All problems are reported at once
+	err := NewLocation().WithLatLong(100, 200).WithCountry("Japan").Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "latitude")
+	assert.Contains(t, err.Error(), "longitude")
+	assert.Contains(t, err.Error(), "country")
+}
+
+// TestLocationConstructors tests the LocationFrom* constructors
+func TestLocationConstructors(t *testing.T) {
+	loc, err := LocationFromLatLong(51.5072, -0.1276)
+	require.NoError(t, err)
+	assert.Equal(t, 51.5072, *loc.Latitude)
+	assert.Equal(t, -0.1276, *loc.Longitude)
+
+	loc, err = LocationFromLatLong(-91, 0)
+	assert.Nil(t, loc)
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+
+	loc, err = LocationFromTimeZone("Europe/London")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/London", loc.Timezone)
+
+	loc, err = LocationFromTimeZone("London")
+	assert.Nil(t, loc)
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+}
+
+// TestWebSearchWithLocation tests that locations are sent as headers
+func TestWebSearchWithLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Tokyo", r.Header.Get(HeaderLocCity))
+		assert.Equal(t, "Asia/Tokyo", r.Header.Get(HeaderLocTimezone))
+		assert.False(t, r.URL.Query().Has("location"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	params := &WebSearchParams{
+		Location: NewLocation().WithCity("Tokyo").WithTimezone("Asia/Tokyo"),
+	}
+	_, err = client.WebSearch(context.Background(), "ramen", params)
+	assert.NoError(t, err)
+
+	// Invalid locations fail before any request is made
+	params.Location.WithCountry("Japan")
+	_, err = client.WebSearch(context.Background(), "ramen", params)
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+}
+
+// TestClientIPFromContext tests storing the end-user IP in a context
+func TestClientIPFromContext(t *testing.T) {
+	_, ok := ClientIPFromContext(context.Background())
+	assert.False(t, ok)
+
+	ip := netip.MustParseAddr("203.0.113.7")
+	got, ok := ClientIPFromContext(ContextWithClientIP(context.Background(), ip))
+	assert.True(t, ok)
+	assert.Equal(t, ip, got)
+
+	_, ok = ClientIPFromContext(ContextWithClientIP(context.Background(), netip.Addr{}))
+	assert.False(t, ok)
+}
+
+// TestWithAutoLocation tests resolving the location per end user
+func TestWithAutoLocation(t *testing.T) {
+	var lastCity string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastCity = r.Header.Get(HeaderLocCity)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	// Resolve locations from a tiny IP table
+	resolver := LocationResolverFunc(func(ctx context.Context) (*Location, error) {
+		ip, ok := ClientIPFromContext(ctx)
+		if !ok {
+			return nil, nil
+		}
+		switch ip.String() {
+		case "203.0.113.7":
+			return NewLocation().WithCity("Osaka").WithCountry("JP"), nil
+		case "198.51.100.1":
+			return nil, errors.New("geoip database unavailable")
+		}
+		return nil, nil
+	})
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithAutoLocation(resolver))
+	require.NoError(t, err)
+
+	// Resolved location
+	ctx := ContextWithClientIP(context.Background(), netip.MustParseAddr("203.0.113.7"))
+	_, err = client.WebSearch(ctx, "ramen", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Osaka", lastCity)
+
+	// Explicit locations take precedence
+	params := &WebSearchParams{Location: NewLocation().WithCity("Kyoto")}
+	_, err = client.WebSearch(ctx, "ramen", params)
+	require.NoError(t, err)
+	assert.Equal(t, "Kyoto", lastCity)
+
+	// Unknown users get no location
+	_, err = client.WebSearch(context.Background(), "ramen", nil)
+	require.NoError(t, err)
+	assert.Empty(t, lastCity)
+
+	// Resolver errors fail the search
+	ctx = ContextWithClientIP(context.Background(), netip.MustParseAddr("198.51.100.1"))
+	_, err = client.WebSearch(ctx, "ramen", nil)
+	assert.ErrorContains(t, err, "geoip database unavailable")
+
+	// A nil resolver is rejected
+	_, err = NewClient("test-api-key", WithAutoLocation(nil))
+	assert.Equal(t, ErrInvalidParameters, err)
+}
@@ -0,0 +1,213 @@
+package bravesearch
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+)
+
+// LocationResult represents a location (point of interest) search result
+type LocationResult struct {
+	Type          string         `json:"type"`
+	ID            string         `json:"id,omitempty"`
+	Title         string         `json:"title"`
+	URL           string         `json:"url,omitempty"`
+	ProviderURL   string         `json:"provider_url,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Coordinates   []float64      `json:"coordinates,omitempty"`
+	PostalAddress *PostalAddress `json:"postal_address,omitempty"`
+	Contact       *Contact       `json:"contact,omitempty"`
+	PriceRange    string         `json:"price_range,omitempty"`
+	Rating        *Rating        `json:"rating,omitempty"`
+	Distance      *Distance      `json:"distance,omitempty"`
+	Categories    []string       `json:"categories,omitempty"`
+	ServesCuisine []string       `json:"serves_cuisine,omitempty"`
+	Thumbnail     *Thumbnail     `json:"thumbnail,omitempty"`
+	Timezone      string         `json:"timezone,omitempty"`
+}
+
+// PostalAddress represents a postal address
+type PostalAddress struct {
+	Type            string `json:"type,omitempty"`
+	Country         string `json:"country,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	DisplayAddress  string `json:"displayAddress,omitempty"`
+}
+
+// Contact represents contact information
+type Contact struct {
+	Email     string `json:"email,omitempty"`
+	Telephone string `json:"telephone,omitempty"`
+}
+
+// Rating represents a rating
+type Rating struct {
+	RatingValue   float64 `json:"ratingValue"`
+	BestRating    float64 `json:"bestRating,omitempty"`
+	ReviewCount   int     `json:"reviewCount,omitempty"`
+	IsTripadvisor bool    `json:"is_tripadvisor,omitempty"`
+}
+
+// Distance represents a distance as reported by the API
+type Distance struct {
+	Value float64 `json:"value"`
+	Units string  `json:"units"`
+}
+
+// LatLong returns the coordinates of the location, if known
+func (l *LocationResult) LatLong() (latitude, longitude float64, ok bool) {
+	if len(l.Coordinates) != 2 {
+		return 0, 0, false
+	}
+	return l.Coordinates[0], l.Coordinates[1], true
+}
+
+// Address returns the display address, or one composed from its parts
+func (l *LocationResult) Address() string {
+	if l.PostalAddress == nil {
+		return ""
+	}
+	a := l.PostalAddress
+	if a.DisplayAddress != "" {
+		return a.DisplayAddress
+	}
+
+	var parts []string
+	for _, part := range []string{a.StreetAddress, a.AddressLocality, a.AddressRegion, a.PostalCode, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection (RFC 7946)
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON Feature with a Point geometry
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id,omitempty"`
+	Geometry   Point          `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// Point is a GeoJSON Point; Coordinates are longitude then latitude
+type Point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// ToGeoJSON converts the location results into a GeoJSON FeatureCollection,
+// ready to be marshaled and rendered by map libraries such as Leaflet or
+// Mapbox. Each feature has name, url, address, rating, review_count,
+// categories and phone properties where known. Results without coordinates
+// are left out.
+func (l *Locations) ToGeoJSON() *FeatureCollection {
+	collection := &FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+	if l == nil {
+		return collection
+	}
+
+	for _, result := range l.Results {
+		latitude, longitude, ok := result.LatLong()
+		if !ok {
+			continue
+		}
+
+		properties := map[string]any{"name": result.Title}
+		setProperty := func(key string, value any, ok bool) {
+			if ok {
+				properties[key] = value
+			}
+		}
+		setProperty("url", result.URL, result.URL != "")
+		setProperty("address", result.Address(), result.Address() != "")
+		if result.Rating != nil {
+			setProperty("rating", result.Rating.RatingValue, true)
+			setProperty("review_count", result.Rating.ReviewCount, result.Rating.ReviewCount > 0)
+		}
+		setProperty("categories", result.Categories, len(result.Categories) > 0)
+		if result.Contact != nil {
+			setProperty("phone", result.Contact.Telephone, result.Contact.Telephone != "")
+		}
+
+		collection.Features = append(collection.Features, Feature{
+			Type:       "Feature",
+			ID:         result.ID,
+			Geometry:   Point{Type: "Point", Coordinates: [2]float64{longitude, latitude}},
+			Properties: properties,
+		})
+	}
+
+	return collection
+}
+
+// earthRadiusMeters is the mean radius of the Earth
+const earthRadiusMeters = 6371008.8
+
+// DistanceMeters returns the great-circle distance between two points in
+// meters, using the haversine formula
+func DistanceMeters(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLatitude := toRadians(latitude2 - latitude1)
+	dLongitude := toRadians(longitude2 - longitude1)
+	a := math.Sin(dLatitude/2)*math.Sin(dLatitude/2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Sin(dLongitude/2)*math.Sin(dLongitude/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// DistanceFrom returns the distance in meters from the reference point to
+// the location, if its coordinates are known
+func (l *LocationResult) DistanceFrom(latitude, longitude float64) (float64, bool) {
+	resultLatitude, resultLongitude, ok := l.LatLong()
+	if !ok {
+		return 0, false
+	}
+	return DistanceMeters(latitude, longitude, resultLatitude, resultLongitude), true
+}
+
+// WithinRadius returns the results within radiusMeters of the reference
+// point, in their original order. Results without coordinates are left out.
+func (l *Locations) WithinRadius(latitude, longitude, radiusMeters float64) []LocationResult {
+	if l == nil {
+		return nil
+	}
+
+	var results []LocationResult
+	for _, result := range l.Results {
+		if distance, ok := result.DistanceFrom(latitude, longitude); ok && distance <= radiusMeters {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// SortedByDistance returns a copy of the results sorted by distance from the
+// reference point, nearest first. Results without coordinates come last, in
+// their original order.
+func (l *Locations) SortedByDistance(latitude, longitude float64) []LocationResult {
+	if l == nil {
+		return nil
+	}
+
+	results := slices.Clone(l.Results)
+	distance := func(result *LocationResult) float64 {
+		if d, ok := result.DistanceFrom(latitude, longitude); ok {
+			return d
+		}
+		return math.Inf(1)
+	}
+	slices.SortStableFunc(results, func(a, b LocationResult) int {
+		return cmp.Compare(distance(&a), distance(&b))
+	})
+	return results
+}
@@ -0,0 +1,144 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLocations are location results as returned by the API
+const testLocations = `{
+	"type": "locations",
+	"results": [
+		{
+			"type": "location_result",
+			"id": "loc-1",
+			"title": "Ramen Nagi",
+			"url": "https://ramen.example/",
+			"coordinates": [35.6938, 139.7034],
+			"postal_address": {"streetAddress": "1-1-10 Kabukicho", "addressLocality": "Shinjuku", "country": "JP"},
+			"rating": {"ratingValue": 4.5, "bestRating": 5, "reviewCount": 1200},
+			"categories": ["Ramen"],
+			"contact": {"telephone": "+81 3-0000-0000"}
+		},
+		{
+			"type": "location_result",
+			"title": "Fuunji",
+			"coordinates": [35.6870, 139.6970],
+			"postal_address": {"displayAddress": "2-14-3 Yoyogi, Shibuya, Tokyo"}
+		},
+		{
+			"type": "location_result",
+			"title": "Somewhere"
+		}
+	]
+}`
+
+// TestLocationResultUnmarshal tests decoding location results
+func TestLocationResultUnmarshal(t *testing.T) {
+	var locations Locations
+	require.NoError(t, json.Unmarshal([]byte(testLocations), &locations))
+	require.Len(t, locations.Results, 3)
+
+	result := locations.Results[0]
+	latitude, longitude, ok := result.LatLong()
+	require.True(t, ok)
+	assert.Equal(t, 35.6938, latitude)
+	assert.Equal(t, 139.7034, longitude)
+	assert.Equal(t, "1-1-10 Kabukicho, Shinjuku, JP", result.Address())
+	assert.Equal(t, 4.5, result.Rating.RatingValue)
+
+	assert.Equal(t, "2-14-3 Yoyogi, Shibuya, Tokyo", locations.Results[1].Address())
+
+	_, _, ok = locations.Results[2].LatLong()
+	assert.False(t, ok)
+	assert.Empty(t, locations.Results[2].Address())
+}
+
+// TestLocationsToGeoJSON tests exporting location results as GeoJSON
+func TestLocationsToGeoJSON(t *testing.T) {
+	var locations Locations
+	require.NoError(t, json.Unmarshal([]byte(testLocations), &locations))
+
+	data, err := json.Marshal(locations.ToGeoJSON())
+	require.NoError(t, err)
+
+	expected := `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"type": "Feature",
+				"id": "loc-1",
+				"geometry": {"type": "Point", "coordinates": [139.7034, 35.6938]},
+				"properties": {
+					"name": "Ramen Nagi",
+					"url": "https://ramen.example/",
+					"address": "1-1-10 Kabukicho, Shinjuku, JP",
+					"rating": 4.5,
+					"review_count": 1200,
+					"categories": ["Ramen"],
+					"phone": "+81 3-0000-0000"
+				}
+			},
+			{
+				"type": "Feature",
+				"geometry": {"type": "Point", "coordinates": [139.697, 35.687]},
+				"properties": {"name": "Fuunji", "address": "2-14-3 Yoyogi, Shibuya, Tokyo"}
+			}
+		]
+	}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+// TestLocationsToGeoJSONEmpty tests that empty results give an empty collection
+func TestLocationsToGeoJSONEmpty(t *testing.T) {
+	var locations *Locations
+	data, err := json.Marshal(locations.ToGeoJSON())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "FeatureCollection", "features": []}`, string(data))
+}
+
+// TestDistanceMeters tests great-circle distances
+func TestDistanceMeters(t *testing.T) {
+	// Tokyo Station to Osaka Station is about 403 km
+	assert.InDelta(t, 403_000, DistanceMeters(35.6812, 139.7671, 34.7025, 135.4959), 2_000)
+	assert.Zero(t, DistanceMeters(35.6812, 139.7671, 35.6812, 139.7671))
+
+	// Antipodes
+	assert.InDelta(t, math.Pi*earthRadiusMeters, DistanceMeters(0, 0, 0, 180), 1)
+}
+
+// TestLocationsDistance tests filtering and sorting by distance
+func TestLocationsDistance(t *testing.T) {
+	var locations Locations
+	require.NoError(t, json.Unmarshal([]byte(testLocations), &locations))
+
+	// Shinjuku Station
+	latitude, longitude := 35.6896, 139.7006
+
+	distance, ok := locations.Results[0].DistanceFrom(latitude, longitude)
+	require.True(t, ok)
+	assert.InDelta(t, 550, distance, 50)
+
+	_, ok = locations.Results[2].DistanceFrom(latitude, longitude)
+	assert.False(t, ok)
+
+	nearby := locations.WithinRadius(latitude, longitude, 500)
+	require.Len(t, nearby, 1)
+	assert.Equal(t, "Fuunji", nearby[0].Title)
+
+	sorted := locations.SortedByDistance(latitude, longitude)
+	var titles []string
+	for _, result := range sorted {
+		titles = append(titles, result.Title)
+	}
+	assert.Equal(t, []string{"Fuunji", "Ramen Nagi", "Somewhere"}, titles)
+	assert.Equal(t, "Ramen Nagi", locations.Results[0].Title) // not modified
+
+	var missing *Locations
+	assert.Nil(t, missing.WithinRadius(latitude, longitude, 500))
+	assert.Nil(t, missing.SortedByDistance(latitude, longitude))
+}
@@ -0,0 +1,87 @@
+package bravesearch
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// NavigationalTarget returns the destination of a navigational query ("take
+// me to X"): the first web result whose domain or profile name matches the
+// query. A domain matches if the query is its hostname, without "www.", or
+// its site name, the label before the public suffix ("github" for
+// github.com, "bbc" for bbc.co.uk). It returns false if the API did not flag the query as navigational
+// or no result matches.
+func (r *WebSearchResponse) NavigationalTarget() (string, bool) {
+	if r.Query == nil || !r.Query.IsNavigational || r.Web == nil {
+		return "", false
+	}
+
+	target := compactText(r.Query.Original)
+	if r.Query.Altered != "" {
+		target = compactText(r.Query.Altered)
+	}
+	if target == "" {
+		return "", false
+	}
+
+	for _, result := range r.Web.Results {
+		u, err := url.Parse(result.URL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			continue
+		}
+
+		if hostnameMatches(result.hostname(), target) ||
+			(result.Profile != nil && compactText(result.Profile.Name) == target) {
+			return result.URL, true
+		}
+	}
+
+	return "", false
+}
+
+// genericSecondLevelLabels are the labels that, under a country code
+// top-level domain, form a public suffix ("co.uk", "com.au")
+var genericSecondLevelLabels = map[string]bool{
+	"ac": true, "co": true, "com": true, "edu": true, "gov": true,
+	"ne": true, "net": true, "or": true, "org": true,
+}
+
+// hostnameMatches reports whether target, a compacted query, names hostname:
+// the hostname without "www." or its site name
+func hostnameMatches(hostname, target string) bool {
+	hostname = hostutil.WithoutWWW(hostname)
+	if compactText(hostname) == target {
+		return true
+	}
+	return compactText(siteName(hostname)) == target
+}
+
+// siteName returns the label of hostname before its public suffix, guessed
+// without a public suffix list: the top-level domain, preceded by a generic
+// second-level label under two-letter country codes
+func siteName(hostname string) string {
+	labels := strings.Split(hostname, ".")
+	if len(labels) < 2 {
+		return ""
+	}
+	labels = labels[:len(labels)-1]
+	tld := hostname[strings.LastIndex(hostname, ".")+1:]
+	if len(tld) == 2 && len(labels) > 1 && genericSecondLevelLabels[labels[len(labels)-1]] {
+		labels = labels[:len(labels)-1]
+	}
+	return labels[len(labels)-1]
+}
+
+// compactText lowercases s and drops everything but letters and digits, so
+// "Go.dev" and "go dev" compare equal
+func compactText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,64 @@
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNavigationalTarget tests finding the destination of navigational queries
+func TestNavigationalTarget(t *testing.T) {
+	results := &Search{Results: []SearchResult{
+		{URL: "https://en.wikipedia.org/wiki/GitHub", MetaURL: &MetaURL{Hostname: "en.wikipedia.org"}},
+		{URL: "https://github.com/", MetaURL: &MetaURL{Hostname: "github.com"}},
+		{URL: "https://www.nytimes.com/", Profile: &Profile{Name: "The New York Times"}},
+		{URL: "https://www.google.com/", MetaURL: &MetaURL{Hostname: "www.google.com"}},
+		{URL: "https://www.apple.com/", MetaURL: &MetaURL{Hostname: "www.apple.com"}},
+		{URL: "https://go.dev/"},
+		{URL: "https://www.bbc.co.uk/news", MetaURL: &MetaURL{Hostname: "www.bbc.co.uk"}},
+	}}
+
+	tests := []struct {
+		name     string
+		query    *Query
+		expected string
+		ok       bool
+	}{
+		{"domain", &Query{Original: "github", IsNavigational: true}, "https://github.com/", true},
+		{"domain with spaces", &Query{Original: "Git Hub", IsNavigational: true}, "https://github.com/", true},
+		{"profile name", &Query{Original: "the new york times", IsNavigational: true}, "https://www.nytimes.com/", true},
+		{"host from URL", &Query{Original: "go.dev", IsNavigational: true}, "https://go.dev/", true},
+		{"altered query", &Query{Original: "githbu", Altered: "github", IsNavigational: true}, "https://github.com/", true},
+		{"site name under a second-level suffix", &Query{Original: "BBC", IsNavigational: true}, "https://www.bbc.co.uk/news", true},
+		{"hostname without www", &Query{Original: "apple.com", IsNavigational: true}, "https://www.apple.com/", true},
+		{"no match", &Query{Original: "gitlab", IsNavigational: true}, "", false},
+		{"site name, not part of one", &Query{Original: "go", IsNavigational: true}, "https://go.dev/", true},
+		{"part of another site name", &Query{Original: "app", IsNavigational: true}, "", false},
+		{"not navigational", &Query{Original: "github"}, "", false},
+		{"no query", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &WebSearchResponse{Query: tt.query, Web: results}
+			target, ok := response.NavigationalTarget()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
+// TestNavigationalTargetSkipsUnsafeURLs tests that only web URLs are returned
+func TestNavigationalTargetSkipsUnsafeURLs(t *testing.T) {
+	response := &WebSearchResponse{
+		Query: &Query{Original: "github", IsNavigational: true},
+		Web: &Search{Results: []SearchResult{
+			{URL: "javascript:alert('github')"},
+			{URL: "https://github.com/"},
+		}},
+	}
+
+	target, ok := response.NavigationalTarget()
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com/", target)
+}
@@ -0,0 +1,257 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewsResult represents a news search result
+type NewsResult struct {
+	Type          string     `json:"type,omitempty"`
+	URL           string     `json:"url"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description,omitempty"`
+	Age           string     `json:"age,omitempty"`
+	PageAge       string     `json:"page_age,omitempty"`
+	PageFetched   string     `json:"page_fetched,omitempty"`
+	Breaking      bool       `json:"breaking,omitempty"`
+	IsLive        bool       `json:"is_live,omitempty"`
+	MetaURL       *MetaURL   `json:"meta_url,omitempty"`
+	Thumbnail     *Thumbnail `json:"thumbnail,omitempty"`
+	ExtraSnippets []string   `json:"extra_snippets,omitempty"`
+
+	// SourceScore is set by the configured SourceRater
+	SourceScore *SourceScore `json:"-"`
+
+	// Flagged is set by the configured URLChecker to the reason the URL was flagged
+	Flagged string `json:"-"`
+
+	// Provenance records the request that produced the result
+	Provenance *Provenance `json:"-"`
+}
+
+// pageAgeLayouts are the timestamp formats of page_age
+var pageAgeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// absoluteAgeLayouts are the date formats used by age for older results
+var absoluteAgeLayouts = []string{
+	"January 2, 2006",
+	"Jan 2, 2006",
+}
+
+// relativeAgePattern matches relative ages such as "3 hours ago" or "an hour ago"
+var relativeAgePattern = regexp.MustCompile(`^(\d+|an?) (second|minute|hour|day|week|month|year)s? ago$`)
+
+// relativeAgeUnits are the durations of relative age units. Months and years
+// are approximate, which is fine for bucketing.
+var relativeAgeUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+	"month":  30 * 24 * time.Hour,
+	"year":   365 * 24 * time.Hour,
+}
+
+// PublishedAt returns when the article was published, parsed from page_age
+// or, failing that, from the human-readable age relative to now. Timestamps
+// without a time zone are taken as UTC.
+func (n *NewsResult) PublishedAt(now time.Time) (time.Time, bool) {
+	for _, layout := range pageAgeLayouts {
+		if t, err := time.Parse(layout, n.PageAge); err == nil {
+			return t, true
+		}
+	}
+	return ParseAge(n.Age, now)
+}
+
+// ParseAge parses an age as shown by the API, either relative ("3 hours
+// ago", "a day ago") or absolute ("January 2, 2006"), into a point in time
+func ParseAge(age string, now time.Time) (time.Time, bool) {
+	age = strings.ToLower(strings.TrimSpace(age))
+
+	if m := relativeAgePattern.FindStringSubmatch(age); m != nil {
+		count := 1
+		if m[1] != "a" && m[1] != "an" {
+			n, err := strconv.Atoi(m[1])
+			if err != nil {
+				return time.Time{}, false
+			}
+			count = n
+		}
+		return now.Add(-time.Duration(count) * relativeAgeUnits[m[2]]), true
+	}
+
+	for _, layout := range absoluteAgeLayouts {
+		if t, err := time.Parse(layout, age); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NewsBucket is a freshness bucket of a news timeline
+type NewsBucket string
+
+// News timeline buckets, from newest to oldest
+const (
+	NewsBucketLastHour NewsBucket = "last_hour"
+	NewsBucketLastDay  NewsBucket = "last_day"
+	NewsBucketLastWeek NewsBucket = "last_week"
+	NewsBucketOlder    NewsBucket = "older"
+
+	// NewsBucketUnknown holds results whose age could not be parsed
+	NewsBucketUnknown NewsBucket = "unknown"
+)
+
+// newsBuckets are the timeline buckets in order, with their maximum ages
+var newsBuckets = []struct {
+	bucket NewsBucket
+	maxAge time.Duration
+}{
+	{NewsBucketLastHour, time.Hour},
+	{NewsBucketLastDay, 24 * time.Hour},
+	{NewsBucketLastWeek, 7 * 24 * time.Hour},
+}
+
+// NewsTimeline groups news results into freshness buckets
+type NewsTimeline struct {
+	// Buckets are in order from newest to oldest, followed by
+	// NewsBucketUnknown. Every bucket is present, even if empty.
+	Buckets []NewsTimelineBucket
+}
+
+// NewsTimelineBucket is a freshness bucket and the results in it
+type NewsTimelineBucket struct {
+	Bucket NewsBucket
+
+	// Results are sorted newest first; unknown ages keep the API order
+	Results []TimedNewsResult
+}
+
+// TimedNewsResult is a news result with its parsed publication time
+type TimedNewsResult struct {
+	NewsResult
+
+	// PublishedAt is zero if the age could not be parsed
+	PublishedAt time.Time
+}
+
+// Bucket returns the results in bucket
+func (t *NewsTimeline) Bucket(bucket NewsBucket) []TimedNewsResult {
+	for _, b := range t.Buckets {
+		if b.Bucket == bucket {
+			return b.Results
+		}
+	}
+	return nil
+}
+
+// Timeline groups the news results into freshness buckets relative to now
+func (n *News) Timeline(now time.Time) *NewsTimeline {
+	timeline := &NewsTimeline{}
+	for _, b := range newsBuckets {
+		timeline.Buckets = append(timeline.Buckets, NewsTimelineBucket{Bucket: b.bucket})
+	}
+	timeline.Buckets = append(timeline.Buckets,
+		NewsTimelineBucket{Bucket: NewsBucketOlder},
+		NewsTimelineBucket{Bucket: NewsBucketUnknown},
+	)
+
+	if n == nil {
+		return timeline
+	}
+
+	for _, result := range n.Results {
+		publishedAt, ok := result.PublishedAt(now)
+		index := newsBucketIndex(now.Sub(publishedAt), ok)
+		timeline.Buckets[index].Results = append(timeline.Buckets[index].Results, TimedNewsResult{
+			NewsResult:  result,
+			PublishedAt: publishedAt,
+		})
+	}
+
+	for i := range timeline.Buckets {
+		results := timeline.Buckets[i].Results
+		sort.SliceStable(results, func(a, b int) bool {
+			return results[a].PublishedAt.After(results[b].PublishedAt)
+		})
+	}
+
+	return timeline
+}
+
+// newsBucketIndex returns the index of the timeline bucket for a result of the given age
+func newsBucketIndex(age time.Duration, known bool) int {
+	if !known {
+		return len(newsBuckets) + 1
+	}
+	for i, b := range newsBuckets {
+		if age <= b.maxAge {
+			return i
+		}
+	}
+	return len(newsBuckets)
+}
+
+// breakingNewsClusterSize is how many news results from the last hour make a story breaking
+const breakingNewsClusterSize = 3
+
+// IsBreakingNews reports whether the query is about breaking news: the API
+// flags it as such, a news result is marked breaking, or a cluster of news
+// results was published within the last hour
+func (r *WebSearchResponse) IsBreakingNews() bool {
+	if r.Query != nil && r.Query.IsNewsBreaking {
+		return true
+	}
+	if r.News == nil {
+		return false
+	}
+
+	now := time.Now()
+	recent := 0
+	for _, result := range r.News.Results {
+		if result.Breaking {
+			return true
+		}
+		if publishedAt, ok := result.PublishedAt(now); ok && now.Sub(publishedAt) <= time.Hour {
+			recent++
+		}
+	}
+	return recent >= breakingNewsClusterSize
+}
+
+// escalateBreakingNews replaces the news results of response with the news of
+// the past day. Failures are reported as warnings and leave response as is.
+func (c *Client) escalateBreakingNews(ctx context.Context, params *WebSearchParams, header http.Header, response *WebSearchResponse) {
+	newsParams := *params
+	newsParams.Freshness = FreshnessDay
+	newsParams.ResultFilter = ResultFilterNews
+	newsParams.Offset = 0
+
+	requestURL, err := c.buildRequestURL(EndpointWebSearch, &newsParams)
+	if err == nil {
+		var newsResponse WebSearchResponse
+		if err = c.makeRequest(ctx, http.MethodGet, requestURL, header, nil, &newsResponse); err == nil {
+			if newsResponse.News != nil {
+				response.News = newsResponse.News
+			}
+			return
+		}
+	}
+
+	c.warn(Warning{
+		Code:    WarningCodeEscalationFailed,
+		Message: "failed to fetch the latest news for a breaking news query: " + telemetryError(err).Error(),
+	})
+}
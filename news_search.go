@@ -0,0 +1,96 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// NewsSearchParams holds the parameters for a news search request
+type NewsSearchParams struct {
+	Country    string `url:"country,omitempty"`
+	SearchLang string `url:"search_lang,omitempty"`
+	UILang     string `url:"ui_lang,omitempty"`
+
+	// Count is the number of articles, at most MaxNewsCount
+	Count int `url:"count,omitempty"`
+
+	// Offset is the page of articles to return, at most MaxOffset
+	Offset int `url:"offset,omitempty"`
+
+	SafeSearch string `url:"safesearch,omitempty"`
+
+	// Freshness limits how recently articles were discovered, e.g.
+	// FreshnessDay or a range from FreshnessRange
+	Freshness string `url:"freshness,omitempty"`
+
+	Spellcheck    *bool `url:"spellcheck,omitempty"` // nil leaves the API default; see Bool
+	ExtraSnippets bool  `url:"extra_snippets,omitempty"`
+
+	// Extra holds query parameters the library does not model yet. Keys
+	// managed by the library are rejected with ErrInvalidParameters.
+	Extra url.Values `url:"-"`
+}
+
+// NewsSearchResponse represents the response from the News Search API
+type NewsSearchResponse struct {
+	Type    string       `json:"type"`
+	Query   *Query       `json:"query,omitempty"`
+	Results []NewsResult `json:"results"`
+}
+
+// Breaking returns the breaking news articles, in order
+func (r *NewsSearchResponse) Breaking() []NewsResult {
+	var breaking []NewsResult
+	for _, result := range r.Results {
+		if result.Breaking {
+			breaking = append(breaking, result)
+		}
+	}
+	return breaking
+}
+
+// Source returns the hostname of the publisher of the article
+func (n *NewsResult) Source() string {
+	var metaHostname string
+	if n.MetaURL != nil {
+		metaHostname = n.MetaURL.Hostname
+	}
+	return hostutil.Of(metaHostname, n.URL)
+}
+
+// NewsSearch searches news articles for query with the News Search API,
+// which returns more articles than the news section of a web search. The
+// configured SourceRater and URLChecker apply to the articles as they do
+// to web search results.
+func (c *Client) NewsSearch(ctx context.Context, query string, params *NewsSearchParams) (*NewsSearchResponse, error) {
+	newsParams := &NewsSearchParams{}
+	if params != nil {
+		*newsParams = *params
+	}
+
+	var response NewsSearchResponse
+	query, err := c.verticalSearch(ctx, query, verticalRequest{
+		endpoint:     EndpointNewsSearch,
+		params:       newsParams,
+		extra:        newsParams.Extra,
+		country:      &newsParams.Country,
+		searchLang:   &newsParams.SearchLang,
+		uiLang:       &newsParams.UILang,
+		count:        &newsParams.Count,
+		defaultCount: DefaultNewsCount,
+		maxCount:     MaxNewsCount,
+		offset:       &newsParams.Offset,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Annotate the articles as the news section of a web search
+	view := &WebSearchResponse{News: &News{Results: response.Results}}
+	c.annotateVertical(ctx, view, query, newsParams.Offset, newsParams.Count)
+	response.Results = view.News.Results
+
+	return &response, nil
+}
@@ -0,0 +1,97 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewsSearch tests the News Search API
+func TestNewsSearch(t *testing.T) {
+	server, query := setupVerticalSearchServer(t, EndpointNewsSearch, "news_search_response.json")
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	response, err := client.NewsSearch(context.Background(), "go release", &NewsSearchParams{
+		Country:   "uk",
+		Count:     30,
+		Offset:    1,
+		Freshness: FreshnessWeek,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "news", response.Type)
+	require.NotNil(t, response.Query)
+	assert.Equal(t, "go release", response.Query.Original)
+	require.Len(t, response.Results, 2)
+
+	article := response.Results[0]
+	assert.Equal(t, "Go 1.24 is released", article.Title)
+	assert.True(t, article.Breaking)
+	assert.Equal(t, "2 hours ago", article.Age)
+	assert.Equal(t, "go.dev", article.Source())
+	assert.Equal(t, "news.example.com", response.Results[1].Source())
+	assert.Equal(t, []string{"Swiss tables power the new map implementation."}, response.Results[1].ExtraSnippets)
+
+	require.NotNil(t, article.Provenance)
+	assert.Equal(t, "go release", article.Provenance.Query)
+	assert.Equal(t, 1, article.Provenance.Offset)
+
+	breaking := response.Breaking()
+	require.Len(t, breaking, 1)
+	assert.Equal(t, article.URL, breaking[0].URL)
+
+	assert.Equal(t, "go release", query.Get("q"))
+	assert.Equal(t, "GB", query.Get("country"))
+	assert.Equal(t, DefaultSearchLang, query.Get("search_lang"))
+	assert.Equal(t, DefaultUILang, query.Get("ui_lang"))
+	assert.Equal(t, "30", query.Get("count"))
+	assert.Equal(t, "1", query.Get("offset"))
+	assert.Equal(t, FreshnessWeek, query.Get("freshness"))
+}
+
+// TestNewsSearchAnnotations tests rating and checking articles like web results
+func TestNewsSearchAnnotations(t *testing.T) {
+	server, _ := setupVerticalSearchServer(t, EndpointNewsSearch, "news_search_response.json")
+
+	rater := SourceRaterFunc(func(hostname string) *SourceScore {
+		if hostname == "go.dev" {
+			return &SourceScore{Score: 1, Label: "official"}
+		}
+		return nil
+	})
+	checker := URLCheckerFunc(func(ctx context.Context, urls []string) (map[string]string, error) {
+		return map[string]string{"https://news.example.com/go-1-24": "malware"}, nil
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"),
+		WithSourceRater(rater), WithURLChecker(checker, URLCheckDrop))
+	require.NoError(t, err)
+
+	response, err := client.NewsSearch(context.Background(), "go release", nil)
+	require.NoError(t, err)
+
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, &SourceScore{Score: 1, Label: "official"}, response.Results[0].SourceScore)
+}
+
+// TestNewsSearchInvalid tests rejecting invalid news search parameters
+func TestNewsSearchInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.NewsSearch(context.Background(), "", nil)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = client.NewsSearch(context.Background(), "go", &NewsSearchParams{Count: MaxNewsCount + 1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewsSearch(context.Background(), "go", &NewsSearchParams{Offset: MaxOffset + 1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewsSearch(context.Background(), "go", &NewsSearchParams{Extra: url.Values{"freshness": {"pd"}}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
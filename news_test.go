@@ -0,0 +1,190 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseAge tests parsing relative and absolute ages
+func TestParseAge(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		age      string
+		expected time.Time
+		ok       bool
+	}{
+		{"5 minutes ago", now.Add(-5 * time.Minute), true},
+		{"an hour ago", now.Add(-time.Hour), true},
+		{"3 hours ago", now.Add(-3 * time.Hour), true},
+		{"a day ago", now.Add(-24 * time.Hour), true},
+		{"2 Weeks ago", now.Add(-14 * 24 * time.Hour), true},
+		{"March 3, 2024", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), true},
+		{"Mar 3, 2024", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"recently", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age, func(t *testing.T) {
+			parsed, ok := ParseAge(tt.age, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.expected.Equal(parsed), "got %v", parsed)
+		})
+	}
+}
+
+// TestNewsResultPublishedAt tests that page_age takes precedence over age
+func TestNewsResultPublishedAt(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	result := NewsResult{Age: "2 days ago", PageAge: "2024-06-15T10:30:00"}
+	publishedAt, ok := result.PublishedAt(now)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC), publishedAt)
+
+	result = NewsResult{Age: "2 days ago"}
+	publishedAt, ok = result.PublishedAt(now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(-48*time.Hour), publishedAt)
+}
+
+// TestNewsTimeline tests grouping news results into freshness buckets
+func TestNewsTimeline(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	news := &News{Results: []NewsResult{
+		{Title: "older", Age: "March 3, 2024"},
+		{Title: "day", Age: "5 hours ago"},
+		{Title: "hour-old", Age: "50 minutes ago"},
+		{Title: "unknown", Age: "recently"},
+		{Title: "hour-new", PageAge: "2024-06-15T11:55:00"},
+		{Title: "week", Age: "3 days ago"},
+	}}
+
+	timeline := news.Timeline(now)
+
+	var buckets []NewsBucket
+	for _, b := range timeline.Buckets {
+		buckets = append(buckets, b.Bucket)
+	}
+	assert.Equal(t, []NewsBucket{
+		NewsBucketLastHour, NewsBucketLastDay, NewsBucketLastWeek, NewsBucketOlder, NewsBucketUnknown,
+	}, buckets)
+
+	titles := func(bucket NewsBucket) []string {
+		var titles []string
+		for _, result := range timeline.Bucket(bucket) {
+			titles = append(titles, result.Title)
+		}
+		return titles
+	}
+	assert.Equal(t, []string{"hour-new", "hour-old"}, titles(NewsBucketLastHour))
+	assert.Equal(t, []string{"day"}, titles(NewsBucketLastDay))
+	assert.Equal(t, []string{"week"}, titles(NewsBucketLastWeek))
+	assert.Equal(t, []string{"older"}, titles(NewsBucketOlder))
+	assert.Equal(t, []string{"unknown"}, titles(NewsBucketUnknown))
+
+	assert.True(t, timeline.Bucket(NewsBucketUnknown)[0].PublishedAt.IsZero())
+}
+
+// TestNewsTimelineNil tests the timeline of missing news results
+func TestNewsTimelineNil(t *testing.T) {
+	var news *News
+	timeline := news.Timeline(time.Now())
+	assert.Len(t, timeline.Buckets, 5)
+	assert.Empty(t, timeline.Bucket(NewsBucketLastHour))
+}
+
+// TestIsBreakingNews tests breaking news detection
+func TestIsBreakingNews(t *testing.T) {
+	tests := []struct {
+		name     string
+		response WebSearchResponse
+		expected bool
+	}{
+		{"no news", WebSearchResponse{}, false},
+		{"flagged query", WebSearchResponse{Query: &Query{IsNewsBreaking: true}}, true},
+		{"breaking result", WebSearchResponse{News: &News{Results: []NewsResult{{Breaking: true}}}}, true},
+		{"recent cluster", WebSearchResponse{News: &News{Results: []NewsResult{
+			{Age: "5 minutes ago"}, {Age: "20 minutes ago"}, {Age: "an hour ago"},
+		}}}, true},
+		{"old news", WebSearchResponse{News: &News{Results: []NewsResult{
+			{Age: "5 minutes ago"}, {Age: "2 hours ago"}, {Age: "3 days ago"},
+		}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.response.IsBreakingNews())
+		})
+	}
+}
+
+// TestBreakingNewsEscalation tests fetching the latest news for breaking news queries
+func TestBreakingNewsEscalation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("freshness") == FreshnessDay {
+			assert.Equal(t, ResultFilterNews, r.URL.Query().Get("result_filter"))
+			_, _ = w.Write([]byte(`{"type": "search", "news": {"type": "news", "results": [{"title": "latest"}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"type": "search", "query": {"original": "quake", "is_news_breaking": true},
+			"web": {"type": "search", "results": [{"title": "web"}]},
+			"news": {"type": "news", "results": [{"title": "stale"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithBreakingNewsEscalation(true))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "quake", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, "web", response.Web.Results[0].Title)
+	assert.Equal(t, "latest", response.News.Results[0].Title)
+
+	// An explicit freshness is respected
+	requests.Store(0)
+	_, err = client.WebSearch(context.Background(), "quake", &WebSearchParams{Freshness: FreshnessWeek})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// TestBreakingNewsEscalationFailure tests that a failed follow-up keeps the original response
+func TestBreakingNewsEscalationFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("freshness") == FreshnessDay {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search", "news": {"type": "news", "results": [{"title": "flash", "breaking": true}]}}`))
+	}))
+	defer server.Close()
+
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithBreakingNewsEscalation(true),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "quake", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "flash", response.News.Results[0].Title)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodeEscalationFailed, warnings[0].Code)
+	assert.NotContains(t, warnings[0].Message, "quake")
+}
@@ -0,0 +1,132 @@
+package bravesearch
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OfflineBaseURL is the base URL used by clients created with NewOfflineClient
+const OfflineBaseURL = "http://offline.invalid"
+
+// OfflineDefaultFixture is the fixture served when no pattern matches the query
+const OfflineDefaultFixture = "default.json"
+
+// NewOfflineClient creates a client that never touches the network. Every
+// request is answered with a canned JSON response read from fixtures.
+//
+// Fixture files are named after the query they answer, as a path.Match
+// pattern followed by ".json" (e.g. "golang*.json" or "brave search.json").
+// Queries are matched case-insensitively: an exact name wins, then the first
+// matching pattern in lexical order, then OfflineDefaultFixture. Fixtures are
+// looked up in a directory named after the endpoint (e.g. "web/search")
+// before the root of fixtures. Requests without a matching fixture fail with
+// a 404 API error.
+//
+// Options are applied as for NewClient, except that the base URL and HTTP
+// client are always replaced by offline ones.
+func NewOfflineClient(fixtures fs.FS, options ...ClientOption) (*Client, error) {
+	if fixtures == nil {
+		return nil, ErrInvalidParameters
+	}
+
+	offline := []ClientOption{
+		WithBaseURL(OfflineBaseURL),
+		WithHTTPClient(&http.Client{Transport: &offlineTransport{fixtures: fixtures}}),
+	}
+	return NewClient("offline", append(options, offline...)...)
+}
+
+// offlineTransport is an http.RoundTripper serving responses from fixtures
+type offlineTransport struct {
+	fixtures fs.FS
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(req.URL.Query().Get("q"))
+	endpoint := strings.Trim(req.URL.Path, "/")
+
+	for _, dir := range []string{endpoint, "."} {
+		name, err := matchFixture(t.fixtures, dir, query)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+
+		data, err := fs.ReadFile(t.fixtures, name)
+		if err != nil {
+			return nil, err
+		}
+		return offlineResponse(req, http.StatusOK, data), nil
+	}
+
+	return offlineResponse(req, http.StatusNotFound, []byte(`{"error": "no offline fixture matches the query"}`)), nil
+}
+
+// matchFixture returns the fixture in dir that answers query, or "" if none does
+func matchFixture(fixtures fs.FS, dir, query string) (string, error) {
+	entries, err := fs.ReadDir(fixtures, dir)
+	if err != nil {
+		if dir != "." {
+			// Endpoint directories are optional
+			return "", nil
+		}
+		return "", err
+	}
+
+	var patterns []string
+	var fallback string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		pattern := strings.ToLower(strings.TrimSuffix(name, ".json"))
+		if pattern == query {
+			return path.Join(dir, name), nil
+		}
+		if name == OfflineDefaultFixture {
+			fallback = path.Join(dir, name)
+			continue
+		}
+		patterns = append(patterns, name)
+	}
+
+	sort.Strings(patterns)
+	for _, name := range patterns {
+		pattern := strings.ToLower(strings.TrimSuffix(name, ".json"))
+		if ok, _ := path.Match(pattern, query); ok {
+			return path.Join(dir, name), nil
+		}
+	}
+
+	return fallback, nil
+}
+
+// offlineResponse builds a JSON response for req
+func offlineResponse(req *http.Request, statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{MIMETypeJSON}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
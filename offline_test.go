@@ -0,0 +1,75 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewOfflineClient tests serving canned responses without network access
+func TestNewOfflineClient(t *testing.T) {
+	data, err := os.ReadFile("testdata/web_search_response.json")
+	require.NoError(t, err)
+
+	fixtures := fstest.MapFS{
+		"go programming.json":    {Data: data},
+		"go*.json":               {Data: []byte(`{"type": "search", "web": {"results": [{"title": "Go pattern"}]}}`)},
+		"default.json":           {Data: []byte(`{"type": "search", "web": {"results": []}}`)},
+		"web/search/brave*.json": {Data: []byte(`{"type": "search", "web": {"results": [{"title": "Brave endpoint"}]}}`)},
+		"web/search/ignored.txt": {Data: []byte(`not a fixture`)},
+		"images/search/go*.json": {Data: []byte(`{"type": "images"}`)},
+	}
+
+	// Options apply, but the base URL is always the offline one
+	client, err := NewOfflineClient(fixtures, WithBaseURL("https://api.search.brave.com/res/v1"))
+	require.NoError(t, err)
+	assert.Equal(t, OfflineBaseURL, client.config.BaseURL)
+
+	ctx := context.Background()
+
+	// Exact match (case-insensitive)
+	response, err := client.WebSearch(ctx, "Go Programming", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.GetResultCount())
+
+	// Pattern match
+	response, err = client.WebSearch(ctx, "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Go pattern", response.GetFirstResult().Title)
+
+	// Endpoint directory takes precedence over the root
+	response, err = client.WebSearch(ctx, "brave browser", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Brave endpoint", response.GetFirstResult().Title)
+
+	// Default fixture
+	response, err = client.WebSearch(ctx, "something else", nil)
+	require.NoError(t, err)
+	assert.True(t, response.IsWebResultEmpty())
+}
+
+// TestNewOfflineClientNoMatch tests the error returned when no fixture matches
+func TestNewOfflineClientNoMatch(t *testing.T) {
+	client, err := NewOfflineClient(fstest.MapFS{
+		"go*.json": {Data: []byte(`{"type": "search"}`)},
+	})
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "rust", nil)
+	assert.Nil(t, response)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, ErrNotFound, apiErr.Err)
+
+	// A nil file system is rejected
+	client, err = NewOfflineClient(nil)
+	assert.Nil(t, client)
+	assert.Equal(t, ErrInvalidParameters, err)
+}
@@ -1,7 +1,9 @@
 package bravesearch
 
 import (
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -27,6 +29,18 @@ func WithRetries(retries int) ClientOption {
 	}
 }
 
+// WithRetryBudget limits the retries of all requests made by the client, and
+// by any other client sharing budget, to the budget's rate
+func WithRetryBudget(budget *RetryBudget) ClientOption {
+	return func(c *ClientConfig) error {
+		if budget == nil {
+			return ErrInvalidParameters
+		}
+		c.RetryBudget = budget
+		return nil
+	}
+}
+
 // WithUserAgent sets the User-Agent header for requests
 func WithUserAgent(userAgent string) ClientOption {
 	return func(c *ClientConfig) error {
@@ -35,6 +49,33 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithAppInfo identifies the calling application in the User-Agent header.
+// The resulting header names both the application and this library, e.g.
+// "myapp/2.1 go-brave-search/0.1.0", and takes precedence over WithUserAgent.
+// The version may be empty.
+func WithAppInfo(name, version string) ClientOption {
+	return func(c *ClientConfig) error {
+		if !isUserAgentToken(name) || (version != "" && !isUserAgentToken(version)) {
+			return ErrInvalidParameters
+		}
+		c.AppName = name
+		c.AppVersion = version
+		return nil
+	}
+}
+
+// WithAppContact adds contact information (a URL or email address) to the
+// User-Agent header, e.g. "myapp/2.1 go-brave-search/0.1.0 (+https://example.com)"
+func WithAppContact(contact string) ClientOption {
+	return func(c *ClientConfig) error {
+		if strings.ContainsAny(contact, "()\r\n") {
+			return ErrInvalidParameters
+		}
+		c.AppContact = strings.TrimSpace(contact)
+		return nil
+	}
+}
+
 // WithBaseURL sets the base URL for the API
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *ClientConfig) error {
@@ -43,6 +84,23 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// WithEndpointOverride sets the path of an endpoint, for plans that serve
+// it elsewhere than the standard path under the base URL. path is either a
+// path under the base URL (e.g. "/web/search") or an absolute URL to use a
+// different host.
+func WithEndpointOverride(endpoint Endpoint, path string) ClientOption {
+	return func(c *ClientConfig) error {
+		if !validEndpointOverride(endpoint, path) {
+			return ErrInvalidParameters
+		}
+		if c.EndpointOverrides == nil {
+			c.EndpointOverrides = make(map[Endpoint]string)
+		}
+		c.EndpointOverrides[endpoint] = path
+		return nil
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *ClientConfig) error {
@@ -51,6 +109,356 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithCookieJar lets requests store and send cookies in jar. Clients never
+// keep cookies otherwise, even when the HTTP client of WithHTTPClient has a
+// jar; use this only for controlled scenarios such as a proxy that needs a
+// session cookie, since cookies can link searches to each other.
+func WithCookieJar(jar http.CookieJar) ClientOption {
+	return func(c *ClientConfig) error {
+		if jar == nil {
+			return ErrInvalidParameters
+		}
+		c.CookieJar = jar
+		return nil
+	}
+}
+
+// WithAuthenticator sets how requests are authenticated, replacing the default
+// X-Subscription-Token header. When an Authenticator is set, the API key
+// passed to NewClient may be empty.
+func WithAuthenticator(authenticator Authenticator) ClientOption {
+	return func(c *ClientConfig) error {
+		if authenticator == nil {
+			return ErrInvalidParameters
+		}
+		c.Authenticator = authenticator
+		return nil
+	}
+}
+
+// WithHeaderPolicy adjusts request headers for API gateways: the name of the
+// subscription token header, whether Cache-Control is sent, and mandatory
+// extra headers
+func WithHeaderPolicy(policy HeaderPolicy) ClientOption {
+	return func(c *ClientConfig) error {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		policy.Headers = policy.Headers.Clone()
+		c.HeaderPolicy = policy
+		return nil
+	}
+}
+
+// WithRequestSigner signs every request with signer right before it is sent
+func WithRequestSigner(signer *RequestSigner) ClientOption {
+	return func(c *ClientConfig) error {
+		if signer == nil || len(signer.Secret) == 0 {
+			return ErrInvalidParameters
+		}
+		c.RequestSigner = signer
+		return nil
+	}
+}
+
+// WithLogger enables logging of API requests. Successful requests are logged
+// at debug level, failed ones at warn level. Query text is passed through the
+// configured QueryScrubber before it is logged.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *ClientConfig) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithQueryScrubber sets the scrubber applied to query text before it is
+// emitted as telemetry. The default is DefaultQueryScrubber.
+func WithQueryScrubber(scrubber QueryScrubber) ClientOption {
+	return func(c *ClientConfig) error {
+		if scrubber == nil {
+			return ErrInvalidParameters
+		}
+		c.QueryScrubber = scrubber
+		return nil
+	}
+}
+
+// WithNoRetention guarantees that the client keeps no query text or result
+// data beyond the lifetime of a call. When enabled, query text is dropped
+// from logs and other telemetry entirely (rather than scrubbed), no
+// responses are cached or recorded, and no searches are mirrored to a shadow
+// client.
+func WithNoRetention(noRetention bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.NoRetention = noRetention
+		return nil
+	}
+}
+
+// WithAutoLocation sets a resolver deriving the user location for searches
+// that don't specify one, so results can be localized per end user in
+// multi-tenant backends
+func WithAutoLocation(resolver LocationResolver) ClientOption {
+	return func(c *ClientConfig) error {
+		if resolver == nil {
+			return ErrInvalidParameters
+		}
+		c.LocationResolver = resolver
+		return nil
+	}
+}
+
+// WithQueryLimits overrides the query size limits checked before a request
+// is sent. A zero limit disables that check, leaving it to the API.
+func WithQueryLimits(limits QueryLimits) ClientOption {
+	return func(c *ClientConfig) error {
+		if limits.MaxChars < 0 || limits.MaxWords < 0 {
+			return ErrInvalidParameters
+		}
+		c.QueryLimits = limits
+		return nil
+	}
+}
+
+// WithQueryNormalization sets the Unicode normalization applied to queries
+// before they are sent. The default is QueryNormalizationNFC; use
+// QueryNormalizationNone to opt out.
+func WithQueryNormalization(form QueryNormalization) ClientOption {
+	return func(c *ClientConfig) error {
+		if form < QueryNormalizationNFC || form > QueryNormalizationNone {
+			return ErrInvalidParameters
+		}
+		c.QueryNormalization = form
+		return nil
+	}
+}
+
+// WithAPIVersion pins the API version (a date such as "2023-01-01") sent in
+// the Api-Version header. By default the API serves its latest version.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *ClientConfig) error {
+		if _, err := time.Parse(time.DateOnly, version); err != nil {
+			return ErrInvalidParameters
+		}
+		c.APIVersion = version
+		return nil
+	}
+}
+
+// WithQueryRewriter sets the QueryRewriter ConversationSearch uses to turn
+// conversational messages into standalone queries, instead of the HeuristicRewriter
+func WithQueryRewriter(rewriter QueryRewriter) ClientOption {
+	return func(c *ClientConfig) error {
+		if rewriter == nil {
+			return ErrInvalidParameters
+		}
+		c.QueryRewriter = rewriter
+		return nil
+	}
+}
+
+// WithWarningHandler sets a handler for non-fatal warnings, such as country
+// or language codes that were mapped to the form the API expects
+func WithWarningHandler(handler WarningHandler) ClientOption {
+	return func(c *ClientConfig) error {
+		c.WarningHandler = handler
+		return nil
+	}
+}
+
+// WithStrictCodes makes searches with country or language codes the API is
+// not known to support fail with ErrInvalidParameters, instead of sending
+// them anyway with a warning
+func WithStrictCodes(strict bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.StrictCodes = strict
+		return nil
+	}
+}
+
+// WithCodeTableSource validates country and language codes against the
+// tables loaded from source instead of the bundled ones. The source is loaded
+// on first use and again on each call to Client.RefreshCodeTables. Requests
+// wait for the first load, bounded by the client timeout, so source should
+// be fast or the client warmed up with a first search.
+func WithCodeTableSource(source CodeTableSource) ClientOption {
+	return func(c *ClientConfig) error {
+		if source == nil {
+			return ErrInvalidParameters
+		}
+		c.CodeTableSource = source
+		return nil
+	}
+}
+
+// WithBreakingNewsEscalation makes web searches for breaking news (see
+// WebSearchResponse.IsBreakingNews) fetch the latest news in a follow-up
+// request restricted to the past day, replacing the news results of the
+// response. Searches that already set a freshness are not escalated.
+func WithBreakingNewsEscalation(enabled bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.EscalateBreakingNews = enabled
+		return nil
+	}
+}
+
+// WithSourceRater annotates the web, news and video results of every search
+// with the SourceScore of their source. Results are not rated by default;
+// DefaultSourceRater is a sample implementation.
+func WithSourceRater(rater SourceRater) ClientOption {
+	return func(c *ClientConfig) error {
+		if rater == nil {
+			return ErrInvalidParameters
+		}
+		c.SourceRater = rater
+		return nil
+	}
+}
+
+// WithURLChecker checks the web, news and video results of every search with
+// checker, annotating (URLCheckAnnotate) or dropping (URLCheckDrop) flagged
+// results before they reach end users. If the check fails the results are
+// returned unchecked and a warning is reported.
+func WithURLChecker(checker URLChecker, action URLCheckAction) ClientOption {
+	return func(c *ClientConfig) error {
+		if checker == nil || (action != URLCheckAnnotate && action != URLCheckDrop) {
+			return ErrInvalidParameters
+		}
+		c.URLChecker = checker
+		c.URLCheckAction = action
+		return nil
+	}
+}
+
+// WithPreviewer attaches preview images rendered by previewer to the web
+// results of every search, rendering up to concurrency previews at a time
+// (DefaultPreviewConcurrency if zero). Searches wait for the previews, so
+// previewers should be fast or time out on their own.
+func WithPreviewer(previewer Previewer, concurrency int) ClientOption {
+	return func(c *ClientConfig) error {
+		if previewer == nil || concurrency < 0 {
+			return ErrInvalidParameters
+		}
+		c.Previewer = previewer
+		c.PreviewConcurrency = concurrency
+		return nil
+	}
+}
+
+// WithSoftFail makes web searches return the sections of a response that
+// could be decoded, listing the others in DecodeWarnings, instead of failing
+// when one section (such as videos) does not match the expected format
+func WithSoftFail(softFail bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.SoftFail = softFail
+		return nil
+	}
+}
+
+// WithAdaptiveCompression stops requesting gzip for endpoints whose
+// responses are so small that decompressing them costs more than the
+// transfer time gzip saves, such as suggestions. stats, as returned by
+// Client.CompressionStats, seed the decisions so they survive restarts;
+// pass nil to start from scratch.
+func WithAdaptiveCompression(stats []CompressionStat) ClientOption {
+	return func(c *ClientConfig) error {
+		c.AdaptiveCompression = true
+		c.CompressionStats = stats
+		return nil
+	}
+}
+
+// WithStableResults makes web searches return stabilized responses (see
+// WebSearchResponse.Stabilized), with results in a deterministic order and
+// volatile fields cleared, so snapshot-based tests and diffs aren't noisy
+func WithStableResults(enabled bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.StableResults = enabled
+		return nil
+	}
+}
+
+// WithEmptyResultsError makes web searches that find nothing return an
+// *EmptyResultsError instead of an empty response, so callers can branch on
+// errors.Is(err, ErrNoResults) without probing every result section
+func WithEmptyResultsError(enabled bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.EmptyResultsError = enabled
+		return nil
+	}
+}
+
+// WithRateLimitStore throttles requests to requestsPerSecond (the per-second
+// limit of the API plan) through a RateLimitStore shared with other clients
+// using the same API key, and shares the rate limits the API reports with
+// them. Every attempt, including retries, takes a slot.
+func WithRateLimitStore(store RateLimitStore, requestsPerSecond int) ClientOption {
+	return func(c *ClientConfig) error {
+		if store == nil || requestsPerSecond < 1 {
+			return ErrInvalidParameters
+		}
+		c.RateLimitStore = store
+		c.RateLimitPerSecond = requestsPerSecond
+		return nil
+	}
+}
+
+// WithFeedbackStore records the result feedback of RecordFeedback in store,
+// e.g. one shared by all instances of a service, instead of in memory
+func WithFeedbackStore(store FeedbackStore) ClientOption {
+	return func(c *ClientConfig) error {
+		if store == nil {
+			return ErrInvalidParameters
+		}
+		c.FeedbackStore = store
+		return nil
+	}
+}
+
+// WithShadowClient mirrors the web searches the sampler picks to other, e.g.
+// a client with another API version or other defaults, to validate a
+// configuration change on live traffic. Shadow searches run in the
+// background once the primary search succeeded and never affect its
+// response; ShadowReport compares their results and latency. Call
+// WaitShadow to let the searches in flight complete before reading the final
+// report or shutting down. Shadow searches time out with the client's timeout
+// and are not mirrored in no-retention mode (see WithNoRetention).
+func WithShadowClient(other Searcher, sampler ShadowSampler) ClientOption {
+	return func(c *ClientConfig) error {
+		if other == nil || sampler == nil {
+			return ErrInvalidParameters
+		}
+		c.ShadowSearcher = other
+		c.ShadowSampler = sampler
+		return nil
+	}
+}
+
+// WithAuditLog records every API request (who searched what, when, with
+// which parameters and the outcome) with the Auditor. Use NewJSONAuditor to
+// write JSON lines to an io.Writer. Query text is recorded unscrubbed, except
+// in no-retention mode where it is left out.
+func WithAuditLog(auditor Auditor) ClientOption {
+	return func(c *ClientConfig) error {
+		if auditor == nil {
+			return ErrInvalidParameters
+		}
+		c.Auditor = auditor
+		return nil
+	}
+}
+
+// WithNoDefaults stops the client from filling in unset parameters (country,
+// languages, count and SafeSearch) with its defaults, so requests carry only
+// what the caller set and the API's own defaults apply, as in the web UI.
+// The WithDefault* options have no effect while it is enabled.
+func WithNoDefaults(noDefaults bool) ClientOption {
+	return func(c *ClientConfig) error {
+		c.NoDefaults = noDefaults
+		return nil
+	}
+}
+
 // WithDefaultCountry sets the default country for requests
 func WithDefaultCountry(country string) ClientOption {
 	return func(c *ClientConfig) error {
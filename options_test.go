@@ -63,6 +63,52 @@ func TestWithUserAgent(t *testing.T) {
 	assert.Equal(t, "", config.UserAgent)
 }
 
+// TestWithAppInfo tests the WithAppInfo option
+func TestWithAppInfo(t *testing.T) {
+	config := &ClientConfig{}
+
+	// Test with name and version
+	err := WithAppInfo("myapp", "2.1")(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "myapp", config.AppName)
+	assert.Equal(t, "2.1", config.AppVersion)
+
+	// Test with name only
+	err = WithAppInfo("otherapp", "")(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "otherapp", config.AppName)
+	assert.Equal(t, "", config.AppVersion)
+
+	// Test with invalid values
+	assert.Equal(t, ErrInvalidParameters, WithAppInfo("", "1.0")(config))
+	assert.Equal(t, ErrInvalidParameters, WithAppInfo("my app", "1.0")(config))
+	assert.Equal(t, ErrInvalidParameters, WithAppInfo("myapp", "1.0/beta")(config))
+}
+
+// TestWithAppContact tests the WithAppContact option
+func TestWithAppContact(t *testing.T) {
+	config := &ClientConfig{}
+
+	err := WithAppContact(" https://example.com/bot ")(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/bot", config.AppContact)
+
+	err = WithAppContact("(evil)")(config)
+	assert.Equal(t, ErrInvalidParameters, err)
+}
+
+// TestWithAPIVersion tests the WithAPIVersion option
+func TestWithAPIVersion(t *testing.T) {
+	config := &ClientConfig{}
+
+	err := WithAPIVersion("2023-01-01")(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-01-01", config.APIVersion)
+
+	err = WithAPIVersion("v1")(config)
+	assert.Equal(t, ErrInvalidParameters, err)
+}
+
 // TestWithBaseURL tests the WithBaseURL option
 func TestWithBaseURL(t *testing.T) {
 	config := &ClientConfig{}
@@ -94,6 +140,20 @@ func TestWithHTTPClient(t *testing.T) {
 	assert.Nil(t, config.HTTPClient)
 }
 
+// TestWithQueryScrubber tests the WithQueryScrubber option
+func TestWithQueryScrubber(t *testing.T) {
+	config := &ClientConfig{}
+	scrubber := DefaultQueryScrubber()
+
+	err := WithQueryScrubber(scrubber)(config)
+	assert.NoError(t, err)
+	assert.Equal(t, scrubber, config.QueryScrubber)
+
+	// Test with nil scrubber
+	err = WithQueryScrubber(nil)(config)
+	assert.Equal(t, ErrInvalidParameters, err)
+}
+
 // TestWithDefaultCountry tests the WithDefaultCountry option
 func TestWithDefaultCountry(t *testing.T) {
 	config := &ClientConfig{}
@@ -0,0 +1,49 @@
+package bravesearch
+
+// Pagination describes the page of results a response holds. The API counts
+// offsets in pages of Count results, up to MaxOffset.
+type Pagination struct {
+	// Offset is the page of the response, starting at 0
+	Offset int
+
+	// Count is the number of results requested per page, or 0 if the API's
+	// default was used (see WithNoDefaults)
+	Count int
+
+	// MoreResults reports whether the API has more results
+	MoreResults bool
+
+	// params are the parameters of the request
+	params WebSearchParams
+}
+
+// Pagination returns the pagination of a response returned by WebSearch.
+// Responses decoded elsewhere are assumed to be the first page with
+// default parameters.
+func (r *WebSearchResponse) Pagination() Pagination {
+	pagination := Pagination{MoreResults: r.HasMoreResults()}
+	if r != nil && r.params != nil {
+		pagination.params = *r.params
+	} else {
+		pagination.params = *NewWebSearchParams()
+	}
+	pagination.Offset = pagination.params.Offset
+	pagination.Count = pagination.params.Count
+	return pagination
+}
+
+// HasNext reports whether there is a following page that can be requested
+func (p Pagination) HasNext() bool {
+	return p.MoreResults && p.Offset < MaxOffset
+}
+
+// NextParams returns the parameters requesting the following page, or nil
+// if there is none
+func (p Pagination) NextParams() *WebSearchParams {
+	if !p.HasNext() {
+		return nil
+	}
+	params := p.params
+	params.Offset++
+	return &params
+}
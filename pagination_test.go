@@ -0,0 +1,62 @@
+package bravesearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPagination tests paging through results with NextParams
+func TestPagination(t *testing.T) {
+	pages := [][]string{{"https://a.example/"}, {"https://b.example/"}, {"https://c.example/"}}
+
+	server := newMockServer(t, pagedResponse(pages))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	params := &WebSearchParams{Count: MaxCount, Country: "JP"}
+	var urls []string
+	for params != nil {
+		response, err := client.WebSearch(context.Background(), "golang", params)
+		require.NoError(t, err)
+		urls = append(urls, resultURLs(response)...)
+
+		pagination := response.Pagination()
+		assert.Equal(t, params.Offset, pagination.Offset)
+		assert.Equal(t, MaxCount, pagination.Count)
+		params = pagination.NextParams()
+		if params != nil {
+			assert.Equal(t, "JP", params.Country)
+		}
+	}
+
+	assert.Equal(t, []string{"https://a.example/", "https://b.example/", "https://c.example/"}, urls)
+	assert.Equal(t, []int{0, 1, 2}, requestedOffsets(t, server))
+}
+
+// TestPaginationLimits tests the last page and decoded responses
+func TestPaginationLimits(t *testing.T) {
+	last := &WebSearchResponse{
+		Query:  &Query{MoreResultsAvailable: true},
+		params: &WebSearchParams{Count: 10, Offset: MaxOffset},
+	}
+	pagination := last.Pagination()
+	assert.True(t, pagination.MoreResults)
+	assert.False(t, pagination.HasNext())
+	assert.Nil(t, pagination.NextParams())
+
+	decoded := &WebSearchResponse{Query: &Query{MoreResultsAvailable: true}}
+	pagination = decoded.Pagination()
+	assert.Equal(t, 0, pagination.Offset)
+	assert.Equal(t, DefaultCount, pagination.Count)
+	next := pagination.NextParams()
+	require.NotNil(t, next)
+	assert.Equal(t, 1, next.Offset)
+	assert.Equal(t, DefaultCount, next.Count)
+
+	assert.Nil(t, (*WebSearchResponse)(nil).Pagination().NextParams())
+	assert.Nil(t, (&WebSearchResponse{}).Pagination().NextParams())
+}
@@ -0,0 +1,145 @@
+package bravesearch
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// encodeParams encodes the fields of a parameter struct as query values
+// according to their url struct tags, so new parameters only need a tagged
+// field. A tag names the parameter and takes the options "omitempty" (skip
+// zero values; nil pointers are always skipped) and "int" (encode booleans
+// as 1 or 0). Fields tagged "-" or without a url tag are not encoded.
+// Supported field types are strings, booleans, integers, floats, pointers
+// to these and string slices, which are encoded as repeated parameters.
+func encodeParams(params any) (url.Values, error) {
+	values := url.Values{}
+
+	v := reflect.ValueOf(params)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: cannot encode %s as query parameters", ErrInvalidParameters, v.Type())
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("url")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			continue
+		}
+		omitEmpty := hasTagOption(options, "omitempty")
+		asInt := hasTagOption(options, "int")
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		} else if omitEmpty && value.IsZero() {
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			values.Add(name, value.String())
+		case reflect.Bool:
+			switch {
+			case asInt && value.Bool():
+				values.Add(name, "1")
+			case asInt:
+				values.Add(name, "0")
+			default:
+				values.Add(name, strconv.FormatBool(value.Bool()))
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values.Add(name, strconv.FormatInt(value.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			values.Add(name, strconv.FormatUint(value.Uint(), 10))
+		case reflect.Float32, reflect.Float64:
+			values.Add(name, strconv.FormatFloat(value.Float(), 'f', -1, 64))
+		case reflect.Slice:
+			if value.Type().Elem().Kind() != reflect.String {
+				return nil, fmt.Errorf("%w: cannot encode field %s of type %s", ErrInvalidParameters, field.Name, field.Type)
+			}
+			for j := 0; j < value.Len(); j++ {
+				values.Add(name, value.Index(j).String())
+			}
+		default:
+			return nil, fmt.Errorf("%w: cannot encode field %s of type %s", ErrInvalidParameters, field.Name, field.Type)
+		}
+	}
+
+	return values, nil
+}
+
+// hasTagOption reports whether the comma-separated tag options include option
+func hasTagOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeExtra adds the extra parameters to values. Extra parameters are meant
+// for parameters the library does not model yet, so a key that is already
+// set or that names a field of params is rejected rather than silently
+// overriding (or being overridden by) the library.
+func mergeExtra(values, extra url.Values, params any) error {
+	if len(extra) == 0 {
+		return nil
+	}
+
+	modeled := paramNames(params)
+	for key, extraValues := range extra {
+		if key == "" {
+			return fmt.Errorf("%w: extra parameter without a name", ErrInvalidParameters)
+		}
+		if modeled[key] || values.Has(key) {
+			return fmt.Errorf("%w: extra parameter %q is managed by the library", ErrInvalidParameters, key)
+		}
+		for _, value := range extraValues {
+			values.Add(key, value)
+		}
+	}
+
+	return nil
+}
+
+// paramNames returns the names of the parameters the url tags of params define
+func paramNames(params any) map[string]bool {
+	t := reflect.TypeOf(params)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	names := make(map[string]bool)
+	if t == nil || t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("url"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
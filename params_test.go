@@ -0,0 +1,109 @@
+package bravesearch
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncodeParams tests encoding parameter structs by their url tags
+func TestEncodeParams(t *testing.T) {
+	type params struct {
+		Name     string   `url:"name"`
+		Empty    string   `url:"empty,omitempty"`
+		Blank    string   `url:"blank"`
+		Count    int      `url:"count,omitempty"`
+		Limit    uint     `url:"limit,omitempty"`
+		Ratio    float64  `url:"ratio,omitempty"`
+		Flag     bool     `url:"flag,omitempty"`
+		Callback bool     `url:"callback,omitempty,int"`
+		Zero     bool     `url:"zero,int"`
+		Optional *bool    `url:"optional,omitempty"`
+		Unset    *bool    `url:"unset,omitempty"`
+		Tags     []string `url:"tag,omitempty"`
+		Skipped  string   `url:"-"`
+		Untagged string
+		private  string `url:"private"`
+	}
+
+	values, err := encodeParams(&params{
+		Name:     "go",
+		Count:    5,
+		Limit:    7,
+		Ratio:    0.5,
+		Flag:     true,
+		Callback: true,
+		Optional: Bool(false),
+		Tags:     []string{"a", "b"},
+		Skipped:  "x",
+		Untagged: "y",
+		private:  "z",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"name":     {"go"},
+		"blank":    {""},
+		"count":    {"5"},
+		"limit":    {"7"},
+		"ratio":    {"0.5"},
+		"flag":     {"true"},
+		"callback": {"1"},
+		"zero":     {"0"},
+		"optional": {"false"},
+		"tag":      {"a", "b"},
+	}, values)
+
+	values, err = encodeParams((*params)(nil))
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+// TestEncodeParamsUnsupported tests rejecting values that cannot be encoded
+func TestEncodeParamsUnsupported(t *testing.T) {
+	_, err := encodeParams("query")
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = encodeParams(struct {
+		Location map[string]string `url:"location"`
+	}{Location: map[string]string{"a": "b"}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = encodeParams(struct {
+		IDs []int `url:"id"`
+	}{IDs: []int{1}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// TestEncodeWebSearchParams tests that every tagged web search parameter is encoded
+func TestEncodeWebSearchParams(t *testing.T) {
+	values, err := encodeParams(&WebSearchParams{
+		Query:              "golang",
+		EnableRichCallback: true,
+		Location:           &Location{City: "Tokyo"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"q": {"golang"}, "enable_rich_callback": {"1"}}, values)
+}
+
+// TestMergeExtra tests adding extra parameters the library does not model
+func TestMergeExtra(t *testing.T) {
+	params := &WebSearchParams{Query: "golang", Count: 5}
+	values, err := encodeParams(params)
+	require.NoError(t, err)
+
+	require.NoError(t, mergeExtra(values, url.Values{"new_feature": {"a", "b"}}, params))
+	assert.Equal(t, []string{"a", "b"}, values["new_feature"])
+	assert.Equal(t, "5", values.Get("count"))
+
+	// Modeled parameters are rejected, whether set or not
+	for _, key := range []string{"count", "freshness", "q", ""} {
+		err := mergeExtra(url.Values{}, url.Values{key: {"x"}}, params)
+		assert.ErrorIs(t, err, ErrInvalidParameters, key)
+	}
+
+	// Parameters already set by the library are rejected
+	err = mergeExtra(url.Values{"q": {"golang"}}, url.Values{"q": {"x"}}, &SuggestParams{})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
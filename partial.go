@@ -0,0 +1,95 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DecodeWarning reports a section of a response that could not be decoded
+// in soft-fail mode (see WithSoftFail). The section is left unset.
+type DecodeWarning struct {
+	// Section is the JSON name of the section, such as "videos"
+	Section string
+
+	// Err is the decoding error
+	Err error
+}
+
+// Error implements the error interface
+func (w DecodeWarning) Error() string {
+	return fmt.Sprintf("failed to decode %s section: %v", w.Section, w.Err)
+}
+
+// Unwrap returns the decoding error
+func (w DecodeWarning) Unwrap() error {
+	return w.Err
+}
+
+// partialDecoder is implemented by responses that can be decoded section by section
+type partialDecoder interface {
+	decodePartial(data []byte) error
+}
+
+// decodeResponse decodes data into result. In soft-fail mode, responses that
+// support it are decoded section by section, keeping the sections that decode.
+func (c *Client) decodeResponse(data []byte, result any) error {
+	if decoder, ok := result.(partialDecoder); ok && c.config.SoftFail {
+		return decoder.decodePartial(data)
+	}
+	return json.Unmarshal(data, result)
+}
+
+// decodePartial decodes the sections of a web search response one by one,
+// recording the sections that fail in DecodeWarnings. It fails only if the
+// response is not a JSON object or none of its sections decode.
+func (r *WebSearchResponse) decodePartial(data []byte) error {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(r).Elem()
+	t := v.Type()
+	decoded := 0
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		raw, ok := sections[name]
+		if !ok {
+			continue
+		}
+
+		value := reflect.New(field.Type)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			r.DecodeWarnings = append(r.DecodeWarnings, DecodeWarning{Section: name, Err: err})
+			continue
+		}
+		v.Field(i).Set(value.Elem())
+		decoded++
+	}
+
+	if decoded == 0 && len(r.DecodeWarnings) > 0 {
+		errs := make([]error, len(r.DecodeWarnings))
+		for i, warning := range r.DecodeWarnings {
+			errs[i] = warning
+		}
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// warnDecodeFailures reports the sections of response that could not be decoded
+func (c *Client) warnDecodeFailures(response *WebSearchResponse) {
+	for _, warning := range response.DecodeWarnings {
+		c.warn(Warning{
+			Code:    WarningCodeDecodeFailed,
+			Message: warning.Error(),
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// brokenVideosResponse has a videos section that does not match VideoResult
+const brokenVideosResponse = `{
+	"type": "search",
+	"web": {"type": "search", "results": [{"title": "Go", "url": "https://go.dev"}]},
+	"videos": {"type": "videos", "results": "unavailable"},
+	"query": {"original": "golang"}
+}`
+
+// TestDecodePartial tests decoding a response section by section
+func TestDecodePartial(t *testing.T) {
+	var response WebSearchResponse
+	require.NoError(t, response.decodePartial([]byte(brokenVideosResponse)))
+
+	assert.Equal(t, "search", response.Type)
+	require.NotNil(t, response.Web)
+	assert.Len(t, response.Web.Results, 1)
+	require.NotNil(t, response.Query)
+	assert.Equal(t, "golang", response.Query.Original)
+	assert.Nil(t, response.Videos)
+
+	require.Len(t, response.DecodeWarnings, 1)
+	assert.Equal(t, "videos", response.DecodeWarnings[0].Section)
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, response.DecodeWarnings[0], &typeErr)
+
+	// Nothing decodes
+	response = WebSearchResponse{}
+	assert.Error(t, response.decodePartial([]byte(`{"type": 1, "web": []}`)))
+
+	// Not an object
+	response = WebSearchResponse{}
+	assert.Error(t, response.decodePartial([]byte(`[]`)))
+}
+
+// TestWithSoftFail tests returning partial responses instead of failing
+func TestWithSoftFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(brokenVideosResponse))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	assert.ErrorIs(t, err, ErrInvalidResponse)
+
+	var mu sync.Mutex
+	var warnings []Warning
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL), WithSoftFail(true),
+		WithWarningHandler(func(warning Warning) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, warning)
+		}))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.NotNil(t, response.Web)
+	assert.Len(t, response.Web.Results, 1)
+	assert.Nil(t, response.Videos)
+	require.Len(t, response.DecodeWarnings, 1)
+	assert.Equal(t, "videos", response.DecodeWarnings[0].Section)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodeDecodeFailed, warnings[0].Code)
+	assert.Contains(t, warnings[0].Message, "videos")
+}
@@ -0,0 +1,81 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultPreviewConcurrency is how many previews are rendered at a time by default
+const DefaultPreviewConcurrency = 4
+
+// Previewer renders a preview image (e.g. a screenshot) of a web page, for
+// link-preview UIs. Implementations usually call out to a renderer service
+// and must be safe for concurrent use.
+type Previewer interface {
+	Preview(ctx context.Context, url string) ([]byte, error)
+}
+
+// PreviewerFunc is an adapter to allow the use of ordinary functions as Previewers
+type PreviewerFunc func(ctx context.Context, url string) ([]byte, error)
+
+// Preview calls f(ctx, url)
+func (f PreviewerFunc) Preview(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+// AttachPreviews sets the PreviewImage of results using previewer, rendering
+// up to concurrency previews at a time. Results whose preview fails are left
+// without one, and the failures are returned joined together.
+func AttachPreviews(ctx context.Context, previewer Previewer, results []SearchResult, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = DefaultPreviewConcurrency
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	slots := make(chan struct{}, concurrency)
+	for i := range results {
+		if results[i].URL == "" {
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(result *SearchResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			image, err := previewer.Preview(ctx, result.URL)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("preview of %s: %w", result.URL, err))
+				mu.Unlock()
+				return
+			}
+			result.PreviewImage = image
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// attachPreviews attaches previews to the web results of response with the
+// configured Previewer, if any. Failures are reported as warnings.
+func (c *Client) attachPreviews(ctx context.Context, response *WebSearchResponse) {
+	if c.config.Previewer == nil || response.Web == nil {
+		return
+	}
+
+	if err := AttachPreviews(ctx, c.config.Previewer, response.Web.Results, c.config.PreviewConcurrency); err != nil {
+		c.warn(Warning{
+			Code:    WarningCodePreviewFailed,
+			Message: "failed to render result previews: " + err.Error(),
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAttachPreviews tests attaching preview images to results
+func TestAttachPreviews(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	previewer := PreviewerFunc(func(ctx context.Context, url string) ([]byte, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		if url == "https://broken.example/" {
+			return nil, errors.New("render failed")
+		}
+		return []byte("png:" + url), nil
+	})
+
+	results := []SearchResult{
+		{URL: "https://a.example/"},
+		{URL: "https://broken.example/"},
+		{URL: ""},
+		{URL: "https://b.example/"},
+		{URL: "https://c.example/"},
+	}
+
+	err := AttachPreviews(context.Background(), previewer, results, 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "preview of https://broken.example/: render failed")
+
+	assert.Equal(t, []byte("png:https://a.example/"), results[0].PreviewImage)
+	assert.Nil(t, results[1].PreviewImage)
+	assert.Nil(t, results[2].PreviewImage)
+	assert.Equal(t, []byte("png:https://b.example/"), results[3].PreviewImage)
+	assert.Equal(t, []byte("png:https://c.example/"), results[4].PreviewImage)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+
+	assert.NoError(t, AttachPreviews(context.Background(), previewer, results[:1], 0))
+}
+
+// TestWithPreviewer tests the preview stage of web searches
+func TestWithPreviewer(t *testing.T) {
+	server, _ := setupMockServer(t)
+	defer server.Close()
+
+	previewer := PreviewerFunc(func(ctx context.Context, url string) ([]byte, error) {
+		if url == "https://go.dev/" {
+			return nil, errors.New("timeout")
+		}
+		return []byte("image"), nil
+	})
+
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/res/v1"),
+		WithPreviewer(previewer, 1),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "go programming", nil)
+	require.NoError(t, err)
+	require.Greater(t, len(response.Web.Results), 1)
+	for _, result := range response.Web.Results {
+		if result.URL == "https://go.dev/" {
+			assert.Nil(t, result.PreviewImage)
+		} else {
+			assert.Equal(t, []byte("image"), result.PreviewImage)
+		}
+	}
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodePreviewFailed, warnings[0].Code)
+
+	_, err = NewClient("test-api-key", WithPreviewer(nil, 1))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	_, err = NewClient("test-api-key", WithPreviewer(previewer, -1))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
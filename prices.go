@@ -0,0 +1,242 @@
+package bravesearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// Availability is whether a product offer can be bought
+type Availability string
+
+// Availabilities
+const (
+	AvailabilityUnknown    Availability = ""
+	AvailabilityInStock    Availability = "in_stock"
+	AvailabilityOutOfStock Availability = "out_of_stock"
+	AvailabilityPreOrder   Availability = "pre_order"
+)
+
+// PriceQuote is the price of a product at a merchant
+type PriceQuote struct {
+	// Product is the name of the product
+	Product string
+
+	// Merchant is the hostname of the offer, without "www."
+	Merchant string
+
+	// URL is the offer URL, or that of the result it was found in
+	URL string
+
+	// Price is the parsed amount and Currency its ISO 4217 code, if known
+	Price    float64
+	Currency string
+
+	// RawPrice is the price as shown by the API
+	RawPrice string
+
+	Availability Availability
+}
+
+// PriceTable compares the prices of merchants in one currency
+type PriceTable struct {
+	Currency string
+
+	// Quotes holds the lowest quote of each merchant, cheapest first.
+	// Quotes that are out of stock come last.
+	Quotes []PriceQuote
+}
+
+// Lowest returns the cheapest quote that is not out of stock
+func (t *PriceTable) Lowest() (PriceQuote, bool) {
+	for _, quote := range t.Quotes {
+		if quote.Availability != AvailabilityOutOfStock {
+			return quote, true
+		}
+	}
+	return PriceQuote{}, false
+}
+
+// currencySymbols maps currency symbols to ISO 4217 codes. "$" is taken to
+// be US dollars unless the offer names its currency.
+var currencySymbols = map[string]string{
+	"$": "USD", "US$": "USD", "€": "EUR", "£": "GBP", "¥": "JPY", "円": "JPY",
+	"₹": "INR", "₩": "KRW", "A$": "AUD", "C$": "CAD", "CA$": "CAD", "R$": "BRL",
+	"zł": "PLN",
+}
+
+// ExtractPrices returns a quote for each offer of the results that carry
+// product data, skipping prices that cannot be parsed
+func ExtractPrices(results []SearchResult) []PriceQuote {
+	var quotes []PriceQuote
+	for _, result := range results {
+		for _, product := range ExtractProducts([]SearchResult{result}) {
+			for _, offer := range product.Offers {
+				price, currency, ok := ParsePrice(offer.Price)
+				if !ok {
+					continue
+				}
+				if offer.PriceCurrency != "" {
+					currency = strings.ToUpper(offer.PriceCurrency)
+				}
+
+				availability := parseAvailability(offer.Availability)
+				if availability == AvailabilityUnknown {
+					availability = availabilityFromText(result.Description)
+				}
+
+				offerURL := textutil.FirstNonEmpty(offer.URL, result.URL)
+				quotes = append(quotes, PriceQuote{
+					Product:      product.Name,
+					Merchant:     merchantName(offerURL),
+					URL:          offerURL,
+					Price:        price,
+					Currency:     currency,
+					RawPrice:     offer.Price,
+					Availability: availability,
+				})
+			}
+		}
+	}
+	return quotes
+}
+
+// ComparePrices groups quotes into a PriceTable per currency, keeping the
+// lowest quote of each merchant. Tables are ordered by the number of
+// merchants, most first; quotes without a currency are left out.
+func ComparePrices(quotes []PriceQuote) []PriceTable {
+	lowest := make(map[string]map[string]PriceQuote)
+	for _, quote := range quotes {
+		if quote.Currency == "" {
+			continue
+		}
+		merchants := lowest[quote.Currency]
+		if merchants == nil {
+			merchants = make(map[string]PriceQuote)
+			lowest[quote.Currency] = merchants
+		}
+		if current, ok := merchants[quote.Merchant]; !ok || cheaper(quote, current) {
+			merchants[quote.Merchant] = quote
+		}
+	}
+
+	tables := make([]PriceTable, 0, len(lowest))
+	for currency, merchants := range lowest {
+		table := PriceTable{Currency: currency}
+		for _, quote := range merchants {
+			table.Quotes = append(table.Quotes, quote)
+		}
+		sort.Slice(table.Quotes, func(i, j int) bool {
+			if cheaper(table.Quotes[i], table.Quotes[j]) {
+				return true
+			}
+			if cheaper(table.Quotes[j], table.Quotes[i]) {
+				return false
+			}
+			return table.Quotes[i].Merchant < table.Quotes[j].Merchant
+		})
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		if len(tables[i].Quotes) != len(tables[j].Quotes) {
+			return len(tables[i].Quotes) > len(tables[j].Quotes)
+		}
+		return tables[i].Currency < tables[j].Currency
+	})
+	return tables
+}
+
+// cheaper reports whether quote a ranks before b: available before out of
+// stock, then by price
+func cheaper(a, b PriceQuote) bool {
+	aOut, bOut := a.Availability == AvailabilityOutOfStock, b.Availability == AvailabilityOutOfStock
+	if aOut != bOut {
+		return bOut
+	}
+	return a.Price < b.Price
+}
+
+// ParsePrice parses a price as shown in offers, such as "$1,299.99",
+// "19,99 €" or "USD 20", into its amount and ISO 4217 currency code. The
+// currency is "" if the price names none.
+func ParsePrice(s string) (float64, string, bool) {
+	s = strings.TrimSpace(s)
+	start := strings.IndexFunc(s, unicode.IsDigit)
+	if start < 0 {
+		return 0, "", false
+	}
+	end := strings.LastIndexFunc(s, unicode.IsDigit) + 1
+
+	amount, ok := parseAmount(s[start:end])
+	if !ok {
+		return 0, "", false
+	}
+
+	currency := strings.TrimSpace(s[:start])
+	if currency == "" {
+		currency = strings.TrimSpace(s[end:])
+	}
+	currency = strings.Trim(currency, " ,.-") // e.g. "12,- €"
+	if code, ok := currencySymbols[currency]; ok {
+		return amount, code, true
+	}
+	if len(currency) == 3 && strings.IndexFunc(currency, func(r rune) bool { return !unicode.IsLetter(r) }) < 0 {
+		return amount, strings.ToUpper(currency), true
+	}
+	return amount, "", true
+}
+
+// parseAmount parses a number with thousands separators. The last "." or
+// "," is the decimal separator if one or two digits follow it.
+func parseAmount(s string) (float64, bool) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(s)
+	decimal := strings.LastIndexAny(s, ".,")
+	if decimal >= 0 && len(s)-decimal-1 <= 2 {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s[:decimal]) + "." + s[decimal+1:]
+	} else {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s)
+	}
+
+	amount, err := strconv.ParseFloat(s, 64)
+	return amount, err == nil && amount >= 0
+}
+
+// parseAvailability maps a schema.org availability such as
+// "https://schema.org/InStock" to an Availability
+func parseAvailability(s string) Availability {
+	name := strings.ToLower(s[strings.LastIndex(s, "/")+1:])
+	switch name {
+	case "instock", "instoreonly", "onlineonly", "limitedavailability":
+		return AvailabilityInStock
+	case "outofstock", "soldout", "discontinued":
+		return AvailabilityOutOfStock
+	case "preorder", "presale", "backorder":
+		return AvailabilityPreOrder
+	default:
+		return AvailabilityUnknown
+	}
+}
+
+// availabilityFromText guesses the availability from a result description
+func availabilityFromText(text string) Availability {
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "out of stock") || strings.Contains(text, "sold out") || strings.Contains(text, "unavailable"):
+		return AvailabilityOutOfStock
+	case strings.Contains(text, "pre-order") || strings.Contains(text, "preorder"):
+		return AvailabilityPreOrder
+	case strings.Contains(text, "in stock"):
+		return AvailabilityInStock
+	default:
+		return AvailabilityUnknown
+	}
+}
+
+// merchantName returns the hostname of a URL without "www."
+func merchantName(rawURL string) string {
+	return hostutil.WithoutWWW(hostutil.Of("", rawURL))
+}
@@ -0,0 +1,132 @@
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParsePrice tests parsing prices in common formats
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		input    string
+		amount   float64
+		currency string
+		ok       bool
+	}{
+		{"$19.99", 19.99, "USD", true},
+		{"$1,299.99", 1299.99, "USD", true},
+		{"19,99 €", 19.99, "EUR", true},
+		{"1.299,00 €", 1299, "EUR", true},
+		{"€ 12,-", 12, "EUR", true},
+		{"¥1,980", 1980, "JPY", true},
+		{"£5", 5, "GBP", true},
+		{"USD 20", 20, "USD", true},
+		{"20 chf", 20, "CHF", true},
+		{"CA$ 35.50", 35.5, "CAD", true},
+		{"1 299,90 zł", 1299.9, "PLN", true},
+		{"42", 42, "", true},
+		{"about 42 bucks", 42, "", true},
+		{"free", 0, "", false},
+		{"", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			amount, currency, ok := ParsePrice(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.amount, amount, 0.001)
+			assert.Equal(t, tt.currency, currency)
+		})
+	}
+}
+
+// TestExtractPrices tests extracting quotes from product results
+func TestExtractPrices(t *testing.T) {
+	results := []SearchResult{
+		{
+			Title: "Kettle at Shop A",
+			URL:   "https://www.shop-a.example/kettle",
+			Product: &Product{
+				Name: "Electric Kettle",
+				Offers: []Offer{
+					{URL: "https://www.shop-a.example/kettle?color=red", Price: "$39.99", Availability: "https://schema.org/InStock"},
+					{Price: "34,99", PriceCurrency: "eur"},
+				},
+			},
+		},
+		{
+			Title:       "Kettle at Shop B",
+			URL:         "https://shop-b.example/p/1",
+			Description: "Currently out of stock.",
+			Product:     &Product{Name: "Electric Kettle", Price: "$29.99"},
+		},
+		{Title: "Kettle review", URL: "https://reviews.example/kettle"},
+		{Title: "Unpriced", URL: "https://shop-c.example/p", Product: &Product{Offers: []Offer{{Price: "call us"}}}},
+	}
+
+	quotes := ExtractPrices(results)
+	require.Len(t, quotes, 3)
+
+	assert.Equal(t, PriceQuote{
+		Product:      "Electric Kettle",
+		Merchant:     "shop-a.example",
+		URL:          "https://www.shop-a.example/kettle?color=red",
+		Price:        39.99,
+		Currency:     "USD",
+		RawPrice:     "$39.99",
+		Availability: AvailabilityInStock,
+	}, quotes[0])
+
+	// The currency of the offer wins, and the result URL stands in for the offer URL
+	assert.Equal(t, "EUR", quotes[1].Currency)
+	assert.Equal(t, "https://www.shop-a.example/kettle", quotes[1].URL)
+	assert.Equal(t, AvailabilityUnknown, quotes[1].Availability)
+
+	// The availability is guessed from the description
+	assert.Equal(t, "shop-b.example", quotes[2].Merchant)
+	assert.Equal(t, AvailabilityOutOfStock, quotes[2].Availability)
+}
+
+// TestComparePrices tests building price comparison tables
+func TestComparePrices(t *testing.T) {
+	quotes := []PriceQuote{
+		{Merchant: "a.example", Price: 39.99, Currency: "USD"},
+		{Merchant: "a.example", Price: 35, Currency: "USD"},
+		{Merchant: "b.example", Price: 29.99, Currency: "USD", Availability: AvailabilityOutOfStock},
+		{Merchant: "c.example", Price: 37, Currency: "USD", Availability: AvailabilityPreOrder},
+		{Merchant: "d.example", Price: 30, Currency: "EUR"},
+		{Merchant: "e.example", Price: 10},
+	}
+
+	tables := ComparePrices(quotes)
+	require.Len(t, tables, 2)
+
+	usd := tables[0]
+	assert.Equal(t, "USD", usd.Currency)
+	require.Len(t, usd.Quotes, 3)
+	assert.Equal(t, "a.example", usd.Quotes[0].Merchant)
+	assert.Equal(t, 35.0, usd.Quotes[0].Price)
+	assert.Equal(t, "c.example", usd.Quotes[1].Merchant)
+	assert.Equal(t, "b.example", usd.Quotes[2].Merchant) // out of stock comes last
+
+	lowest, ok := usd.Lowest()
+	assert.True(t, ok)
+	assert.Equal(t, "a.example", lowest.Merchant)
+
+	assert.Equal(t, "EUR", tables[1].Currency)
+
+	soldOut := PriceTable{Quotes: []PriceQuote{{Availability: AvailabilityOutOfStock}}}
+	_, ok = soldOut.Lowest()
+	assert.False(t, ok)
+}
+
+// TestParseAvailability tests mapping schema.org availabilities
+func TestParseAvailability(t *testing.T) {
+	assert.Equal(t, AvailabilityInStock, parseAvailability("https://schema.org/InStock"))
+	assert.Equal(t, AvailabilityInStock, parseAvailability("InStock"))
+	assert.Equal(t, AvailabilityOutOfStock, parseAvailability("http://schema.org/SoldOut"))
+	assert.Equal(t, AvailabilityPreOrder, parseAvailability("https://schema.org/PreOrder"))
+	assert.Equal(t, AvailabilityUnknown, parseAvailability(""))
+}
@@ -0,0 +1,229 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// Platform is a social network FindProfiles searches
+type Platform string
+
+// Platforms
+const (
+	PlatformLinkedIn  Platform = "linkedin"
+	PlatformX         Platform = "x"
+	PlatformGitHub    Platform = "github"
+	PlatformFacebook  Platform = "facebook"
+	PlatformInstagram Platform = "instagram"
+	PlatformYouTube   Platform = "youtube"
+	PlatformTikTok    Platform = "tiktok"
+	PlatformReddit    Platform = "reddit"
+)
+
+// SocialProfile is a profile found by FindProfiles
+type SocialProfile struct {
+	Platform Platform
+	URL      string
+
+	// Handle is the user or page name in the profile URL, e.g. "@acme"
+	Handle string
+
+	// Title is the title of the search result
+	Title string
+
+	// Confidence ranges from 0 to 1 and weighs how well the result title
+	// and handle match the name, and how high the result ranked
+	Confidence float64
+}
+
+// platform describes where a platform keeps its profiles
+type platform struct {
+	domains []string
+
+	// profilePath matches the path of profile pages; its last group is the handle
+	profilePath *regexp.Regexp
+
+	// reserved are handles that are pages of the platform itself
+	reserved map[string]bool
+}
+
+// platforms are the platforms FindProfiles searches
+var platforms = map[Platform]platform{
+	PlatformLinkedIn: {
+		domains:     []string{"linkedin.com"},
+		profilePath: regexp.MustCompile(`^/(?:in|company)/([^/]+)/?$`),
+	},
+	PlatformX: {
+		domains:     []string{"x.com", "twitter.com"},
+		profilePath: regexp.MustCompile(`^/([A-Za-z0-9_]{1,15})/?$`),
+		reserved:    reservedHandles("home", "search", "explore", "i", "intent", "share", "login", "settings", "notifications", "messages", "tos", "privacy"),
+	},
+	PlatformGitHub: {
+		domains:     []string{"github.com"},
+		profilePath: regexp.MustCompile(`^/([A-Za-z0-9-]+)/?$`),
+		reserved:    reservedRepoOwners,
+	},
+	PlatformFacebook: {
+		domains:     []string{"facebook.com"},
+		profilePath: regexp.MustCompile(`^/([A-Za-z0-9.]+)/?$`),
+		reserved:    reservedHandles("groups", "events", "watch", "share", "login", "marketplace", "gaming", "help", "policies", "profile.php"),
+	},
+	PlatformInstagram: {
+		domains:     []string{"instagram.com"},
+		profilePath: regexp.MustCompile(`^/([A-Za-z0-9_.]+)/?$`),
+		reserved:    reservedHandles("explore", "accounts", "about", "developer", "legal", "direct"),
+	},
+	PlatformYouTube: {
+		domains:     []string{"youtube.com"},
+		profilePath: regexp.MustCompile(`^/(@[A-Za-z0-9_.-]+|(?:c|channel|user)/[^/]+)/?$`),
+	},
+	PlatformTikTok: {
+		domains:     []string{"tiktok.com"},
+		profilePath: regexp.MustCompile(`^/(@[A-Za-z0-9_.]+)/?$`),
+	},
+	PlatformReddit: {
+		domains:     []string{"reddit.com"},
+		profilePath: regexp.MustCompile(`^/(?:user|u)/([^/]+)/?$`),
+	},
+}
+
+// reservedHandles returns a set of handles
+func reservedHandles(handles ...string) map[string]bool {
+	set := make(map[string]bool, len(handles))
+	for _, handle := range handles {
+		set[handle] = true
+	}
+	return set
+}
+
+// profileSearchCount is the number of results searched per platform
+const profileSearchCount = 5
+
+// Weights of the profile confidence components
+const (
+	profileTitleWeight  = 0.5
+	profileHandleWeight = 0.3
+	profileRankWeight   = 0.2
+)
+
+// FindProfiles searches the social networks for profiles of a person or
+// brand and returns the most likely profile per platform. It runs one query
+// per platform (e.g. `"Acme" site:linkedin.com`) concurrently through the
+// client, keeps only profile pages and drops results that match neither the
+// name nor the handle. Platforms whose search failed are reported as
+// warnings; FindProfiles fails only if every search failed.
+func (c *Client) FindProfiles(ctx context.Context, personOrBrand string) (map[Platform]SocialProfile, error) {
+	name := strings.TrimSpace(personOrBrand)
+	if name == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	profiles := make(map[Platform]SocialProfile)
+	errs := make(map[Platform]error)
+	for id, p := range platforms {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			query := fmt.Sprintf("%q site:%s", name, strings.Join(p.domains, " OR site:"))
+			response, err := c.WebSearch(ctx, query, &WebSearchParams{ResultFilter: "web", Count: profileSearchCount})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			if profile, ok := bestProfile(id, p, name, response.GetWebResults()); ok {
+				profiles[id] = profile
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) == len(platforms) {
+		failed := make([]error, 0, len(errs))
+		for _, err := range errs {
+			failed = append(failed, err)
+		}
+		return nil, errors.Join(failed...)
+	}
+	for id, err := range errs {
+		c.warn(Warning{
+			Code:    WarningCodeProfileSearchFailed,
+			Message: fmt.Sprintf("profile search on %s failed: %v", id, err),
+		})
+	}
+	return profiles, nil
+}
+
+// bestProfile returns the profile page among results that best matches name
+func bestProfile(id Platform, p platform, name string, results []SearchResult) (SocialProfile, bool) {
+	var best SocialProfile
+	found := false
+	seen := make(map[string]bool)
+	for rank, result := range results {
+		handle, ok := p.handle(result.URL)
+		if !ok || seen[strings.ToLower(handle)] {
+			continue
+		}
+		seen[strings.ToLower(handle)] = true
+
+		confidence, ok := profileConfidence(name, handle, result.Title, rank)
+		if !ok {
+			continue
+		}
+		if !found || confidence > best.Confidence {
+			best = SocialProfile{Platform: id, URL: result.URL, Handle: handle, Title: result.Title, Confidence: confidence}
+			found = true
+		}
+	}
+	return best, found
+}
+
+// handle returns the handle of a profile URL of the platform
+func (p platform) handle(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !hostutil.MatchesAnyDomain(u.Hostname(), p.domains) {
+		return "", false
+	}
+	m := p.profilePath.FindStringSubmatch(u.Path)
+	if m == nil || p.reserved[strings.ToLower(m[len(m)-1])] {
+		return "", false
+	}
+	return m[len(m)-1], true
+}
+
+// profileConfidence scores how likely a result at rank is a profile of
+// name. It is false if neither the title nor the handle match the name.
+func profileConfidence(name, handle, title string, rank int) (float64, bool) {
+	words := strings.Fields(strings.ToLower(name))
+	lowerTitle := strings.ToLower(title)
+	matched := 0
+	for _, word := range words {
+		if strings.Contains(lowerTitle, word) {
+			matched++
+		}
+	}
+	titleMatch := float64(matched) / float64(len(words))
+
+	handleMatch := 0.0
+	compactName, compactHandle := compactText(name), compactText(handle)
+	if len(compactHandle) >= 3 && (strings.Contains(compactHandle, compactName) || strings.Contains(compactName, compactHandle)) {
+		handleMatch = 1
+	}
+	if titleMatch == 0 && handleMatch == 0 {
+		return 0, false
+	}
+
+	rankScore := 1 / float64(rank+1)
+	return profileTitleWeight*titleMatch + profileHandleWeight*handleMatch + profileRankWeight*rankScore, true
+}
@@ -0,0 +1,124 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// profileResults are the web results served per site: operator
+var profileResults = map[string]string{
+	"linkedin.com": `[
+		{"title": "Acme Corp: Overview | LinkedIn", "url": "https://www.linkedin.com/company/acme-corp/"},
+		{"title": "Jobs at Acme | LinkedIn", "url": "https://www.linkedin.com/jobs/acme"}
+	]`,
+	"x.com": `[
+		{"title": "Acme (@acme) / X", "url": "https://x.com/acme"},
+		{"title": "Acme (@acme) / Twitter", "url": "https://twitter.com/acme"},
+		{"title": "Acme on X: \"Launch day\"", "url": "https://x.com/acme/status/1"}
+	]`,
+	"github.com": `[
+		{"title": "GitHub - acme/widgets", "url": "https://github.com/acme/widgets"},
+		{"title": "Unrelated Org", "url": "https://github.com/unrelated"},
+		{"title": "Explore", "url": "https://github.com/explore"}
+	]`,
+}
+
+// TestFindProfiles tests discovering profiles across platforms
+func TestFindProfiles(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		mu.Lock()
+		queries = append(queries, query)
+		mu.Unlock()
+
+		if strings.Contains(query, "site:reddit.com") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		results := "[]"
+		for site, served := range profileResults {
+			if strings.Contains(query, "site:"+site) {
+				results = served
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"type": "search", "web": {"type": "search", "results": %s}}`, results)
+	}))
+	defer server.Close()
+
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithRetries(0),
+		WithWarningHandler(func(w Warning) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, w)
+		}),
+	)
+	require.NoError(t, err)
+
+	profiles, err := client.FindProfiles(context.Background(), "Acme")
+	require.NoError(t, err)
+
+	assert.Len(t, queries, len(platforms))
+	assert.Contains(t, queries, `"Acme" site:x.com OR site:twitter.com`)
+
+	require.Len(t, profiles, 2)
+	linkedIn := profiles[PlatformLinkedIn]
+	assert.Equal(t, "https://www.linkedin.com/company/acme-corp/", linkedIn.URL)
+	assert.Equal(t, "acme-corp", linkedIn.Handle)
+	assert.InDelta(t, 1.0, linkedIn.Confidence, 0.001)
+
+	// The same handle on twitter.com is a duplicate
+	assert.Equal(t, "https://x.com/acme", profiles[PlatformX].URL)
+
+	// Repositories and pages of the platform are not profiles, and unrelated profiles are dropped
+	_, ok := profiles[PlatformGitHub]
+	assert.False(t, ok)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodeProfileSearchFailed, warnings[0].Code)
+	assert.Contains(t, warnings[0].Message, "reddit")
+}
+
+// TestFindProfilesFailure tests that FindProfiles fails if every search failed
+func TestFindProfilesFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.FindProfiles(context.Background(), "Acme")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = client.FindProfiles(context.Background(), "  ")
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+}
+
+// TestProfileConfidence tests scoring profile matches
+func TestProfileConfidence(t *testing.T) {
+	confidence, ok := profileConfidence("Jane Doe", "janedoe", "Jane Doe - Engineer", 0)
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, confidence, 0.001)
+
+	confidence, ok = profileConfidence("Jane Doe", "jd1984", "Jane Smith", 1)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5*0.5+0.2*0.5, confidence, 0.001)
+
+	_, ok = profileConfidence("Jane Doe", "someone", "Someone Else", 0)
+	assert.False(t, ok)
+}
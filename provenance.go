@@ -0,0 +1,95 @@
+package bravesearch
+
+import "time"
+
+// CacheStatus tells whether a response was fetched from the API or served from a cache
+type CacheStatus string
+
+// Cache statuses
+const (
+	// CacheMiss means the response was fetched from the API
+	CacheMiss CacheStatus = "miss"
+
+	// CacheHit means the response was served from a Session cache
+	CacheHit CacheStatus = "hit"
+)
+
+// Provenance records where a result came from, so downstream systems can
+// audit each document: the query and page that produced it, when it was
+// retrieved and whether it was served from a cache. All the results of a
+// response share one Provenance.
+type Provenance struct {
+	// Query is the query sent to the API
+	Query string `json:"query"`
+
+	// Offset is the page offset of the request (see MaxOffset)
+	Offset int `json:"offset"`
+
+	// Count is the number of results requested per page
+	Count int `json:"count,omitempty"`
+
+	// RetrievedAt is when the response was received from the API
+	RetrievedAt time.Time `json:"retrieved_at"`
+
+	// Cache tells whether the response was served from a cache
+	Cache CacheStatus `json:"cache"`
+}
+
+// setProvenance sets the provenance of the response and of each of its results
+func (r *WebSearchResponse) setProvenance(provenance *Provenance) {
+	r.Provenance = provenance
+	if r.Web != nil {
+		for i := range r.Web.Results {
+			r.Web.Results[i].Provenance = provenance
+		}
+	}
+	if r.News != nil {
+		for i := range r.News.Results {
+			r.News.Results[i].Provenance = provenance
+		}
+	}
+	if r.Videos != nil {
+		for i := range r.Videos.Results {
+			r.Videos.Results[i].Provenance = provenance
+		}
+	}
+}
+
+// cacheHit returns a copy of a cached response whose provenance reports a
+// cache hit, leaving the shared cached response unmodified
+func (r *WebSearchResponse) cacheHit() *WebSearchResponse {
+	provenance := Provenance{}
+	if r.Provenance != nil {
+		provenance = *r.Provenance
+	}
+	provenance.Cache = CacheHit
+	hit := r.copied()
+	hit.setProvenance(&provenance)
+	return hit
+}
+
+// copied returns a copy of the response with its own results and provenance,
+// so either response can be changed without affecting the other
+func (r *WebSearchResponse) copied() *WebSearchResponse {
+	copied := *r
+	if r.Web != nil {
+		web := *r.Web
+		web.Results = append([]SearchResult(nil), r.Web.Results...)
+		copied.Web = &web
+	}
+	if r.News != nil {
+		news := *r.News
+		news.Results = append([]NewsResult(nil), r.News.Results...)
+		copied.News = &news
+	}
+	if r.Videos != nil {
+		videos := *r.Videos
+		videos.Results = append([]VideoResult(nil), r.Videos.Results...)
+		copied.Videos = &videos
+	}
+	if r.Provenance != nil {
+		provenance := *r.Provenance
+		copied.setProvenance(&provenance)
+	}
+	return &copied
+}
@@ -0,0 +1,60 @@
+package bravesearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebSearchProvenance tests attaching provenance to every result, per page
+func TestWebSearchProvenance(t *testing.T) {
+	pages := [][]string{
+		{"https://a.example/", "https://b.example/"},
+		{"https://c.example/"},
+	}
+	server := newMockServer(t, pagedResponse(pages))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	before := time.Now()
+	response, err := client.WebSearchExactly(context.Background(), "golang", 3, nil)
+	require.NoError(t, err)
+
+	results := response.GetWebResults()
+	require.Len(t, results, 3)
+	for i, expectedOffset := range []int{0, 0, 1} {
+		provenance := results[i].Provenance
+		require.NotNil(t, provenance, i)
+		assert.Equal(t, "golang", provenance.Query)
+		assert.Equal(t, expectedOffset, provenance.Offset)
+		assert.Equal(t, MaxCount, provenance.Count)
+		assert.Equal(t, CacheMiss, provenance.Cache)
+		assert.False(t, provenance.RetrievedAt.Before(before))
+	}
+}
+
+// TestCacheHit tests that cache hits get their own provenance without modifying the cached response
+func TestCacheHit(t *testing.T) {
+	response := &WebSearchResponse{
+		Web:    &Search{Results: []SearchResult{{URL: "https://a.example/"}}},
+		News:   &News{Results: []NewsResult{{URL: "https://b.example/"}}},
+		Videos: &Videos{Results: []VideoResult{{URL: "https://c.example/"}}},
+	}
+	response.setProvenance(&Provenance{Query: "golang", Cache: CacheMiss})
+
+	hit := response.cacheHit()
+	assert.Equal(t, CacheHit, hit.Provenance.Cache)
+	assert.Equal(t, "golang", hit.Provenance.Query)
+	assert.Same(t, hit.Provenance, hit.Web.Results[0].Provenance)
+	assert.Same(t, hit.Provenance, hit.News.Results[0].Provenance)
+	assert.Same(t, hit.Provenance, hit.Videos.Results[0].Provenance)
+
+	assert.Equal(t, CacheMiss, response.Provenance.Cache)
+	assert.Equal(t, CacheMiss, response.Web.Results[0].Provenance.Cache)
+	assert.Equal(t, CacheMiss, response.News.Results[0].Provenance.Cache)
+	assert.Equal(t, CacheMiss, response.Videos.Results[0].Provenance.Cache)
+}
@@ -0,0 +1,125 @@
+package bravesearch
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Default query limits enforced by the API
+const (
+	DefaultMaxQueryChars = 400
+	DefaultMaxQueryWords = 50
+)
+
+// QueryLimits bounds the size of search queries. A zero limit disables that check.
+type QueryLimits struct {
+	// MaxChars is the maximum number of characters (Unicode code points, not bytes)
+	MaxChars int
+
+	// MaxWords is the maximum number of words, as counted by QueryWordCount
+	MaxWords int
+}
+
+// DefaultQueryLimits returns the limits enforced by the API
+func DefaultQueryLimits() QueryLimits {
+	return QueryLimits{
+		MaxChars: DefaultMaxQueryChars,
+		MaxWords: DefaultMaxQueryWords,
+	}
+}
+
+// QueryNormalization is the Unicode normalization applied to queries
+type QueryNormalization int
+
+// Query normalization forms
+const (
+	// QueryNormalizationNFC composes characters canonically (the default), so
+	// differently composed forms of the same text are sent identically
+	QueryNormalizationNFC QueryNormalization = iota
+
+	// QueryNormalizationNFKC also folds compatibility characters, e.g.
+	// half-width katakana and full-width Latin letters
+	QueryNormalizationNFKC
+
+	// QueryNormalizationNone sends queries unchanged
+	QueryNormalizationNone
+)
+
+// String returns the name of the normalization form
+func (n QueryNormalization) String() string {
+	switch n {
+	case QueryNormalizationNFC:
+		return "nfc"
+	case QueryNormalizationNFKC:
+		return "nfkc"
+	case QueryNormalizationNone:
+		return "none"
+	default:
+		return fmt.Sprintf("QueryNormalization(%d)", int(n))
+	}
+}
+
+// NormalizeQuery applies the normalization form to query
+func NormalizeQuery(query string, form QueryNormalization) string {
+	switch form {
+	case QueryNormalizationNFC:
+		return norm.NFC.String(query)
+	case QueryNormalizationNFKC:
+		return norm.NFKC.String(query)
+	default:
+		return query
+	}
+}
+
+// queryOperators are standalone search operators that are not words
+var queryOperators = map[string]bool{
+	"AND": true,
+	"OR":  true,
+	"NOT": true,
+	"|":   true,
+	"-":   true,
+	"+":   true,
+}
+
+// ValidateQuery checks query against limits. It returns ErrEmptyQuery for
+// blank queries and an error wrapping ErrQueryTooLong if a limit is exceeded.
+func ValidateQuery(query string, limits QueryLimits) error {
+	if strings.TrimSpace(query) == "" {
+		return ErrEmptyQuery
+	}
+
+	if chars := utf8.RuneCountInString(query); limits.MaxChars > 0 && chars > limits.MaxChars {
+		return fmt.Errorf("%w: %d characters exceeds the limit of %d", ErrQueryTooLong, chars, limits.MaxChars)
+	}
+
+	if words := QueryWordCount(query); limits.MaxWords > 0 && words > limits.MaxWords {
+		return fmt.Errorf("%w: %d words exceeds the limit of %d", ErrQueryTooLong, words, limits.MaxWords)
+	}
+
+	return nil
+}
+
+// QueryWordCount counts the words in a query the way the API limit is meant:
+// quotes and the +/- prefixes of terms are syntax rather than words,
+// standalone operators (AND, OR, NOT, |) are not counted, and an operator
+// term such as "site:example.com" is a single word. Words inside quoted
+// phrases count individually.
+func QueryWordCount(query string) int {
+	count := 0
+	for _, field := range strings.Fields(query) {
+		if queryOperators[field] {
+			continue
+		}
+
+		term := strings.Trim(field, `"“”`)
+		term = strings.TrimLeft(term, "+-")
+		if term == "" {
+			continue
+		}
+		count++
+	}
+	return count
+}
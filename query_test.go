@@ -0,0 +1,131 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueryWordCount tests operator-aware word counting
+func TestQueryWordCount(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"golang programming", 2},
+		{`"the quick brown fox"`, 4},
+		{`" spaced phrase "`, 2},
+		{"golang OR rust", 2},
+		{"golang -java +generics", 3},
+		{"golang - java", 2},
+		{"site:go.dev generics", 2},
+		{"東京 ラーメン", 2},
+		{"東京のラーメン屋", 1},
+		{"   ", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, QueryWordCount(tt.query))
+		})
+	}
+}
+
+// TestValidateQuery tests query validation against limits
+func TestValidateQuery(t *testing.T) {
+	limits := DefaultQueryLimits()
+
+	assert.NoError(t, ValidateQuery("golang programming", limits))
+	assert.Equal(t, ErrEmptyQuery, ValidateQuery("", limits))
+	assert.Equal(t, ErrEmptyQuery, ValidateQuery("  ", limits))
+
+	// CJK queries are counted in characters, not bytes (300 runes = 900 bytes)
+	cjk := strings.Repeat("東", 300)
+	assert.NoError(t, ValidateQuery(cjk, limits))
+
+	err := ValidateQuery(strings.Repeat("東", 401), limits)
+	assert.ErrorIs(t, err, ErrQueryTooLong)
+	assert.Contains(t, err.Error(), "401 characters exceeds the limit of 400")
+
+	// Operators don't count as words
+	words := strings.TrimSpace(strings.Repeat("go OR ", 50))
+	words = strings.TrimSuffix(words, " OR")
+	assert.NoError(t, ValidateQuery(words, limits))
+
+	err = ValidateQuery(strings.Repeat("go ", 51), limits)
+	assert.ErrorIs(t, err, ErrQueryTooLong)
+	assert.Contains(t, err.Error(), "51 words exceeds the limit of 50")
+
+	// Zero limits disable the checks
+	assert.NoError(t, ValidateQuery(strings.Repeat("go ", 500), QueryLimits{}))
+}
+
+// TestWithQueryLimits tests overriding the query limits per client
+func TestWithQueryLimits(t *testing.T) {
+	client, err := NewClient("test-api-key", WithQueryLimits(QueryLimits{MaxChars: 10}))
+	require.NoError(t, err)
+	assert.Equal(t, QueryLimits{MaxChars: 10}, client.config.QueryLimits)
+
+	_, err = client.WebSearch(context.Background(), "golang programming", nil)
+	assert.ErrorIs(t, err, ErrQueryTooLong)
+
+	// Default limits
+	client, err = NewClient("test-api-key")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultQueryLimits(), client.config.QueryLimits)
+
+	// Negative limits are rejected
+	_, err = NewClient("test-api-key", WithQueryLimits(QueryLimits{MaxWords: -1}))
+	assert.Equal(t, ErrInvalidParameters, err)
+}
+
+// TestNormalizeQuery tests Unicode normalization of queries
+func TestNormalizeQuery(t *testing.T) {
+	// "が" composed (U+304C) and decomposed (U+304B U+3099)
+	composed := "がっこう"
+	decomposed := "がっこう"
+	require.NotEqual(t, composed, decomposed)
+
+	assert.Equal(t, composed, NormalizeQuery(decomposed, QueryNormalizationNFC))
+	assert.Equal(t, decomposed, NormalizeQuery(decomposed, QueryNormalizationNone))
+
+	// NFKC also folds half-width katakana and full-width Latin letters
+	assert.Equal(t, "カタカナ Go", NormalizeQuery("ｶﾀｶﾅ Ｇｏ", QueryNormalizationNFKC))
+	assert.Equal(t, "ｶﾀｶﾅ Ｇｏ", NormalizeQuery("ｶﾀｶﾅ Ｇｏ", QueryNormalizationNFC))
+}
+
+// TestWithQueryNormalization tests that queries are normalized before sending
+func TestWithQueryNormalization(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.URL.Query().Get("q"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	decomposed := "がっこう"
+
+	// NFC by default
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	_, err = client.WebSearch(context.Background(), decomposed, nil)
+	require.NoError(t, err)
+
+	// Opt out
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL), WithQueryNormalization(QueryNormalizationNone))
+	require.NoError(t, err)
+	_, err = client.WebSearch(context.Background(), decomposed, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"がっこう", decomposed}, received)
+
+	// Unknown forms are rejected
+	_, err = NewClient("test-api-key", WithQueryNormalization(QueryNormalization(42)))
+	assert.Equal(t, ErrInvalidParameters, err)
+}
@@ -0,0 +1,142 @@
+package bravesearch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimitStore shares throttling state between clients using the same API
+// key, typically short-lived workers in different processes (Lambdas, cron
+// jobs), so they coordinate on the API rate limit instead of each throttling
+// on its own. Implementations must be safe for concurrent use.
+type RateLimitStore interface {
+	// Reserve claims a request slot for key, allowing at most limit requests
+	// per window. It returns zero if a slot was claimed, otherwise how long
+	// to wait before trying again.
+	Reserve(ctx context.Context, key string, limit int, window time.Duration) (time.Duration, error)
+
+	// Update records the rate limit the API reported. When no requests
+	// remain, reservations are refused until the reported reset.
+	Update(ctx context.Context, key string, rateLimit RateLimit) error
+}
+
+// rateLimitWindow is the window of the per-second API rate limit
+const rateLimitWindow = time.Second
+
+// MemoryRateLimitStore is a RateLimitStore for clients within one process
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	windows map[string]*rateLimitWindowState
+	now     func() time.Time
+}
+
+// rateLimitWindowState is the request count of the current window of a key
+type rateLimitWindowState struct {
+	start        time.Time
+	count        int
+	blockedUntil time.Time
+}
+
+// NewMemoryRateLimitStore creates an in-process RateLimitStore
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		windows: make(map[string]*rateLimitWindowState),
+		now:     time.Now,
+	}
+}
+
+// Reserve implements RateLimitStore
+func (s *MemoryRateLimitStore) Reserve(ctx context.Context, key string, limit int, window time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	state := s.state(key)
+
+	if now.Before(state.blockedUntil) {
+		return state.blockedUntil.Sub(now), nil
+	}
+	if !now.Before(state.start.Add(window)) {
+		state.start = now
+		state.count = 0
+	}
+	if state.count >= limit {
+		return state.start.Add(window).Sub(now), nil
+	}
+
+	state.count++
+	return 0, nil
+}
+
+// Update implements RateLimitStore
+func (s *MemoryRateLimitStore) Update(ctx context.Context, key string, rateLimit RateLimit) error {
+	if rateLimit.Remaining > 0 || rateLimit.Reset <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state(key).blockedUntil = s.now().Add(time.Duration(rateLimit.Reset) * time.Second)
+	return nil
+}
+
+// state returns the window state of key, creating it if needed
+func (s *MemoryRateLimitStore) state(key string) *rateLimitWindowState {
+	state, ok := s.windows[key]
+	if !ok {
+		state = &rateLimitWindowState{}
+		s.windows[key] = state
+	}
+	return state
+}
+
+// rateLimitKey identifies the API key in a RateLimitStore without revealing it
+func rateLimitKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return "bravesearch:ratelimit:" + hex.EncodeToString(sum[:8])
+}
+
+// reserveRateLimit waits until the RateLimitStore grants a request slot
+func (c *Client) reserveRateLimit(ctx context.Context) error {
+	if c.config.RateLimitStore == nil {
+		return nil
+	}
+
+	for {
+		wait, err := c.config.RateLimitStore.Reserve(ctx, c.rateLimitKey, c.config.RateLimitPerSecond, rateLimitWindow)
+		if err != nil {
+			return fmt.Errorf("failed to reserve rate limit: %w", err)
+		}
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// updateRateLimitStore shares the rate limit reported in resp, if any.
+// Failures are reported as warnings.
+func (c *Client) updateRateLimitStore(ctx context.Context, resp *http.Response) {
+	if c.config.RateLimitStore == nil || resp.Header.Get(HeaderRateLimitRemaining) == "" {
+		return
+	}
+
+	if err := c.config.RateLimitStore.Update(ctx, c.rateLimitKey, *c.parseRateLimitHeaders(resp)); err != nil {
+		c.warn(Warning{
+			Code:    WarningCodeRateLimitStore,
+			Message: "failed to update rate limit store: " + err.Error(),
+		})
+	}
+}
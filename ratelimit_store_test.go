@@ -0,0 +1,135 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryRateLimitStore tests reservations per window
+func TestMemoryRateLimitStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		wait, err := store.Reserve(ctx, "key", 2, time.Second)
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+	}
+
+	now = now.Add(300 * time.Millisecond)
+	wait, err := store.Reserve(ctx, "key", 2, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 700*time.Millisecond, wait)
+
+	// Other keys have their own windows
+	wait, err = store.Reserve(ctx, "other", 2, time.Second)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	// A new window
+	now = now.Add(700 * time.Millisecond)
+	wait, err = store.Reserve(ctx, "key", 2, time.Second)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+}
+
+// TestMemoryRateLimitStoreUpdate tests blocking on exhausted rate limits
+func TestMemoryRateLimitStoreUpdate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, store.Update(ctx, "key", RateLimit{Limit: 1, Remaining: 1, Reset: 1}))
+	wait, err := store.Reserve(ctx, "key", 10, time.Second)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	require.NoError(t, store.Update(ctx, "key", RateLimit{Limit: 1, Remaining: 0, Reset: 30}))
+	wait, err = store.Reserve(ctx, "key", 10, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, wait)
+}
+
+// TestClientRateLimitStore tests that clients sharing a store throttle together
+func TestClientRateLimitStore(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set(HeaderRateLimitRemaining, "1, 1000")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	store := NewMemoryRateLimitStore()
+	var clients []*Client
+	for i := 0; i < 2; i++ {
+		client, err := NewClient("shared-key", WithBaseURL(server.URL), WithRateLimitStore(store, 1))
+		require.NoError(t, err)
+		clients = append(clients, client)
+	}
+
+	_, err := clients[0].WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	// The second client has to wait for the next window
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = clients[1].WebSearch(ctx, "golang", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), requests.Load())
+
+	start := time.Now()
+	_, err = clients[1].WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Greater(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+// TestClientRateLimitStoreError tests that store failures fail the request
+func TestClientRateLimitStoreError(t *testing.T) {
+	client, err := NewClient("test-api-key", WithRateLimitStore(failingRateLimitStore{}, 1))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	assert.ErrorContains(t, err, "failed to reserve rate limit: store unavailable")
+}
+
+// TestWithRateLimitStore tests the rate limit store option
+func TestWithRateLimitStore(t *testing.T) {
+	config := &ClientConfig{}
+	assert.Equal(t, ErrInvalidParameters, WithRateLimitStore(nil, 1)(config))
+	assert.Equal(t, ErrInvalidParameters, WithRateLimitStore(NewMemoryRateLimitStore(), 0)(config))
+	require.NoError(t, WithRateLimitStore(NewMemoryRateLimitStore(), 20)(config))
+	assert.Equal(t, 20, config.RateLimitPerSecond)
+}
+
+// TestRateLimitKey tests that store keys do not reveal the API key
+func TestRateLimitKey(t *testing.T) {
+	key := rateLimitKey("secret-api-key")
+	assert.NotContains(t, key, "secret")
+	assert.Equal(t, key, rateLimitKey("secret-api-key"))
+	assert.NotEqual(t, key, rateLimitKey("other-api-key"))
+}
+
+// failingRateLimitStore is a RateLimitStore that is always unavailable
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Reserve(ctx context.Context, key string, limit int, window time.Duration) (time.Duration, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func (failingRateLimitStore) Update(ctx context.Context, key string, rateLimit RateLimit) error {
+	return errors.New("store unavailable")
+}
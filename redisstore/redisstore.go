@@ -0,0 +1,81 @@
+// Package redisstore provides a Redis-backed bravesearch.RateLimitStore, so
+// clients in different processes sharing one API key throttle together.
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	client, err := bravesearch.NewClient(apiKey,
+//		bravesearch.WithRateLimitStore(redisstore.New(rdb), 1),
+//	)
+package redisstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+)
+
+// reserveScript claims a slot in the current window unless the key is
+// blocked or the window is full, returning the milliseconds to wait (0 if a
+// slot was claimed)
+var reserveScript = redis.NewScript(`
+local blocked = redis.call('PTTL', KEYS[2])
+if blocked > 0 then
+	return blocked
+end
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if count > tonumber(ARGV[1]) then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl < 0 then
+		redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		ttl = tonumber(ARGV[2])
+	end
+	return ttl
+end
+return 0
+`)
+
+// Store is a bravesearch.RateLimitStore backed by Redis. It works with
+// single nodes and clusters; the keys of one API key share a hash slot.
+type Store struct {
+	client redis.Cmdable
+}
+
+// New creates a Store using the Redis client
+func New(client redis.Cmdable) *Store {
+	return &Store{client: client}
+}
+
+// Reserve implements bravesearch.RateLimitStore
+func (s *Store) Reserve(ctx context.Context, key string, limit int, window time.Duration) (time.Duration, error) {
+	wait, err := reserveScript.Run(ctx, s.client,
+		[]string{windowKey(key), blockedKey(key)},
+		limit, window.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// Update implements bravesearch.RateLimitStore
+func (s *Store) Update(ctx context.Context, key string, rateLimit bravesearch.RateLimit) error {
+	if rateLimit.Remaining > 0 || rateLimit.Reset <= 0 {
+		return nil
+	}
+	return s.client.Set(ctx, blockedKey(key), 1, time.Duration(rateLimit.Reset)*time.Second).Err()
+}
+
+// windowKey is the Redis key of the request count of the current window
+func windowKey(key string) string {
+	return "{" + key + "}:window"
+}
+
+// blockedKey is the Redis key set while the API reports no remaining requests
+func blockedKey(key string) string {
+	return "{" + key + "}:blocked"
+}
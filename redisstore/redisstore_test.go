@@ -0,0 +1,72 @@
+package redisstore
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+)
+
+var _ bravesearch.RateLimitStore = (*Store)(nil)
+
+// newTestStore connects to the Redis server in BRAVE_SEARCH_TEST_REDIS_ADDR,
+// skipping the test if it is not set
+func newTestStore(t *testing.T) (*Store, string) {
+	addr := os.Getenv("BRAVE_SEARCH_TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("BRAVE_SEARCH_TEST_REDIS_ADDR is not set")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+
+	key := "bravesearch:test:" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	t.Cleanup(func() { client.Del(context.Background(), windowKey(key), blockedKey(key)) })
+	return New(client), key
+}
+
+// TestStoreReserve tests that reservations are limited per window
+func TestStoreReserve(t *testing.T) {
+	store, key := newTestStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		wait, err := store.Reserve(ctx, key, 2, time.Second)
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+	}
+
+	wait, err := store.Reserve(ctx, key, 2, time.Second)
+	require.NoError(t, err)
+	assert.Greater(t, wait, time.Duration(0))
+	assert.LessOrEqual(t, wait, time.Second)
+}
+
+// TestStoreUpdate tests that exhausted rate limits block reservations
+func TestStoreUpdate(t *testing.T) {
+	store, key := newTestStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.Update(ctx, key, bravesearch.RateLimit{Limit: 1, Remaining: 1, Reset: 1}))
+	wait, err := store.Reserve(ctx, key, 10, time.Second)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	require.NoError(t, store.Update(ctx, key, bravesearch.RateLimit{Limit: 1, Remaining: 0, Reset: 30}))
+	wait, err = store.Reserve(ctx, key, 10, time.Second)
+	require.NoError(t, err)
+	assert.Greater(t, wait, 29*time.Second)
+}
+
+// TestKeys tests that the keys of one API key share a cluster hash slot
+func TestKeys(t *testing.T) {
+	assert.Equal(t, "{bravesearch:ratelimit:abc}:window", windowKey("bravesearch:ratelimit:abc"))
+	assert.Equal(t, "{bravesearch:ratelimit:abc}:blocked", blockedKey("bravesearch:ratelimit:abc"))
+}
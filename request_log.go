@@ -0,0 +1,168 @@
+package bravesearch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestLogEntry is a request recorded in a request log
+type RequestLogEntry struct {
+	// Time is when the request started
+	Time time.Time
+
+	// Duration is how long the request took
+	Duration time.Duration
+
+	// Status is the HTTP status the request ended with; 0 if no response was received
+	Status int
+
+	// Endpoint is the request path, e.g. "/res/v1/web/search"
+	Endpoint string
+
+	// Params are the request parameters, including the query as "q" unless
+	// it was recorded in no-retention mode
+	Params url.Values
+}
+
+// RequestLogWriter writes a compact, replayable log of API requests, one
+// line per request:
+//
+//	<unix ms>\t<duration ms>\t<status>\t<endpoint>\t<sorted, URL-encoded params>
+//
+// It is an Auditor; install it with WithAuditLog (combined with other
+// auditors through MultiAuditor) and read the log back with RequestLogReader.
+type RequestLogWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewRequestLogWriter creates a RequestLogWriter writing to w
+func NewRequestLogWriter(w io.Writer) *RequestLogWriter {
+	return &RequestLogWriter{w: w}
+}
+
+// Audit implements Auditor
+func (l *RequestLogWriter) Audit(ctx context.Context, record AuditRecord) error {
+	params := url.Values{}
+	maps.Copy(params, record.Params)
+	if record.Query != "" {
+		params.Set("q", record.Query)
+	}
+
+	line := fmt.Sprintf("%d\t%d\t%d\t%s\t%s\n",
+		record.Time.UnixMilli(), record.Duration.Milliseconds(), record.Status, record.Endpoint, params.Encode())
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := io.WriteString(l.w, line)
+	return err
+}
+
+// RequestLogReader reads a log written by RequestLogWriter
+type RequestLogReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewRequestLogReader creates a RequestLogReader reading from r
+func NewRequestLogReader(r io.Reader) *RequestLogReader {
+	return &RequestLogReader{scanner: bufio.NewScanner(r)}
+}
+
+// Next returns the next entry of the log, or io.EOF at its end. Blank lines
+// are skipped.
+func (r *RequestLogReader) Next() (RequestLogEntry, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := r.scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		entry, err := parseRequestLogLine(line)
+		if err != nil {
+			return RequestLogEntry{}, fmt.Errorf("request log line %d: %w", r.line, err)
+		}
+		return entry, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return RequestLogEntry{}, err
+	}
+	return RequestLogEntry{}, io.EOF
+}
+
+// parseRequestLogLine parses a single request log line
+func parseRequestLogLine(line string) (RequestLogEntry, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 5 {
+		return RequestLogEntry{}, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidParameters, len(fields))
+	}
+
+	var numbers [3]int64
+	for i := range numbers {
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return RequestLogEntry{}, fmt.Errorf("%w: invalid number %q", ErrInvalidParameters, fields[i])
+		}
+		numbers[i] = n
+	}
+
+	params, err := url.ParseQuery(fields[4])
+	if err != nil {
+		return RequestLogEntry{}, fmt.Errorf("%w: invalid params: %v", ErrInvalidParameters, err)
+	}
+
+	return RequestLogEntry{
+		Time:     time.UnixMilli(numbers[0]),
+		Duration: time.Duration(numbers[1]) * time.Millisecond,
+		Status:   int(numbers[2]),
+		Endpoint: fields[3],
+		Params:   params,
+	}, nil
+}
+
+// Replay sends the request of a log entry again, typically to an offline
+// client (see NewOfflineClient) to evaluate caching strategies without
+// spending quota. Only the endpoint name (the part after the API version,
+// e.g. "/web/search") is kept, so logs replay against any base URL.
+func (c *Client) Replay(ctx context.Context, entry RequestLogEntry) error {
+	endpointURL := ""
+	for known, path := range endpointPaths {
+		if strings.HasSuffix(entry.Endpoint, path) {
+			endpointURL = c.endpointURL(known)
+			break
+		}
+	}
+	if endpointURL == "" {
+		if !strings.HasPrefix(entry.Endpoint, "/") {
+			return fmt.Errorf("%w: invalid endpoint %q", ErrInvalidParameters, entry.Endpoint)
+		}
+		endpointURL = strings.TrimSuffix(c.config.BaseURL, "/") + entry.Endpoint
+	}
+
+	requestURL := endpointURL + "?" + entry.Params.Encode()
+	var response struct{}
+	return c.makeRequest(ctx, http.MethodGet, requestURL, nil, nil, &response)
+}
+
+// MultiAuditor passes every record to all auditors, returning their joined errors
+func MultiAuditor(auditors ...Auditor) Auditor {
+	return AuditorFunc(func(ctx context.Context, record AuditRecord) error {
+		var errs []error
+		for _, auditor := range auditors {
+			errs = append(errs, auditor.Audit(ctx, record))
+		}
+		return errors.Join(errs...)
+	})
+}
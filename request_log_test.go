@@ -0,0 +1,148 @@
+package bravesearch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestLogRoundTrip tests writing and reading back a request log
+func TestRequestLogRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"), WithAuditLog(NewRequestLogWriter(&buf)))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang generics", &WebSearchParams{Country: "JP", Extra: url.Values{"custom": {"a", "b"}}})
+	require.NoError(t, err)
+	_, err = client.Suggest(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	reader := NewRequestLogReader(&buf)
+	entry, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "/res/v1/web/search", entry.Endpoint)
+	assert.Equal(t, "golang generics", entry.Params.Get("q"))
+	assert.Equal(t, "JP", entry.Params.Get("country"))
+	assert.Equal(t, []string{"a", "b"}, entry.Params["custom"])
+	assert.Equal(t, http.StatusOK, entry.Status)
+	assert.WithinDuration(t, time.Now(), entry.Time, time.Minute)
+
+	entry, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "/res/v1/suggest/search", entry.Endpoint)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+// TestRequestLogReaderInvalid tests reading malformed logs
+func TestRequestLogReaderInvalid(t *testing.T) {
+	reader := NewRequestLogReader(strings.NewReader("\n1700000000000\t12\t200\t/web/search\tq=go\nbroken line\n"))
+
+	entry, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Millisecond, entry.Duration)
+	assert.Equal(t, time.UnixMilli(1700000000000), entry.Time)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.ErrorContains(t, err, "line 3")
+}
+
+// TestReplayOffline tests replaying a log against the offline client
+func TestReplayOffline(t *testing.T) {
+	log := "1700000000000\t120\t200\t/res/v1/web/search\tcountry=US&q=golang\n" +
+		"1700000001000\t80\t200\t/res/v1/web/search\tcountry=US&q=unknown\n"
+
+	fixtures := fstest.MapFS{
+		"golang.json": {Data: []byte(`{"type": "search"}`)},
+	}
+	client, err := NewOfflineClient(fixtures)
+	require.NoError(t, err)
+
+	var replayed []string
+	reader := NewRequestLogReader(strings.NewReader(log))
+	for {
+		entry, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		if err := client.Replay(context.Background(), entry); err == nil {
+			replayed = append(replayed, entry.Params.Get("q"))
+		} else {
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+	}
+	assert.Equal(t, []string{"golang"}, replayed)
+}
+
+// TestReplayEndpoints tests replaying the entries of every endpoint against
+// another base URL
+func TestReplayEndpoints(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	for endpoint, endpointPath := range endpointPaths {
+		t.Run(endpoint.String(), func(t *testing.T) {
+			entry := RequestLogEntry{Endpoint: "/res/v1" + endpointPath, Params: url.Values{"q": {"gopher"}}}
+			require.NoError(t, client.Replay(context.Background(), entry))
+			assert.Equal(t, "/res/v1"+endpointPath, path)
+		})
+	}
+}
+
+// TestReplayInvalidEndpoint tests rejecting entries without a valid endpoint
+func TestReplayInvalidEndpoint(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	err = client.Replay(context.Background(), RequestLogEntry{Endpoint: "web/search", Params: url.Values{}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// TestMultiAuditor tests passing records to several auditors
+func TestMultiAuditor(t *testing.T) {
+	var calls int
+	ok := AuditorFunc(func(ctx context.Context, record AuditRecord) error {
+		calls++
+		return nil
+	})
+	failing := AuditorFunc(func(ctx context.Context, record AuditRecord) error {
+		calls++
+		return errors.New("disk full")
+	})
+
+	err := MultiAuditor(ok, failing, ok).Audit(context.Background(), AuditRecord{})
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, 3, calls)
+	assert.NoError(t, MultiAuditor(ok).Audit(context.Background(), AuditRecord{}))
+}
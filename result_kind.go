@@ -0,0 +1,108 @@
+package bravesearch
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// ResultKind is the kind of document a result links to, for routing results
+// to different handling (e.g. PDFs to a PDF parser)
+type ResultKind int
+
+// Result kinds
+const (
+	// ResultKindPage is a web page that matches no more specific kind
+	ResultKindPage ResultKind = iota
+
+	// ResultKindArticle is a news article or blog post
+	ResultKindArticle
+
+	// ResultKindForum is a forum thread or question and answer page
+	ResultKindForum
+
+	// ResultKindVideo is a video page
+	ResultKindVideo
+
+	// ResultKindPDF is a PDF document
+	ResultKindPDF
+
+	// ResultKindProduct is a product page
+	ResultKindProduct
+
+	// ResultKindCodeRepo is a source code repository
+	ResultKindCodeRepo
+)
+
+// String returns the name of the kind
+func (k ResultKind) String() string {
+	switch k {
+	case ResultKindPage:
+		return "page"
+	case ResultKindArticle:
+		return "article"
+	case ResultKindForum:
+		return "forum"
+	case ResultKindVideo:
+		return "video"
+	case ResultKindPDF:
+		return "pdf"
+	case ResultKindProduct:
+		return "product"
+	case ResultKindCodeRepo:
+		return "code_repo"
+	default:
+		return "unknown"
+	}
+}
+
+// videoDomains host mostly video pages
+var videoDomains = []string{"youtube.com", "youtu.be", "vimeo.com", "dailymotion.com", "twitch.tv", "tiktok.com"}
+
+// codeRepoDomains host source code repositories at /<owner>/<repository>
+var codeRepoDomains = []string{"github.com", "gitlab.com", "bitbucket.org", "codeberg.org", "sr.ht"}
+
+// forumDomains host mostly discussions
+var forumDomains = []string{"reddit.com", "stackoverflow.com", "stackexchange.com", "superuser.com", "serverfault.com", "askubuntu.com", "quora.com", "news.ycombinator.com", "lobste.rs"}
+
+// forumPath matches the paths of common forum software
+var forumPath = regexp.MustCompile(`(?i)/(forums?|threads?|discussions?|community|boards?|topic|t)/`)
+
+// articlePath matches the paths of articles, e.g. /2024/05/title or /blog/title
+var articlePath = regexp.MustCompile(`(?i)/((19|20)\d{2}/\d{1,2}/|(news|blog|blogs|articles?|posts?|stories|story)/.)`)
+
+// Kind classifies the document the result links to from its subtype, its
+// structured data and patterns in its URL. It is a heuristic: a page that
+// matches no pattern is ResultKindPage.
+func (r *SearchResult) Kind() ResultKind {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		u = &url.URL{}
+	}
+	hostname := r.hostname()
+
+	switch {
+	case strings.EqualFold(path.Ext(u.Path), ".pdf"):
+		return ResultKindPDF
+	case r.Product != nil || r.Subtype == "product":
+		return ResultKindProduct
+	case r.Subtype == "video" || r.Movie != nil || hostutil.MatchesAnyDomain(hostname, videoDomains):
+		return ResultKindVideo
+	case hostutil.MatchesAnyDomain(hostname, codeRepoDomains) && len(pathSegments(u.Path)) >= 2:
+		return ResultKindCodeRepo
+	case r.Subtype == "qa" || hostutil.MatchesAnyDomain(hostname, forumDomains) || forumPath.MatchString(u.Path):
+		return ResultKindForum
+	case r.Subtype == "article" || articlePath.MatchString(u.Path):
+		return ResultKindArticle
+	default:
+		return ResultKindPage
+	}
+}
+
+// pathSegments returns the non-empty segments of a URL path
+func pathSegments(p string) []string {
+	return strings.FieldsFunc(p, func(r rune) bool { return r == '/' })
+}
@@ -0,0 +1,56 @@
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResultKind tests classifying results by the kind of document
+func TestResultKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   SearchResult
+		expected ResultKind
+	}{
+		{"page", SearchResult{URL: "https://example.com/about"}, ResultKindPage},
+		{"pdf", SearchResult{URL: "https://example.com/papers/report.PDF"}, ResultKindPDF},
+		{"pdf on a forum", SearchResult{URL: "https://example.com/forum/attachment.pdf"}, ResultKindPDF},
+		{"product data", SearchResult{URL: "https://shop.example.com/item/1", Product: &Product{}}, ResultKindProduct},
+		{"product subtype", SearchResult{URL: "https://shop.example.com/item/1", Subtype: "product"}, ResultKindProduct},
+		{"video domain", SearchResult{URL: "https://www.youtube.com/watch?v=abc"}, ResultKindVideo},
+		{"video short link", SearchResult{URL: "https://youtu.be/abc"}, ResultKindVideo},
+		{"video subtype", SearchResult{URL: "https://example.com/clip", Subtype: "video"}, ResultKindVideo},
+		{"code repo", SearchResult{URL: "https://github.com/cnosuke/go-brave-search"}, ResultKindCodeRepo},
+		{"code file", SearchResult{URL: "https://gitlab.com/group/project/-/blob/main/README.md"}, ResultKindCodeRepo},
+		{"code host page", SearchResult{URL: "https://github.com/pricing"}, ResultKindPage},
+		{"forum domain", SearchResult{URL: "https://old.reddit.com/r/golang/comments/abc"}, ResultKindForum},
+		{"stack exchange", SearchResult{URL: "https://unix.stackexchange.com/questions/1"}, ResultKindForum},
+		{"forum path", SearchResult{URL: "https://community.example.com/t/slow-builds/42"}, ResultKindForum},
+		{"qa subtype", SearchResult{URL: "https://example.com/q/1", Subtype: "qa"}, ResultKindForum},
+		{"dated article", SearchResult{URL: "https://example.com/2024/05/go-release"}, ResultKindArticle},
+		{"blog post", SearchResult{URL: "https://example.com/blog/go-release"}, ResultKindArticle},
+		{"blog index", SearchResult{URL: "https://example.com/blog/"}, ResultKindPage},
+		{"article subtype", SearchResult{URL: "https://example.com/go-release", Subtype: "article"}, ResultKindArticle},
+		{
+			"meta url hostname",
+			SearchResult{URL: "https://example.com/watch", MetaURL: &MetaURL{Hostname: "vimeo.com"}},
+			ResultKindVideo,
+		},
+		{"invalid url", SearchResult{URL: "://"}, ResultKindPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.result.Kind())
+		})
+	}
+}
+
+// TestResultKindString tests the names of result kinds
+func TestResultKindString(t *testing.T) {
+	assert.Equal(t, "page", ResultKindPage.String())
+	assert.Equal(t, "pdf", ResultKindPDF.String())
+	assert.Equal(t, "code_repo", ResultKindCodeRepo.String())
+	assert.Equal(t, "unknown", ResultKind(99).String())
+}
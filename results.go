@@ -0,0 +1,74 @@
+package bravesearch
+
+// ResultStatus summarizes the outcome of a successful search
+type ResultStatus int
+
+// Result statuses
+const (
+	// ResultStatusOK means the search found results
+	ResultStatusOK ResultStatus = iota
+
+	// ResultStatusPoor means the search found results, but the API
+	// considers them poor (bad_results)
+	ResultStatusPoor
+
+	// ResultStatusEmpty means the search found nothing
+	ResultStatusEmpty
+)
+
+// String returns the name of the status
+func (s ResultStatus) String() string {
+	switch s {
+	case ResultStatusOK:
+		return "ok"
+	case ResultStatusPoor:
+		return "poor"
+	case ResultStatusEmpty:
+		return "empty"
+	default:
+		return "unknown"
+	}
+}
+
+// IsEmpty reports whether the response has no results in any section
+func (r *WebSearchResponse) IsEmpty() bool {
+	if r == nil {
+		return true
+	}
+	return (r.Web == nil || len(r.Web.Results) == 0) &&
+		(r.News == nil || len(r.News.Results) == 0) &&
+		(r.Videos == nil || len(r.Videos.Results) == 0) &&
+		(r.Locations == nil || len(r.Locations.Results) == 0) &&
+		(r.FAQ == nil || len(r.FAQ.Results) == 0) &&
+		(r.Discussions == nil || len(r.Discussions.Results) == 0) &&
+		(r.Infobox == nil || (len(r.Infobox.Results) == 0 && r.Infobox.Data == nil))
+}
+
+// Status returns the outcome of the search
+func (r *WebSearchResponse) Status() ResultStatus {
+	switch {
+	case r.IsEmpty():
+		return ResultStatusEmpty
+	case r.Query != nil && r.Query.BadResults:
+		return ResultStatusPoor
+	default:
+		return ResultStatusOK
+	}
+}
+
+// CheckResults returns an *EmptyResultsError if the response has no results,
+// and nil otherwise
+func (r *WebSearchResponse) CheckResults() error {
+	if !r.IsEmpty() {
+		return nil
+	}
+
+	err := &EmptyResultsError{Response: r}
+	if r != nil && r.Query != nil {
+		err.Query = r.Query.Original
+		err.Altered = r.Query.Altered
+		err.BadResults = r.Query.BadResults
+		err.ShouldFallback = r.Query.ShouldFallback
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResultStatus tests summarizing search outcomes
+func TestResultStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *WebSearchResponse
+		expected ResultStatus
+	}{
+		{"nil", nil, ResultStatusEmpty},
+		{"no sections", &WebSearchResponse{Query: &Query{Original: "q"}}, ResultStatusEmpty},
+		{"empty sections", &WebSearchResponse{Web: &Search{}, News: &News{}, Infobox: &GraphInfobox{}}, ResultStatusEmpty},
+		{"web results", &WebSearchResponse{Web: &Search{Results: []SearchResult{{URL: "https://example.com/"}}}}, ResultStatusOK},
+		{"news only", &WebSearchResponse{News: &News{Results: []NewsResult{{URL: "https://example.com/"}}}}, ResultStatusOK},
+		{"infobox data", &WebSearchResponse{Infobox: &GraphInfobox{Data: map[string]any{"title": "Go"}}}, ResultStatusOK},
+		{"bad results", &WebSearchResponse{
+			Query: &Query{BadResults: true},
+			Web:   &Search{Results: []SearchResult{{URL: "https://example.com/"}}},
+		}, ResultStatusPoor},
+		{"bad and empty", &WebSearchResponse{Query: &Query{BadResults: true}}, ResultStatusEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.response.Status())
+			assert.Equal(t, tt.expected == ResultStatusEmpty, tt.response.IsEmpty())
+		})
+	}
+
+	assert.Equal(t, "ok", ResultStatusOK.String())
+	assert.Equal(t, "poor", ResultStatusPoor.String())
+	assert.Equal(t, "empty", ResultStatusEmpty.String())
+	assert.Equal(t, "unknown", ResultStatus(42).String())
+}
+
+// TestCheckResults tests the error for searches without results
+func TestCheckResults(t *testing.T) {
+	full := &WebSearchResponse{Web: &Search{Results: []SearchResult{{URL: "https://example.com/"}}}}
+	assert.NoError(t, full.CheckResults())
+
+	empty := &WebSearchResponse{Query: &Query{Original: "qwzx", Altered: "quiz", BadResults: true, ShouldFallback: true}}
+	err := empty.CheckResults()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNoResults))
+	assert.True(t, IsEmptyResultsError(err))
+	assert.Equal(t, "no results (bad results, should fall back)", err.Error())
+
+	var emptyErr *EmptyResultsError
+	require.ErrorAs(t, err, &emptyErr)
+	assert.Equal(t, "qwzx", emptyErr.Query)
+	assert.Equal(t, "quiz", emptyErr.Altered)
+	assert.True(t, emptyErr.BadResults)
+	assert.True(t, emptyErr.ShouldFallback)
+	assert.Same(t, empty, emptyErr.Response)
+
+	err = (*WebSearchResponse)(nil).CheckResults()
+	assert.EqualError(t, err, "no results")
+	assert.False(t, IsEmptyResultsError(ErrInvalidResponse))
+}
+
+// TestWithEmptyResultsError tests that the option turns empty searches into errors
+func TestWithEmptyResultsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search", "query": {"original": "qwzx", "should_fallback": true}, "web": {"type": "search", "results": []}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "qwzx", nil)
+	require.NoError(t, err)
+	assert.Equal(t, ResultStatusEmpty, response.Status())
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL), WithEmptyResultsError(true))
+	require.NoError(t, err)
+
+	response, err = client.WebSearch(context.Background(), "qwzx", nil)
+	assert.Nil(t, response)
+	var emptyErr *EmptyResultsError
+	require.ErrorAs(t, err, &emptyErr)
+	assert.Equal(t, "qwzx", emptyErr.Query)
+	assert.True(t, emptyErr.ShouldFallback)
+}
@@ -0,0 +1,94 @@
+package bravesearch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// retryBudgetWindow is the window a RetryBudget limits retries in
+const retryBudgetWindow = time.Minute
+
+// RetryBudget limits the total number of retries across all the requests
+// sharing it, such as the searches of a batch, to a number per minute. While
+// a widespread upstream failure lasts, requests that find the budget spent
+// fail with their last error instead of retrying, so retry traffic does not
+// grow with the size of the batch.
+//
+// A RetryBudget is safe for concurrent use. It applies to the requests of a
+// Client configured WithRetryBudget, or to requests whose context carries it
+// (see ContextWithRetryBudget), which takes precedence.
+type RetryBudget struct {
+	max int
+	now func() time.Time
+
+	mu      sync.Mutex
+	retries []time.Time
+}
+
+// NewRetryBudget creates a RetryBudget allowing retriesPerMinute retries in
+// any one-minute window
+func NewRetryBudget(retriesPerMinute int) (*RetryBudget, error) {
+	if retriesPerMinute < 0 {
+		return nil, ErrInvalidParameters
+	}
+	return &RetryBudget{max: retriesPerMinute, now: time.Now}, nil
+}
+
+// Allow reports whether a retry may be made, spending one from the budget if so
+func (b *RetryBudget) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.expire(now)
+	if len(b.retries) >= b.max {
+		return false
+	}
+	b.retries = append(b.retries, now)
+	return true
+}
+
+// Remaining returns the number of retries left in the current window
+func (b *RetryBudget) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire(b.now())
+	return b.max - len(b.retries)
+}
+
+// expire forgets the retries made before the current window
+func (b *RetryBudget) expire(now time.Time) {
+	cutoff := now.Add(-retryBudgetWindow)
+	i := 0
+	for i < len(b.retries) && !b.retries[i].After(cutoff) {
+		i++
+	}
+	b.retries = b.retries[i:]
+}
+
+// retryBudgetKey is the context key for the retry budget
+type retryBudgetKey struct{}
+
+// ContextWithRetryBudget returns a copy of ctx carrying a RetryBudget that
+// the requests made with it share, overriding the Client's budget
+func ContextWithRetryBudget(ctx context.Context, budget *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// RetryBudgetFromContext returns the RetryBudget stored in ctx, if any
+func RetryBudgetFromContext(ctx context.Context) (*RetryBudget, bool) {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*RetryBudget)
+	return budget, ok && budget != nil
+}
+
+// allowRetry reports whether the retry budget of the request, if any, allows
+// another retry
+func (c *Client) allowRetry(ctx context.Context) bool {
+	budget, ok := RetryBudgetFromContext(ctx)
+	if !ok {
+		budget = c.config.RetryBudget
+	}
+	return budget == nil || budget.Allow()
+}
@@ -0,0 +1,82 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRetryBudget tests spending and replenishing a retry budget
+func TestRetryBudget(t *testing.T) {
+	_, err := NewRetryBudget(-1)
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	budget, err := NewRetryBudget(2)
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget.now = func() time.Time { return now }
+
+	assert.True(t, budget.Allow())
+	now = now.Add(30 * time.Second)
+	assert.True(t, budget.Allow())
+	assert.False(t, budget.Allow())
+	assert.Equal(t, 0, budget.Remaining())
+
+	// The first retry leaves the window
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 1, budget.Remaining())
+	assert.True(t, budget.Allow())
+	assert.False(t, budget.Allow())
+}
+
+// TestRetryBudgetSharedAcrossRequests tests that requests sharing a budget stop retrying once it is spent
+func TestRetryBudgetSharedAcrossRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	budget, err := NewRetryBudget(1)
+	require.NoError(t, err)
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(2), WithRetryBudget(budget))
+	require.NoError(t, err)
+
+	// The first search spends the budget on its first retry
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Later searches fail without retrying
+	for range 3 {
+		_, err = client.WebSearch(context.Background(), "golang", nil)
+		assert.True(t, IsServerError(err))
+	}
+	assert.Equal(t, int32(5), requests.Load())
+
+	// A budget in the context takes precedence
+	contextBudget, err := NewRetryBudget(5)
+	require.NoError(t, err)
+	_, err = client.WebSearch(ContextWithRetryBudget(context.Background(), contextBudget), "golang", nil)
+	assert.True(t, IsServerError(err))
+	assert.Equal(t, int32(8), requests.Load())
+	assert.Equal(t, 3, contextBudget.Remaining())
+}
+
+// TestWithRetryBudget tests the WithRetryBudget option
+func TestWithRetryBudget(t *testing.T) {
+	config := &ClientConfig{}
+	assert.Equal(t, ErrInvalidParameters, WithRetryBudget(nil)(config))
+
+	budget, err := NewRetryBudget(10)
+	require.NoError(t, err)
+	require.NoError(t, WithRetryBudget(budget)(config))
+	assert.Same(t, budget, config.RetryBudget)
+}
@@ -0,0 +1,262 @@
+package bravesearch
+
+// SanitizePolicy selects the field groups Sanitize strips from a response
+type SanitizePolicy struct {
+	// Thumbnails drops thumbnails, including those of products, recipes and
+	// movies, favicons, infobox images and preview images
+	Thumbnails bool
+
+	// Profiles drops the profiles of results and infoboxes, the authors and
+	// creators of videos and the directors and actors of movies
+	Profiles bool
+
+	// Location drops the location of the user the API echoes in Query (postal
+	// code, city, state and header country) and the distances of local
+	// results from it
+	Location bool
+
+	// Query drops the query text from Query, Provenance and Rewrite
+	Query bool
+
+	// Discussions drops forum discussions, whose untyped results may quote
+	// user posts
+	Discussions bool
+
+	// Scrubber, if set, scrubs personal data from the titles, descriptions
+	// and snippets of results, e.g. DefaultQueryScrubber()
+	Scrubber QueryScrubber
+}
+
+// DefaultSanitizePolicy strips every field group and scrubs text with
+// DefaultQueryScrubber
+func DefaultSanitizePolicy() SanitizePolicy {
+	return SanitizePolicy{
+		Thumbnails:  true,
+		Profiles:    true,
+		Location:    true,
+		Query:       true,
+		Discussions: true,
+		Scrubber:    DefaultQueryScrubber(),
+	}
+}
+
+// Sanitize returns a copy of response without the field groups of policy,
+// e.g. before it is persisted or sent to analytics. The response itself is
+// left unchanged, so cached responses can be sanitized too. A response
+// without its query text cannot be paginated.
+func Sanitize(response *WebSearchResponse, policy SanitizePolicy) *WebSearchResponse {
+	if response == nil {
+		return nil
+	}
+	s := sanitizer{policy: policy, provenances: make(map[*Provenance]*Provenance)}
+	sanitized := *response
+
+	sanitized.Provenance = s.provenance(response.Provenance)
+	if policy.Query {
+		sanitized.Rewrite = nil
+		sanitized.params = nil
+	}
+
+	if response.Query != nil {
+		query := *response.Query
+		if policy.Query {
+			query.Original, query.Altered = "", ""
+		}
+		if policy.Location {
+			query.PostalCode, query.City, query.State, query.HeaderCountry = "", "", "", ""
+		}
+		sanitized.Query = &query
+	}
+
+	if response.Discussions != nil && policy.Discussions {
+		sanitized.Discussions = nil
+	}
+
+	if response.Web != nil {
+		web := *response.Web
+		web.Results = append([]SearchResult(nil), web.Results...)
+		for i := range web.Results {
+			s.webResult(&web.Results[i])
+		}
+		sanitized.Web = &web
+	}
+
+	if response.News != nil {
+		news := *response.News
+		news.Results = append([]NewsResult(nil), news.Results...)
+		for i := range news.Results {
+			s.newsResult(&news.Results[i])
+		}
+		sanitized.News = &news
+	}
+
+	if response.Videos != nil {
+		videos := *response.Videos
+		videos.Results = append([]VideoResult(nil), videos.Results...)
+		for i := range videos.Results {
+			s.videoResult(&videos.Results[i])
+		}
+		sanitized.Videos = &videos
+	}
+
+	if response.FAQ != nil {
+		faq := *response.FAQ
+		faq.Results = append([]QA(nil), faq.Results...)
+		for i := range faq.Results {
+			qa := &faq.Results[i]
+			qa.Question, qa.Answer, qa.Title = s.scrub(qa.Question), s.scrub(qa.Answer), s.scrub(qa.Title)
+			qa.MetaURL = s.metaURL(qa.MetaURL)
+		}
+		sanitized.FAQ = &faq
+	}
+
+	if response.Infobox != nil {
+		infobox := *response.Infobox
+		infobox.Results = append([]InfoboxResult(nil), infobox.Results...)
+		for i := range infobox.Results {
+			s.infoboxResult(&infobox.Results[i])
+		}
+		sanitized.Infobox = &infobox
+	}
+
+	if response.Locations != nil {
+		locations := *response.Locations
+		locations.Results = append([]LocationResult(nil), locations.Results...)
+		for i := range locations.Results {
+			result := &locations.Results[i]
+			if policy.Thumbnails {
+				result.Thumbnail = nil
+			}
+			if policy.Location {
+				result.Distance = nil
+			}
+		}
+		sanitized.Locations = &locations
+	}
+
+	return &sanitized
+}
+
+// sanitizer applies a SanitizePolicy to the results of a response
+type sanitizer struct {
+	policy SanitizePolicy
+
+	// provenances maps provenances to their copies without the query, so
+	// results sharing a provenance still do
+	provenances map[*Provenance]*Provenance
+}
+
+// webResult sanitizes a copied web result
+func (s sanitizer) webResult(result *SearchResult) {
+	result.Title, result.Description = s.scrub(result.Title), s.scrub(result.Description)
+	result.MetaURL = s.metaURL(result.MetaURL)
+	if s.policy.Thumbnails {
+		result.Thumbnail, result.PreviewImage = nil, nil
+	}
+	if s.policy.Profiles {
+		result.Profile = nil
+	}
+	if result.Product != nil && s.policy.Thumbnails {
+		product := *result.Product
+		product.Thumbnail = nil
+		result.Product = &product
+	}
+	if result.Recipe != nil && s.policy.Thumbnails {
+		recipe := *result.Recipe
+		recipe.Thumbnail = nil
+		result.Recipe = &recipe
+	}
+	if result.Movie != nil && (s.policy.Thumbnails || s.policy.Profiles) {
+		movie := *result.Movie
+		if s.policy.Thumbnails {
+			movie.Thumbnail = nil
+		}
+		if s.policy.Profiles {
+			movie.Directors, movie.Actors = nil, nil
+		}
+		result.Movie = &movie
+	}
+	result.Provenance = s.provenance(result.Provenance)
+}
+
+// newsResult sanitizes a copied news result
+func (s sanitizer) newsResult(result *NewsResult) {
+	result.Title, result.Description = s.scrub(result.Title), s.scrub(result.Description)
+	result.ExtraSnippets = s.scrubAll(result.ExtraSnippets)
+	result.MetaURL = s.metaURL(result.MetaURL)
+	if s.policy.Thumbnails {
+		result.Thumbnail = nil
+	}
+	result.Provenance = s.provenance(result.Provenance)
+}
+
+// videoResult sanitizes a copied video result
+func (s sanitizer) videoResult(result *VideoResult) {
+	result.Title, result.Description = s.scrub(result.Title), s.scrub(result.Description)
+	result.MetaURL = s.metaURL(result.MetaURL)
+	if s.policy.Thumbnails {
+		result.Thumbnail = nil
+	}
+	if result.Video != nil && s.policy.Profiles {
+		video := *result.Video
+		video.Author, video.Creator = nil, ""
+		result.Video = &video
+	}
+	result.Provenance = s.provenance(result.Provenance)
+}
+
+// infoboxResult sanitizes a copied infobox result
+func (s sanitizer) infoboxResult(result *InfoboxResult) {
+	result.Description, result.LongDesc = s.scrub(result.Description), s.scrub(result.LongDesc)
+	if s.policy.Thumbnails {
+		result.Thumbnail, result.Images = nil, nil
+	}
+	if s.policy.Profiles {
+		result.Profiles, result.Providers = nil, nil
+	}
+}
+
+// provenance returns provenance without the query if the query is dropped
+func (s sanitizer) provenance(provenance *Provenance) *Provenance {
+	if provenance == nil || !s.policy.Query {
+		return provenance
+	}
+	sanitized, ok := s.provenances[provenance]
+	if !ok {
+		copied := *provenance
+		copied.Query = ""
+		sanitized = &copied
+		s.provenances[provenance] = sanitized
+	}
+	return sanitized
+}
+
+// metaURL returns metaURL without its favicon if thumbnails are dropped
+func (s sanitizer) metaURL(metaURL *MetaURL) *MetaURL {
+	if metaURL == nil || !s.policy.Thumbnails {
+		return metaURL
+	}
+	sanitized := *metaURL
+	sanitized.Favicon = ""
+	return &sanitized
+}
+
+// scrub scrubs text with the scrubber of the policy, if any
+func (s sanitizer) scrub(text string) string {
+	if s.policy.Scrubber == nil || text == "" {
+		return text
+	}
+	return s.policy.Scrubber.Scrub(text)
+}
+
+// scrubAll scrubs a copy of texts
+func (s sanitizer) scrubAll(texts []string) []string {
+	if s.policy.Scrubber == nil || texts == nil {
+		return texts
+	}
+	scrubbed := make([]string, len(texts))
+	for i, text := range texts {
+		scrubbed[i] = s.scrub(text)
+	}
+	return scrubbed
+}
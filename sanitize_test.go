@@ -0,0 +1,142 @@
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sanitizeResponse returns a response with personal data in every field group
+func sanitizeResponse() *WebSearchResponse {
+	provenance := &Provenance{Query: "jane doe jane@example.com", Cache: CacheMiss}
+	return &WebSearchResponse{
+		Query: &Query{Original: "jane doe jane@example.com", PostalCode: "94107", City: "San Francisco", State: "CA", HeaderCountry: "US", Country: "us"},
+		Web: &Search{Results: []SearchResult{{
+			Title:        "Jane Doe",
+			URL:          "https://example.com/jane",
+			Description:  "Contact jane@example.com or +1 415 555 0100",
+			Profile:      &Profile{Name: "Jane Doe", Img: "https://example.com/jane.jpg"},
+			MetaURL:      &MetaURL{Hostname: "example.com", Favicon: "https://example.com/favicon.ico"},
+			Thumbnail:    &Thumbnail{Src: "https://example.com/thumb.jpg"},
+			PreviewImage: []byte("png"),
+			Provenance:   provenance,
+		}, {
+			Title:   "Kettle",
+			Product: &Product{Name: "Kettle", Thumbnail: &Thumbnail{Src: "https://example.com/kettle.jpg"}},
+			Recipe:  &Recipe{Title: "Tea", Thumbnail: &Thumbnail{Src: "https://example.com/tea.jpg"}},
+			Movie: &Movie{
+				Name:      "Heat",
+				Thumbnail: &Thumbnail{Src: "https://example.com/heat.jpg"},
+				Directors: []Person{{Name: "Michael Mann", URL: "https://example.com/mann"}},
+				Actors:    []Person{{Name: "Al Pacino"}},
+				Genre:     []string{"Crime"},
+			},
+		}}},
+		News: &News{Results: []NewsResult{{
+			Title:         "Local news",
+			ExtraSnippets: []string{"Reach the editor at editor@example.com"},
+			Thumbnail:     &Thumbnail{Src: "https://example.com/news.jpg"},
+			Provenance:    provenance,
+		}}},
+		Videos: &Videos{Results: []VideoResult{{
+			Title:      "Vlog",
+			Video:      &VideoData{Creator: "Jane Doe", Author: &Profile{Name: "Jane Doe"}, Duration: "05:00"},
+			Provenance: provenance,
+		}}},
+		Infobox: &GraphInfobox{Results: []InfoboxResult{{
+			Title:    "Jane Doe",
+			Profiles: []Profile{{Name: "X", URL: "https://x.com/jane"}},
+			Images:   []Thumbnail{{Src: "https://example.com/jane.jpg"}},
+		}}},
+		Locations:   &Locations{Results: []LocationResult{{Title: "Cafe", Distance: &Distance{Value: 0.3, Units: "miles"}}}},
+		Discussions: &Discussions{Results: []any{map[string]any{"author": "jane"}}},
+		FAQ:         &FAQ{Results: []QA{{Question: "Who is Jane?", Answer: "Email jane@example.com"}}},
+		Rewrite:     &QueryRewrite{Original: "who is she", Query: "jane doe"},
+		Provenance:  provenance,
+		params:      &WebSearchParams{Query: "jane doe jane@example.com"},
+	}
+}
+
+// TestSanitize tests stripping every field group
+func TestSanitize(t *testing.T) {
+	response := sanitizeResponse()
+	sanitized := Sanitize(response, DefaultSanitizePolicy())
+
+	assert.Equal(t, &Query{Country: "us"}, sanitized.Query)
+	assert.Nil(t, sanitized.Rewrite)
+	assert.Nil(t, sanitized.Discussions)
+	assert.Empty(t, sanitized.Provenance.Query)
+	assert.Equal(t, CacheMiss, sanitized.Provenance.Cache)
+
+	require.Len(t, sanitized.Web.Results, 2)
+
+	web := sanitized.Web.Results[0]
+	assert.Equal(t, "Contact [email] or [phone]", web.Description)
+	assert.Nil(t, web.Profile)
+	assert.Nil(t, web.Thumbnail)
+	assert.Nil(t, web.PreviewImage)
+	assert.Equal(t, &MetaURL{Hostname: "example.com"}, web.MetaURL)
+	assert.Same(t, sanitized.Provenance, web.Provenance)
+
+	structured := sanitized.Web.Results[1]
+	assert.Equal(t, &Product{Name: "Kettle"}, structured.Product)
+	assert.Equal(t, &Recipe{Title: "Tea"}, structured.Recipe)
+	assert.Equal(t, &Movie{Name: "Heat", Genre: []string{"Crime"}}, structured.Movie)
+
+	news := sanitized.News.Results[0]
+	assert.Equal(t, []string{"Reach the editor at [email]"}, news.ExtraSnippets)
+	assert.Nil(t, news.Thumbnail)
+	assert.Empty(t, news.Provenance.Query)
+
+	video := sanitized.Videos.Results[0].Video
+	assert.Equal(t, &VideoData{Duration: "05:00"}, video)
+
+	assert.Nil(t, sanitized.Infobox.Results[0].Profiles)
+	assert.Nil(t, sanitized.Infobox.Results[0].Images)
+	assert.Nil(t, sanitized.Locations.Results[0].Distance)
+	assert.Equal(t, "Email [email]", sanitized.FAQ.Results[0].Answer)
+
+	// Sanitized responses do not keep the query for pagination
+	assert.Nil(t, sanitized.params)
+}
+
+// TestSanitizeLeavesResponse tests that the original response is not modified
+func TestSanitizeLeavesResponse(t *testing.T) {
+	response := sanitizeResponse()
+	Sanitize(response, DefaultSanitizePolicy())
+	assert.Equal(t, sanitizeResponse(), response)
+}
+
+// TestSanitizeFieldGroups tests stripping only the selected field groups
+func TestSanitizeFieldGroups(t *testing.T) {
+	sanitized := Sanitize(sanitizeResponse(), SanitizePolicy{Thumbnails: true})
+
+	web := sanitized.Web.Results[0]
+	assert.Nil(t, web.Thumbnail)
+	assert.Empty(t, web.MetaURL.Favicon)
+	assert.NotNil(t, web.Profile)
+	assert.Contains(t, web.Description, "jane@example.com")
+	movie := sanitized.Web.Results[1].Movie
+	assert.Nil(t, movie.Thumbnail)
+	assert.Len(t, movie.Directors, 1)
+	assert.Nil(t, sanitized.Web.Results[1].Product.Thumbnail)
+	assert.Equal(t, "jane doe jane@example.com", sanitized.Query.Original)
+	assert.Equal(t, "94107", sanitized.Query.PostalCode)
+	assert.NotNil(t, sanitized.Discussions)
+	assert.NotNil(t, sanitized.Rewrite)
+
+	sanitized = Sanitize(sanitizeResponse(), SanitizePolicy{Profiles: true})
+	movie = sanitized.Web.Results[1].Movie
+	assert.NotNil(t, movie.Thumbnail)
+	assert.Nil(t, movie.Directors)
+	assert.Nil(t, movie.Actors)
+	assert.NotNil(t, sanitized.Web.Results[1].Recipe.Thumbnail)
+
+	sanitized = Sanitize(sanitizeResponse(), SanitizePolicy{Location: true})
+	assert.Empty(t, sanitized.Query.City)
+	assert.Equal(t, "jane doe jane@example.com", sanitized.Query.Original)
+	assert.NotNil(t, sanitized.Web.Results[0].Thumbnail)
+
+	assert.Nil(t, Sanitize(nil, DefaultSanitizePolicy()))
+}
@@ -0,0 +1,125 @@
+package bravesearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// scriptLanguages maps scripts written by a single language to that
+// language. Han is handled separately because Japanese mixes it with kana.
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Thai, "th"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Armenian, "hy"},
+	{unicode.Georgian, "ka"},
+}
+
+// DetectLanguage guesses the language of text from its script. It only
+// answers for scripts that identify a language, such as kana for Japanese
+// or Hangul for Korean; text in Latin, Cyrillic or Arabic script is reported
+// as unknown.
+func DetectLanguage(text string) (string, bool) {
+	counts := make(map[string]int)
+	han, letters := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Han, r) {
+			han++
+			continue
+		}
+		for _, sl := range scriptLanguages {
+			if unicode.Is(sl.script, r) {
+				counts[sl.language]++
+				break
+			}
+		}
+	}
+
+	// Japanese text is mostly kanji, so any kana makes Han text Japanese
+	if counts["ja"] > 0 {
+		counts["ja"] += han
+	} else {
+		counts["zh"] = han
+	}
+
+	best, bestCount := "", 0
+	for language, count := range counts {
+		if count > bestCount || (count == bestCount && language < best) {
+			best, bestCount = language, count
+		}
+	}
+	if letters == 0 || bestCount*2 < letters {
+		return "", false
+	}
+	return best, true
+}
+
+// FilterByScript returns the results whose title and description are mostly
+// (at least half of their letters) written in script, e.g. unicode.Cyrillic
+func FilterByScript(results []SearchResult, script *unicode.RangeTable) []SearchResult {
+	var filtered []SearchResult
+	for _, result := range results {
+		inScript, letters := 0, 0
+		for _, r := range textutil.PlainText(result.Title + " " + result.Description) {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			letters++
+			if unicode.Is(script, r) {
+				inScript++
+			}
+		}
+		if letters > 0 && inScript*2 >= letters {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// FilterByLanguage returns the results in one of langs, compared by primary
+// language so "en" matches "en-gb". Results without a language are detected
+// with DetectLanguage from their title and description, and dropped if it
+// cannot tell.
+func FilterByLanguage(results []SearchResult, langs ...string) []SearchResult {
+	wanted := make(map[string]bool, len(langs))
+	for _, lang := range langs {
+		wanted[primaryLanguage(lang)] = true
+	}
+
+	var filtered []SearchResult
+	for _, result := range results {
+		lang := result.Language
+		if lang == "" {
+			lang, _ = DetectLanguage(textutil.PlainText(result.Title + " " + result.Description))
+		}
+		if lang != "" && wanted[primaryLanguage(lang)] {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// primaryLanguage returns the lowercase primary language subtag of a code,
+// mapping the API's "jp" to "ja"
+func primaryLanguage(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	if code == "jp" {
+		return "ja"
+	}
+	return code
+}
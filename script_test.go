@@ -0,0 +1,85 @@
+package bravesearch
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetectLanguage tests guessing languages from scripts
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+		ok       bool
+	}{
+		{"japanese", "東京の天気予報です", "ja", true},
+		{"kanji only", "東京都", "zh", true},
+		{"chinese", "北京天气预报", "zh", true},
+		{"korean", "서울 날씨", "ko", true},
+		{"thai", "สภาพอากาศกรุงเทพ", "th", true},
+		{"greek", "Καιρός Αθήνα", "el", true},
+		{"japanese with latin", "Go言語のチュートリアルと入門", "ja", true},
+		{"mostly latin", "Go programming tutorial 東京", "", false},
+		{"latin", "weather in London", "", false},
+		{"cyrillic", "погода в Москве", "", false},
+		{"no letters", "123 !?", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, ok := DetectLanguage(tt.text)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, lang)
+		})
+	}
+}
+
+// TestFilterByScript tests partitioning results by script
+func TestFilterByScript(t *testing.T) {
+	results := []SearchResult{
+		{Title: "Погода в Москве", Description: "Прогноз <strong>погоды</strong>", URL: "https://ru.example/"},
+		{Title: "Moscow weather", Description: "Forecast for Москва", URL: "https://en.example/"},
+		{Title: "東京の天気", URL: "https://ja.example/"},
+		{Title: "2024", URL: "https://digits.example/"},
+	}
+
+	urls := func(results []SearchResult) []string {
+		var urls []string
+		for _, result := range results {
+			urls = append(urls, result.URL)
+		}
+		return urls
+	}
+
+	assert.Equal(t, []string{"https://ru.example/"}, urls(FilterByScript(results, unicode.Cyrillic)))
+	assert.Equal(t, []string{"https://en.example/"}, urls(FilterByScript(results, unicode.Latin)))
+	assert.Equal(t, []string{"https://ja.example/"}, urls(FilterByScript(results, unicode.Han)))
+	assert.Empty(t, FilterByScript(results, unicode.Arabic))
+}
+
+// TestFilterByLanguage tests filtering results by language
+func TestFilterByLanguage(t *testing.T) {
+	results := []SearchResult{
+		{Title: "London weather", Language: "en", URL: "https://en.example/"},
+		{Title: "UK forecast", Language: "en-gb", URL: "https://gb.example/"},
+		{Title: "東京の天気", URL: "https://ja.example/"},
+		{Title: "서울 날씨", URL: "https://ko.example/"},
+		{Title: "Untagged page", URL: "https://unknown.example/"},
+	}
+
+	urls := func(results []SearchResult) []string {
+		var urls []string
+		for _, result := range results {
+			urls = append(urls, result.URL)
+		}
+		return urls
+	}
+
+	assert.Equal(t, []string{"https://en.example/", "https://gb.example/"}, urls(FilterByLanguage(results, "EN")))
+	assert.Equal(t, []string{"https://ja.example/"}, urls(FilterByLanguage(results, "jp")))
+	assert.Equal(t, []string{"https://ja.example/", "https://ko.example/"}, urls(FilterByLanguage(results, "ja-JP", "ko")))
+	assert.Empty(t, FilterByLanguage(results))
+}
@@ -0,0 +1,67 @@
+package bravesearch
+
+import "regexp"
+
+// QueryScrubber removes personal data from query text before it leaves the
+// process in logs or other telemetry. Implementations must be safe for
+// concurrent use.
+type QueryScrubber interface {
+	Scrub(query string) string
+}
+
+// QueryScrubberFunc is an adapter to allow the use of ordinary functions as QueryScrubbers
+type QueryScrubberFunc func(query string) string
+
+// Scrub calls f(query)
+func (f QueryScrubberFunc) Scrub(query string) string {
+	return f(query)
+}
+
+// ScrubRule replaces every match of Pattern with Replacement
+type ScrubRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// RegexpScrubber is a QueryScrubber applying its rules in order
+type RegexpScrubber struct {
+	Rules []ScrubRule
+}
+
+// Scrub implements QueryScrubber
+func (s *RegexpScrubber) Scrub(query string) string {
+	for _, rule := range s.Rules {
+		query = rule.Pattern.ReplaceAllString(query, rule.Replacement)
+	}
+	return query
+}
+
+// Default scrub rules. Card numbers go before phone numbers, which would
+// otherwise match them too.
+var (
+	// ScrubEmail matches email addresses
+	ScrubEmail = ScrubRule{
+		Pattern:     regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Replacement: "[email]",
+	}
+
+	// ScrubCreditCard matches payment card numbers, optionally grouped with spaces or dashes
+	ScrubCreditCard = ScrubRule{
+		Pattern:     regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		Replacement: "[card]",
+	}
+
+	// ScrubPhoneNumber matches phone numbers with at least 8 digits
+	ScrubPhoneNumber = ScrubRule{
+		Pattern:     regexp.MustCompile(`\+?\(?\d[\d ().-]{6,}\d`),
+		Replacement: "[phone]",
+	}
+)
+
+// DefaultQueryScrubber returns a scrubber for email addresses, payment card
+// numbers and phone numbers
+func DefaultQueryScrubber() *RegexpScrubber {
+	return &RegexpScrubber{
+		Rules: []ScrubRule{ScrubEmail, ScrubCreditCard, ScrubPhoneNumber},
+	}
+}
@@ -0,0 +1,50 @@
+package bravesearch
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultQueryScrubber tests scrubbing personal data from queries
+func TestDefaultQueryScrubber(t *testing.T) {
+	scrubber := DefaultQueryScrubber()
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"golang programming", "golang programming"},
+		{"contact jane.doe+news@example.co.jp today", "contact [email] today"},
+		{"card 4111 1111 1111 1111 stolen", "card [card] stolen"},
+		{"card 4111-1111-1111-1111", "card [card]"},
+		{"call +1 (555) 123-4567 now", "call [phone] now"},
+		{"03-1234-5678 restaurant", "[phone] restaurant"},
+		{"go 1.24 release notes", "go 1.24 release notes"},
+		{"population 2024", "population 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scrubber.Scrub(tt.query))
+		})
+	}
+}
+
+// TestRegexpScrubberCustomRules tests scrubbing with custom rules
+func TestRegexpScrubberCustomRules(t *testing.T) {
+	scrubber := &RegexpScrubber{
+		Rules: []ScrubRule{
+			{Pattern: regexp.MustCompile(`(?i)customer-\d+`), Replacement: "[customer]"},
+		},
+	}
+	assert.Equal(t, "orders for [customer]", scrubber.Scrub("orders for Customer-42"))
+}
+
+// TestQueryScrubberFunc tests the function adapter
+func TestQueryScrubberFunc(t *testing.T) {
+	scrubber := QueryScrubberFunc(strings.ToUpper)
+	assert.Equal(t, "GOLANG", scrubber.Scrub("golang"))
+}
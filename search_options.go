@@ -0,0 +1,202 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// SearchOption is a function that can be used to set web search parameters,
+// mirroring ClientOption at the request level
+type SearchOption func(*WebSearchParams) error
+
+// NewSearchParams creates WebSearchParams from options. Parameters no option
+// sets are left unset, so the client defaults apply.
+func NewSearchParams(options ...SearchOption) (*WebSearchParams, error) {
+	params := &WebSearchParams{}
+	if err := params.Apply(options...); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// Apply sets the parameters of the options on p, in order
+func (p *WebSearchParams) Apply(options ...SearchOption) error {
+	for _, option := range options {
+		if err := option(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WebSearchWithOptions performs a web search with parameters set by options:
+//
+//	client.WebSearchWithOptions(ctx, "golang", WithCount(5), WithFreshness(FreshnessWeek))
+func (c *Client) WebSearchWithOptions(ctx context.Context, query string, options ...SearchOption) (*WebSearchResponse, error) {
+	params, err := NewSearchParams(options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.WebSearch(ctx, query, params)
+}
+
+// WithCount sets the number of results, at most MaxCount
+func WithCount(count int) SearchOption {
+	return func(p *WebSearchParams) error {
+		if count < 1 || count > MaxCount {
+			return ErrInvalidParameters
+		}
+		p.Count = count
+		return nil
+	}
+}
+
+// WithOffset sets the page offset, at most MaxOffset
+func WithOffset(offset int) SearchOption {
+	return func(p *WebSearchParams) error {
+		if offset < 0 || offset > MaxOffset {
+			return ErrInvalidParameters
+		}
+		p.Offset = offset
+		return nil
+	}
+}
+
+// WithCountry sets the country to search from
+func WithCountry(country string) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.Country = country
+		return nil
+	}
+}
+
+// WithSearchLanguage sets the language of the results
+func WithSearchLanguage(lang string) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.SearchLang = lang
+		return nil
+	}
+}
+
+// WithUILanguage sets the language of the response metadata
+func WithUILanguage(lang string) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.UILang = lang
+		return nil
+	}
+}
+
+// WithSafeSearch sets the SafeSearch level (SafeSearchOff, SafeSearchModerate or SafeSearchStrict)
+func WithSafeSearch(safeSearch string) SearchOption {
+	return func(p *WebSearchParams) error {
+		switch safeSearch {
+		case SafeSearchOff, SafeSearchModerate, SafeSearchStrict:
+			p.SafeSearch = safeSearch
+			return nil
+		default:
+			return ErrInvalidParameters
+		}
+	}
+}
+
+// WithFreshness sets how recently results must have been discovered, e.g.
+// FreshnessWeek or a range from FreshnessRange
+func WithFreshness(freshness string) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.Freshness = freshness
+		return nil
+	}
+}
+
+// WithGoggle sets the Goggle (by URL or definition) to re-rank results with
+func WithGoggle(goggle string) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.Goggles = goggle
+		return nil
+	}
+}
+
+// WithResultFilter restricts the response to result types such as ResultFilterWeb and ResultFilterNews
+func WithResultFilter(filters ...string) SearchOption {
+	return func(p *WebSearchParams) error {
+		if len(filters) == 0 {
+			return ErrInvalidParameters
+		}
+		p.ResultFilter = strings.Join(filters, ",")
+		return nil
+	}
+}
+
+// WithUnits sets the measurement units ("metric" or "imperial")
+func WithUnits(units string) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.Units = units
+		return nil
+	}
+}
+
+// WithSpellcheck sets whether the query may be spellchecked
+func WithSpellcheck(spellcheck bool) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.Spellcheck = Bool(spellcheck)
+		return nil
+	}
+}
+
+// WithTextDecorations sets whether snippets may contain highlighting markup
+func WithTextDecorations(textDecorations bool) SearchOption {
+	return func(p *WebSearchParams) error {
+		p.TextDecorations = Bool(textDecorations)
+		return nil
+	}
+}
+
+// WithExtraSnippets requests up to five additional snippets per result
+func WithExtraSnippets() SearchOption {
+	return func(p *WebSearchParams) error {
+		p.ExtraSnippets = true
+		return nil
+	}
+}
+
+// WithSummary requests a summary key for the summarizer
+func WithSummary() SearchOption {
+	return func(p *WebSearchParams) error {
+		p.Summary = true
+		return nil
+	}
+}
+
+// WithRichCallback requests a callback key for rich results such as instant answers
+func WithRichCallback() SearchOption {
+	return func(p *WebSearchParams) error {
+		p.EnableRichCallback = true
+		return nil
+	}
+}
+
+// WithLocation sets the location of the user
+func WithLocation(location *Location) SearchOption {
+	return func(p *WebSearchParams) error {
+		if err := location.Validate(); err != nil {
+			return err
+		}
+		p.Location = location
+		return nil
+	}
+}
+
+// WithExtraParam adds a query parameter the library does not model yet (see WebSearchParams.Extra)
+func WithExtraParam(name string, values ...string) SearchOption {
+	return func(p *WebSearchParams) error {
+		if name == "" {
+			return ErrInvalidParameters
+		}
+		if p.Extra == nil {
+			p.Extra = url.Values{}
+		}
+		p.Extra[name] = append(p.Extra[name], values...)
+		return nil
+	}
+}
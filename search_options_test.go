@@ -0,0 +1,111 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewSearchParams tests setting parameters with options
+func TestNewSearchParams(t *testing.T) {
+	params, err := NewSearchParams(
+		WithCount(5),
+		WithOffset(2),
+		WithCountry("JP"),
+		WithSearchLanguage("jp"),
+		WithUILanguage("ja-JP"),
+		WithSafeSearch(SafeSearchStrict),
+		WithFreshness(FreshnessWeek),
+		WithGoggle("https://example.com/goggle"),
+		WithResultFilter(ResultFilterWeb, ResultFilterNews),
+		WithUnits("metric"),
+		WithSpellcheck(false),
+		WithTextDecorations(true),
+		WithExtraSnippets(),
+		WithSummary(),
+		WithRichCallback(),
+		WithLocation(&Location{City: "Tokyo"}),
+		WithExtraParam("new_feature", "a"),
+		WithExtraParam("new_feature", "b"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, &WebSearchParams{
+		Count:              5,
+		Offset:             2,
+		Country:            "JP",
+		SearchLang:         "jp",
+		UILang:             "ja-JP",
+		SafeSearch:         SafeSearchStrict,
+		Freshness:          FreshnessWeek,
+		Goggles:            "https://example.com/goggle",
+		ResultFilter:       "web,news",
+		Units:              "metric",
+		Spellcheck:         Bool(false),
+		TextDecorations:    Bool(true),
+		ExtraSnippets:      true,
+		Summary:            true,
+		EnableRichCallback: true,
+		Location:           &Location{City: "Tokyo"},
+		Extra:              url.Values{"new_feature": {"a", "b"}},
+	}, params)
+
+	// Later options win
+	params, err = NewSearchParams(WithCount(5), WithCount(10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, params.Count)
+
+	params, err = NewSearchParams()
+	require.NoError(t, err)
+	assert.Equal(t, &WebSearchParams{}, params)
+}
+
+// TestSearchOptionsInvalid tests rejecting invalid option values
+func TestSearchOptionsInvalid(t *testing.T) {
+	for name, option := range map[string]SearchOption{
+		"count zero":     WithCount(0),
+		"count too high": WithCount(MaxCount + 1),
+		"offset":         WithOffset(MaxOffset + 1),
+		"negative":       WithOffset(-1),
+		"safesearch":     WithSafeSearch("maximum"),
+		"result filter":  WithResultFilter(),
+		"extra":          WithExtraParam(""),
+	} {
+		_, err := NewSearchParams(option)
+		assert.ErrorIs(t, err, ErrInvalidParameters, name)
+	}
+
+	lat := 35.0
+	_, err := NewSearchParams(WithLocation(&Location{Latitude: &lat}))
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+}
+
+// TestWebSearchWithOptions tests searching with options on top of the client defaults
+func TestWebSearchWithOptions(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithDefaultCountry("JP"))
+	require.NoError(t, err)
+
+	_, err = client.WebSearchWithOptions(context.Background(), "golang", WithCount(5), WithFreshness(FreshnessWeek), WithGoggle("goggle"))
+	require.NoError(t, err)
+	assert.Equal(t, "golang", query.Get("q"))
+	assert.Equal(t, "5", query.Get("count"))
+	assert.Equal(t, FreshnessWeek, query.Get("freshness"))
+	assert.Equal(t, "goggle", query.Get("goggles"))
+	assert.Equal(t, "JP", query.Get("country"))
+
+	_, err = client.WebSearchWithOptions(context.Background(), "golang", WithCount(100))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
@@ -0,0 +1,113 @@
+// Package seo derives search engine optimization metrics from Brave Search
+// web search responses: keyword competitiveness, rank tracking for owned
+// domains and share of voice across competitors.
+//
+// The metrics are rough heuristics computed from the results page alone,
+// meant for keyword research pipelines rather than as authoritative scores.
+package seo
+
+import (
+	"strings"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// DefaultBrandDomains are large, authoritative sites that are hard to
+// outrank. A domain also matches its subdomains.
+var DefaultBrandDomains = []string{
+	"amazon.com", "apple.com", "bbc.co.uk", "cnn.com", "ebay.com",
+	"facebook.com", "forbes.com", "github.com", "google.com", "imdb.com",
+	"instagram.com", "linkedin.com", "microsoft.com", "nytimes.com",
+	"pinterest.com", "quora.com", "reddit.com", "stackoverflow.com",
+	"tripadvisor.com", "walmart.com", "wikipedia.org", "x.com", "yelp.com",
+	"youtube.com",
+}
+
+// Competition holds rough competitiveness metrics of a query
+type Competition struct {
+	// Results is the number of web results considered
+	Results int
+
+	// ExactTitleMatches is the number of results whose title contains the query
+	ExactTitleMatches int
+
+	// DomainDiversity is the ratio of distinct domains to results; low
+	// diversity means a few domains dominate the query
+	DomainDiversity float64
+
+	// BrandResults is the number of results from brand domains
+	BrandResults int
+
+	// Score ranges from 0 (easy) to 1 (highly competitive). It weighs the
+	// share of exact title matches, the share of brand results and the lack
+	// of domain diversity.
+	Score float64
+}
+
+// Weights of the competition score components
+const (
+	exactTitleWeight = 0.4
+	brandWeight      = 0.35
+	diversityWeight  = 0.25
+)
+
+// AnalyzeCompetition derives competitiveness metrics for query from the web
+// results of response. brandDomains are the domains counted as big brands;
+// nil means DefaultBrandDomains.
+func AnalyzeCompetition(query string, response *bravesearch.WebSearchResponse, brandDomains []string) Competition {
+	if brandDomains == nil {
+		brandDomains = DefaultBrandDomains
+	}
+
+	results := response.GetWebResults()
+	competition := Competition{Results: len(results)}
+	if len(results) == 0 {
+		return competition
+	}
+
+	normalizedQuery := normalize(query)
+	domains := make(map[string]bool)
+	for _, result := range results {
+		if normalizedQuery != "" && strings.Contains(normalize(result.Title), normalizedQuery) {
+			competition.ExactTitleMatches++
+		}
+
+		host := Hostname(result)
+		domains[host] = true
+		for _, brand := range brandDomains {
+			if MatchesDomain(host, brand) {
+				competition.BrandResults++
+				break
+			}
+		}
+	}
+
+	n := float64(len(results))
+	competition.DomainDiversity = float64(len(domains)) / n
+	competition.Score = exactTitleWeight*float64(competition.ExactTitleMatches)/n +
+		brandWeight*float64(competition.BrandResults)/n +
+		diversityWeight*(1-competition.DomainDiversity)
+	return competition
+}
+
+// Hostname returns the lowercase hostname of a result without a leading
+// "www.", taken from its meta URL or else its URL
+func Hostname(result bravesearch.SearchResult) string {
+	var metaHostname string
+	if result.MetaURL != nil {
+		metaHostname = result.MetaURL.Hostname
+	}
+	return hostutil.WithoutWWW(hostutil.Of(metaHostname, result.URL))
+}
+
+// MatchesDomain reports whether host is domain or one of its subdomains
+func MatchesDomain(host, domain string) bool {
+	return hostutil.MatchesDomain(host, domain)
+}
+
+// normalize strips markup, lowercases text and collapses whitespace
+func normalize(text string) string {
+	return strings.ToLower(textutil.PlainText(text))
+}
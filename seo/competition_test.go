@@ -0,0 +1,59 @@
+package seo
+
+import (
+	"testing"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/stretchr/testify/assert"
+)
+
+// webResponse creates a response with web results for the given title and URL pairs
+func webResponse(pairs ...string) *bravesearch.WebSearchResponse {
+	var results []bravesearch.SearchResult
+	for i := 0; i+1 < len(pairs); i += 2 {
+		results = append(results, bravesearch.SearchResult{Title: pairs[i], URL: pairs[i+1]})
+	}
+	return &bravesearch.WebSearchResponse{Web: &bravesearch.Search{Results: results}}
+}
+
+// TestAnalyzeCompetition tests competitiveness metrics
+func TestAnalyzeCompetition(t *testing.T) {
+	response := webResponse(
+		"Best <strong>Running Shoes</strong> 2024", "https://www.runnersworld.example/shoes",
+		"Running shoes - Wikipedia", "https://en.wikipedia.org/wiki/Running_shoe",
+		"Amazon.com: running shoes", "https://www.amazon.com/s?k=running+shoes",
+		"Top trainers for runners", "https://runnersworld.example/trainers",
+	)
+
+	competition := AnalyzeCompetition("running  SHOES", response, nil)
+	assert.Equal(t, 4, competition.Results)
+	assert.Equal(t, 3, competition.ExactTitleMatches)
+	assert.Equal(t, 2, competition.BrandResults)
+	assert.InDelta(t, 0.75, competition.DomainDiversity, 1e-9)
+	assert.InDelta(t, 0.4*0.75+0.35*0.5+0.25*0.25, competition.Score, 1e-9)
+
+	custom := AnalyzeCompetition("running shoes", response, []string{"runnersworld.example"})
+	assert.Equal(t, 2, custom.BrandResults)
+
+	empty := AnalyzeCompetition("running shoes", &bravesearch.WebSearchResponse{}, nil)
+	assert.Equal(t, Competition{}, empty)
+}
+
+// TestHostname tests extracting result hostnames
+func TestHostname(t *testing.T) {
+	assert.Equal(t, "go.dev", Hostname(bravesearch.SearchResult{URL: "https://WWW.Go.dev/doc"}))
+	assert.Equal(t, "pkg.go.dev", Hostname(bravesearch.SearchResult{
+		URL:     "https://example.com/",
+		MetaURL: &bravesearch.MetaURL{Hostname: "pkg.go.dev"},
+	}))
+	assert.Equal(t, "", Hostname(bravesearch.SearchResult{URL: "://bad"}))
+}
+
+// TestMatchesDomain tests domain and subdomain matching
+func TestMatchesDomain(t *testing.T) {
+	assert.True(t, MatchesDomain("example.com", "example.com"))
+	assert.True(t, MatchesDomain("blog.example.com", "www.example.com"))
+	assert.True(t, MatchesDomain("Example.COM.", "example.com"))
+	assert.False(t, MatchesDomain("notexample.com", "example.com"))
+	assert.False(t, MatchesDomain("example.com", ""))
+}
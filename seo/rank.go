@@ -0,0 +1,94 @@
+package seo
+
+import (
+	"sort"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// RankOf returns the 1-based position of the first web result of response
+// from domain or one of its subdomains, and the result itself. ok is false
+// if no result is from domain.
+func RankOf(response *bravesearch.WebSearchResponse, domain string) (position int, result *bravesearch.SearchResult, ok bool) {
+	results := response.GetWebResults()
+	for i := range results {
+		if MatchesDomain(Hostname(results[i]), domain) {
+			return i + 1, &results[i], true
+		}
+	}
+	return 0, nil, false
+}
+
+// Rank is the position of a domain for one query
+type Rank struct {
+	Query string
+
+	// Position is 1-based, or 0 if the domain does not rank
+	Position int
+
+	// URL and Title are those of the ranking result, if any
+	URL   string
+	Title string
+}
+
+// RankReport is the ranking of a domain across a keyword set
+type RankReport struct {
+	Domain string
+
+	// Ranks are sorted by query
+	Ranks []Rank
+}
+
+// NewRankReport ranks domain in responses, which map queries to their
+// search responses
+func NewRankReport(domain string, responses map[string]*bravesearch.WebSearchResponse) *RankReport {
+	report := &RankReport{Domain: domain}
+	for _, query := range sortedQueries(responses) {
+		rank := Rank{Query: query}
+		if position, result, ok := RankOf(responses[query], domain); ok {
+			rank.Position = position
+			rank.URL = result.URL
+			rank.Title = textutil.PlainText(result.Title)
+		}
+		report.Ranks = append(report.Ranks, rank)
+	}
+	return report
+}
+
+// Ranked returns the number of queries the domain ranks for
+func (r *RankReport) Ranked() int {
+	ranked := 0
+	for _, rank := range r.Ranks {
+		if rank.Position > 0 {
+			ranked++
+		}
+	}
+	return ranked
+}
+
+// AveragePosition returns the average position over the queries the domain
+// ranks for, or 0 if it ranks for none
+func (r *RankReport) AveragePosition() float64 {
+	total, ranked := 0, 0
+	for _, rank := range r.Ranks {
+		if rank.Position > 0 {
+			total += rank.Position
+			ranked++
+		}
+	}
+	if ranked == 0 {
+		return 0
+	}
+	return float64(total) / float64(ranked)
+}
+
+// sortedQueries returns the queries of responses in order
+func sortedQueries(responses map[string]*bravesearch.WebSearchResponse) []string {
+	queries := make([]string, 0, len(responses))
+	for query := range responses {
+		queries = append(queries, query)
+	}
+	sort.Strings(queries)
+	return queries
+}
@@ -0,0 +1,61 @@
+package seo
+
+import (
+	"testing"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRankOf tests finding the position of a domain
+func TestRankOf(t *testing.T) {
+	response := webResponse(
+		"Go", "https://go.dev/",
+		"Go docs", "https://pkg.go.dev/std",
+		"Example", "https://www.example.com/go",
+		"Example again", "https://example.com/golang",
+	)
+
+	position, result, ok := RankOf(response, "example.com")
+	require.True(t, ok)
+	assert.Equal(t, 3, position)
+	assert.Equal(t, "https://www.example.com/go", result.URL)
+
+	position, _, ok = RankOf(response, "pkg.go.dev")
+	assert.True(t, ok)
+	assert.Equal(t, 2, position)
+
+	position, result, ok = RankOf(response, "rust-lang.org")
+	assert.False(t, ok)
+	assert.Zero(t, position)
+	assert.Nil(t, result)
+
+	_, _, ok = RankOf(nil, "go.dev")
+	assert.False(t, ok)
+}
+
+// TestRankReport tests ranking a domain across queries
+func TestRankReport(t *testing.T) {
+	responses := map[string]*bravesearch.WebSearchResponse{
+		"golang":         webResponse("<strong>Go</strong>", "https://go.dev/"),
+		"go tutorial":    webResponse("Tour", "https://tour.example/", "Learn Go", "https://go.dev/learn"),
+		"rust":           webResponse("Rust", "https://rust-lang.org/"),
+		"go concurrency": webResponse("Blog", "https://blog.example/", "Other", "https://other.example/", "Effective Go", "https://go.dev/doc/effective_go"),
+	}
+
+	report := NewRankReport("go.dev", responses)
+	assert.Equal(t, "go.dev", report.Domain)
+	assert.Equal(t, []Rank{
+		{Query: "go concurrency", Position: 3, URL: "https://go.dev/doc/effective_go", Title: "Effective Go"},
+		{Query: "go tutorial", Position: 2, URL: "https://go.dev/learn", Title: "Learn Go"},
+		{Query: "golang", Position: 1, URL: "https://go.dev/", Title: "Go"},
+		{Query: "rust"},
+	}, report.Ranks)
+	assert.Equal(t, 3, report.Ranked())
+	assert.InDelta(t, 2.0, report.AveragePosition(), 1e-9)
+
+	empty := NewRankReport("go.dev", nil)
+	assert.Zero(t, empty.Ranked())
+	assert.Zero(t, empty.AveragePosition())
+}
@@ -0,0 +1,85 @@
+package seo
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+)
+
+// ShareOfVoiceReport is the weighted visibility of competing domains across a keyword set
+type ShareOfVoiceReport struct {
+	// Queries is the number of queries in the keyword set
+	Queries int
+
+	// Domains are in the order they were given
+	Domains []DomainVisibility
+}
+
+// DomainVisibility is the visibility of one domain across a keyword set
+type DomainVisibility struct {
+	Domain string
+
+	// Ranked is the number of queries the domain ranks for
+	Ranked int
+
+	// Visibility is the average position weight of the domain over all
+	// queries, from 0 (never ranks) to 1 (always first). A position p
+	// weighs 1/p, so top positions dominate.
+	Visibility float64
+
+	// Share is the domain's part of the total visibility of all domains
+	Share float64
+}
+
+// ShareOfVoice computes the visibility of domains across responses, which
+// map queries to their search responses. Only the best position of each
+// domain per query counts.
+func ShareOfVoice(responses map[string]*bravesearch.WebSearchResponse, domains []string) *ShareOfVoiceReport {
+	report := &ShareOfVoiceReport{Queries: len(responses)}
+
+	total := 0.0
+	for _, domain := range domains {
+		visibility := DomainVisibility{Domain: domain}
+		for _, query := range sortedQueries(responses) {
+			if position, _, ok := RankOf(responses[query], domain); ok {
+				visibility.Ranked++
+				visibility.Visibility += 1 / float64(position)
+			}
+		}
+		if report.Queries > 0 {
+			visibility.Visibility /= float64(report.Queries)
+		}
+		total += visibility.Visibility
+		report.Domains = append(report.Domains, visibility)
+	}
+
+	if total > 0 {
+		for i := range report.Domains {
+			report.Domains[i].Share = report.Domains[i].Visibility / total
+		}
+	}
+	return report
+}
+
+// WriteCSV writes the report as CSV with a header row of
+// domain, ranked, visibility and share
+func (r *ShareOfVoiceReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"domain", "ranked", "visibility", "share"}); err != nil {
+		return err
+	}
+	for _, domain := range r.Domains {
+		if err := writer.Write([]string{
+			domain.Domain,
+			strconv.Itoa(domain.Ranked),
+			strconv.FormatFloat(domain.Visibility, 'f', 4, 64),
+			strconv.FormatFloat(domain.Share, 'f', 4, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,66 @@
+package seo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	bravesearch "github.com/cnosuke/go-brave-search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShareOfVoice tests weighted visibility across a keyword set
+func TestShareOfVoice(t *testing.T) {
+	responses := map[string]*bravesearch.WebSearchResponse{
+		"running shoes": webResponse(
+			"A", "https://shop-a.example/",
+			"B", "https://shop-b.example/",
+			"A again", "https://shop-a.example/sale",
+		),
+		"trail shoes": webResponse(
+			"B", "https://shop-b.example/trail",
+			"C", "https://shop-c.example/",
+		),
+		"socks": webResponse("D", "https://shop-d.example/"),
+		"laces": webResponse("A", "https://blog.shop-a.example/laces"),
+	}
+
+	report := ShareOfVoice(responses, []string{"shop-a.example", "shop-b.example", "shop-z.example"})
+	assert.Equal(t, 4, report.Queries)
+	require.Len(t, report.Domains, 3)
+
+	// shop-a: positions 1 and 1; shop-b: positions 2 and 1
+	a, b, z := report.Domains[0], report.Domains[1], report.Domains[2]
+	assert.Equal(t, DomainVisibility{Domain: "shop-z.example"}, z)
+	assert.Equal(t, 2, a.Ranked)
+	assert.InDelta(t, 2.0/4, a.Visibility, 1e-9)
+	assert.Equal(t, 2, b.Ranked)
+	assert.InDelta(t, 1.5/4, b.Visibility, 1e-9)
+	assert.InDelta(t, 2.0/3.5, a.Share, 1e-9)
+	assert.InDelta(t, 1.5/3.5, b.Share, 1e-9)
+
+	var csv strings.Builder
+	require.NoError(t, report.WriteCSV(&csv))
+	assert.Equal(t, "domain,ranked,visibility,share\n"+
+		"shop-a.example,2,0.5000,0.5714\n"+
+		"shop-b.example,2,0.3750,0.4286\n"+
+		"shop-z.example,0,0.0000,0.0000\n", csv.String())
+
+	empty := ShareOfVoice(nil, []string{"shop-a.example"})
+	assert.Equal(t, []DomainVisibility{{Domain: "shop-a.example"}}, empty.Domains)
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+// Write implements io.Writer
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestShareOfVoiceCSVError tests that write errors are returned
+func TestShareOfVoiceCSVError(t *testing.T) {
+	report := &ShareOfVoiceReport{Domains: []DomainVisibility{{Domain: "example.com"}}}
+	assert.EqualError(t, report.WriteCSV(failingWriter{}), "disk full")
+}
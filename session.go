@@ -0,0 +1,234 @@
+package bravesearch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultSessionCacheTTL is how long a Session caches responses by default
+const DefaultSessionCacheTTL = 5 * time.Minute
+
+// Session wraps a Client with sticky per-user search defaults (country,
+// languages, SafeSearch and location) and a session-scoped response cache,
+// so application code doesn't have to thread them through every call.
+//
+// Parameters passed to a search take precedence over session defaults, which
+// take precedence over client defaults. Cached responses are shared between
+// callers and must not be modified; their Provenance reports CacheHit. A
+// Session is safe for concurrent use.
+type Session struct {
+	client   *Client
+	defaults WebSearchParams
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]sessionCacheEntry
+}
+
+// sessionCacheEntry is a cached response and its expiry time
+type sessionCacheEntry struct {
+	response  *WebSearchResponse
+	expiresAt time.Time
+}
+
+// SessionOption is a function that can be used to configure a Session
+type SessionOption func(*Session) error
+
+// NewSession creates a Session using the client. The session cache is
+// disabled when the client is in no-retention mode.
+func (c *Client) NewSession(options ...SessionOption) (*Session, error) {
+	session := &Session{
+		client:   c,
+		cacheTTL: DefaultSessionCacheTTL,
+		now:      time.Now,
+		cache:    make(map[string]sessionCacheEntry),
+	}
+
+	for _, option := range options {
+		if err := option(session); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.config.NoRetention {
+		session.cacheTTL = 0
+	}
+
+	return session, nil
+}
+
+// WithSessionCountry sets the session country
+func WithSessionCountry(country string) SessionOption {
+	return func(s *Session) error {
+		s.defaults.Country = country
+		return nil
+	}
+}
+
+// WithSessionSearchLanguage sets the session search language
+func WithSessionSearchLanguage(lang string) SessionOption {
+	return func(s *Session) error {
+		s.defaults.SearchLang = lang
+		return nil
+	}
+}
+
+// WithSessionUILanguage sets the session UI language
+func WithSessionUILanguage(lang string) SessionOption {
+	return func(s *Session) error {
+		s.defaults.UILang = lang
+		return nil
+	}
+}
+
+// WithSessionSafeSearch sets the session SafeSearch level
+func WithSessionSafeSearch(safeSearch string) SessionOption {
+	return func(s *Session) error {
+		s.defaults.SafeSearch = safeSearch
+		return nil
+	}
+}
+
+// WithSessionLocation sets the session user location
+func WithSessionLocation(location *Location) SessionOption {
+	return func(s *Session) error {
+		if err := location.Validate(); err != nil {
+			return err
+		}
+		s.defaults.Location = location
+		return nil
+	}
+}
+
+// WithSessionCacheTTL sets how long responses are cached; zero disables the cache
+func WithSessionCacheTTL(ttl time.Duration) SessionOption {
+	return func(s *Session) error {
+		if ttl < 0 {
+			return ErrInvalidParameters
+		}
+		s.cacheTTL = ttl
+		return nil
+	}
+}
+
+// WebSearch performs a web search with the session defaults applied,
+// answering from the session cache when possible
+func (s *Session) WebSearch(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+	searchParams := s.applyDefaults(params)
+
+	key, err := s.cacheKey(query, searchParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if response, ok := s.cached(key); ok {
+		return response.cacheHit(), nil
+	}
+
+	response, err := s.client.WebSearch(ctx, query, searchParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache a copy, so callers changing the response do not change later hits
+	s.store(key, response.copied())
+	return response, nil
+}
+
+// ClearCache drops every cached response
+func (s *Session) ClearCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.cache)
+}
+
+// applyDefaults returns a copy of params with unset fields taken from the session defaults
+func (s *Session) applyDefaults(params *WebSearchParams) *WebSearchParams {
+	searchParams := &WebSearchParams{}
+	if params != nil {
+		*searchParams = *params
+	}
+
+	if searchParams.Country == "" {
+		searchParams.Country = s.defaults.Country
+	}
+	if searchParams.SearchLang == "" {
+		searchParams.SearchLang = s.defaults.SearchLang
+	}
+	if searchParams.UILang == "" {
+		searchParams.UILang = s.defaults.UILang
+	}
+	if searchParams.SafeSearch == "" {
+		searchParams.SafeSearch = s.defaults.SafeSearch
+	}
+	if searchParams.Location == nil {
+		searchParams.Location = s.defaults.Location
+	}
+
+	return searchParams
+}
+
+// cacheKey identifies a search by its request URL and location headers
+func (s *Session) cacheKey(query string, params *WebSearchParams) (string, error) {
+	keyParams := *params
+	keyParams.Query = NormalizeQuery(query, s.client.config.QueryNormalization)
+
+	requestURL, err := s.client.buildRequestURL(EndpointWebSearch, &keyParams)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := params.Location.Headers()
+	if err != nil {
+		return "", err
+	}
+
+	key := requestURL
+	for _, name := range []string{
+		HeaderLocLatitude, HeaderLocLongitude, HeaderLocTimezone, HeaderLocCity,
+		HeaderLocState, HeaderLocStateName, HeaderLocCountry, HeaderLocPostalCode,
+	} {
+		key += "\n" + header.Get(name)
+	}
+	return key, nil
+}
+
+// cached returns the unexpired cached response for key, if any
+func (s *Session) cached(key string) (*WebSearchResponse, bool) {
+	if s.cacheTTL == 0 {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !s.now().Before(entry.expiresAt) {
+		delete(s.cache, key)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+// store caches response under key, evicting expired entries
+func (s *Session) store(key string, response *WebSearchResponse) {
+	if s.cacheTTL == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, entry := range s.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = sessionCacheEntry{response: response, expiresAt: now.Add(s.cacheTTL)}
+}
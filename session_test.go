@@ -0,0 +1,156 @@
+package bravesearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionDefaults tests that session defaults are applied between params and client defaults
+func TestSessionDefaults(t *testing.T) {
+	server := newMockServer(t, nil)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	session, err := client.NewSession(
+		WithSessionCountry("JP"),
+		WithSessionSearchLanguage("jp"),
+		WithSessionSafeSearch(SafeSearchStrict),
+		WithSessionLocation(NewLocation().WithCity("Tokyo")),
+	)
+	require.NoError(t, err)
+
+	_, err = session.WebSearch(context.Background(), "ramen", nil)
+	require.NoError(t, err)
+
+	query := server.lastRequest().URL.Query()
+	assert.Equal(t, "JP", query.Get("country"))
+	assert.Equal(t, "jp", query.Get("search_lang"))
+	assert.Equal(t, DefaultUILang, query.Get("ui_lang")) // client default
+	assert.Equal(t, SafeSearchStrict, query.Get("safesearch"))
+	assert.Equal(t, "Tokyo", server.lastRequest().Header.Get(HeaderLocCity))
+
+	// Explicit params win over session defaults
+	_, err = session.WebSearch(context.Background(), "ramen", &WebSearchParams{Country: "US"})
+	require.NoError(t, err)
+	assert.Equal(t, "US", server.lastRequest().URL.Query().Get("country"))
+}
+
+// TestSessionCache tests the session-scoped response cache
+func TestSessionCache(t *testing.T) {
+	server := newMockServer(t, nil)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	session, err := client.NewSession(WithSessionCacheTTL(time.Minute))
+	require.NoError(t, err)
+
+	now := time.Now()
+	session.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	first, err := session.WebSearch(ctx, "ramen", nil)
+	require.NoError(t, err)
+
+	// Same search is served from cache
+	second, err := session.WebSearch(ctx, "ramen", nil)
+	require.NoError(t, err)
+	assert.Equal(t, first.Web.Results[0].Title, second.Web.Results[0].Title)
+	assert.Equal(t, CacheMiss, first.Provenance.Cache)
+	assert.Equal(t, CacheHit, second.Provenance.Cache)
+	assert.Equal(t, CacheHit, second.Web.Results[0].Provenance.Cache)
+	assert.Equal(t, first.Provenance.RetrievedAt, second.Provenance.RetrievedAt)
+	assert.Equal(t, 1, server.requestCount(""))
+
+	// Differently composed forms of the same query share a cache entry
+	_, err = session.WebSearch(ctx, "\u304b\u3099\u3063\u3053\u3046", nil)
+	require.NoError(t, err)
+	_, err = session.WebSearch(ctx, "\u304c\u3063\u3053\u3046", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.requestCount(""))
+
+	// Different params or locations are cached separately
+	_, err = session.WebSearch(ctx, "ramen", &WebSearchParams{Count: 5})
+	require.NoError(t, err)
+	_, err = session.WebSearch(ctx, "ramen", &WebSearchParams{Location: NewLocation().WithCity("Osaka")})
+	require.NoError(t, err)
+	assert.Equal(t, 4, server.requestCount(""))
+
+	// Entries expire
+	now = now.Add(2 * time.Minute)
+	_, err = session.WebSearch(ctx, "ramen", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, server.requestCount(""))
+
+	// Clearing the cache forces a new request
+	session.ClearCache()
+	_, err = session.WebSearch(ctx, "ramen", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 6, server.requestCount(""))
+}
+
+// TestSessionCacheCopies tests that changing a response does not change the
+// cached copy
+func TestSessionCacheCopies(t *testing.T) {
+	server := newMockServer(t, nil)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	session, err := client.NewSession()
+	require.NoError(t, err)
+
+	first, err := session.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	first.Web.Results[0].Title = "Changed"
+	first.Provenance.Query = "changed"
+
+	second, err := session.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.requestCount(""))
+	assert.Equal(t, "Go", second.Web.Results[0].Title)
+	assert.Equal(t, "golang", second.Provenance.Query)
+	assert.Equal(t, CacheHit, second.Provenance.Cache)
+	assert.Equal(t, CacheMiss, first.Provenance.Cache)
+}
+
+// TestSessionCacheDisabled tests that nothing is cached when disabled or in no-retention mode
+func TestSessionCacheDisabled(t *testing.T) {
+	server := newMockServer(t, nil)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+	session, err := client.NewSession(WithSessionCacheTTL(0))
+	require.NoError(t, err)
+
+	noRetentionClient, err := NewClient("test-api-key", WithBaseURL(server.URL), WithNoRetention(true))
+	require.NoError(t, err)
+	noRetentionSession, err := noRetentionClient.NewSession()
+	require.NoError(t, err)
+
+	for _, s := range []*Session{session, noRetentionSession} {
+		requests := server.requestCount("")
+		for i := 0; i < 2; i++ {
+			_, err := s.WebSearch(context.Background(), "ramen", nil)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, requests+2, server.requestCount(""))
+		assert.Empty(t, s.cache)
+	}
+}
+
+// TestSessionOptionErrors tests invalid session options
+func TestSessionOptionErrors(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.NewSession(WithSessionCacheTTL(-time.Second))
+	assert.Equal(t, ErrInvalidParameters, err)
+
+	_, err = client.NewSession(WithSessionLocation(NewLocation().WithLatLong(100, 0)))
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+}
@@ -0,0 +1,221 @@
+package bravesearch
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Searcher performs web searches. Client and Session are Searchers.
+type Searcher interface {
+	WebSearch(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error)
+}
+
+// SearcherFunc is an adapter to allow the use of ordinary functions as Searchers
+type SearcherFunc func(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error)
+
+// WebSearch calls f(ctx, query, params)
+func (f SearcherFunc) WebSearch(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+	return f(ctx, query, params)
+}
+
+// ShadowSampler decides which queries are mirrored to the shadow Searcher.
+// Implementations must be safe for concurrent use.
+type ShadowSampler interface {
+	Sample(query string) bool
+}
+
+// ShadowSamplerFunc is an adapter to allow the use of ordinary functions as ShadowSamplers
+type ShadowSamplerFunc func(query string) bool
+
+// Sample calls f(query)
+func (f ShadowSamplerFunc) Sample(query string) bool {
+	return f(query)
+}
+
+// SampleRate returns a ShadowSampler mirroring a random share of queries,
+// from 0 (none) to 1 (all)
+func SampleRate(rate float64) ShadowSampler {
+	return ShadowSamplerFunc(func(string) bool {
+		return rand.Float64() < rate
+	})
+}
+
+// ShadowReport compares the shadow searches with the searches they mirrored
+type ShadowReport struct {
+	// Mirrored is the number of shadow searches completed, Errors the number
+	// of those that failed
+	Mirrored int `json:"mirrored"`
+	Errors   int `json:"errors"`
+
+	// MeanOverlap is the mean share of web result URLs both searches
+	// returned (the Jaccard index), 1 when both returned none
+	MeanOverlap float64 `json:"mean_overlap"`
+
+	// TopMatches is the number of searches whose first web results match
+	TopMatches int `json:"top_matches"`
+
+	// MeanLatencyDelta is the mean latency of the shadow searches minus that
+	// of the searches they mirrored
+	MeanLatencyDelta time.Duration `json:"mean_latency_delta"`
+}
+
+// shadowTracker mirrors searches to the shadow Searcher and accumulates the
+// comparisons
+type shadowTracker struct {
+	mu           sync.Mutex
+	report       ShadowReport
+	overlap      float64
+	latencyDelta time.Duration
+
+	// inFlight is the number of shadow searches running; idle is closed
+	// when it drops to zero
+	inFlight int
+	idle     chan struct{}
+}
+
+// start records a shadow search starting
+func (t *shadowTracker) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.inFlight == 0 {
+		t.idle = make(chan struct{})
+	}
+	t.inFlight++
+}
+
+// done records a shadow search ending, with t.mu held
+func (t *shadowTracker) done() {
+	t.inFlight--
+	if t.inFlight == 0 {
+		close(t.idle)
+	}
+}
+
+// shadowContextKey marks the context of shadow searches, so they are not
+// mirrored again when the shadow Searcher mirrors to the client
+type shadowContextKey struct{}
+
+// mirror runs the search of query with params against the shadow Searcher
+// in the background, if one is configured and the sampler picks the query,
+// and compares it with the primary response. Failures only show in the
+// ShadowReport. Nothing is mirrored in no-retention mode, as the query would
+// outlive the call.
+func (c *Client) mirror(ctx context.Context, query string, params *WebSearchParams, primary *WebSearchResponse, primaryLatency time.Duration) {
+	if c.config.ShadowSearcher == nil || c.config.NoRetention || ctx.Value(shadowContextKey{}) != nil || !c.config.ShadowSampler.Sample(query) {
+		return
+	}
+
+	// Copy what the caller may change once the primary search returns
+	primaryURLs := webResultURLs(primary)
+	var shadowParams *WebSearchParams
+	if params != nil {
+		copied := *params
+		shadowParams = &copied
+	}
+
+	// The shadow search outlives the primary one, but not indefinitely unless
+	// the client has no timeout
+	ctx = context.WithValue(context.WithoutCancel(ctx), shadowContextKey{}, true)
+	cancel := context.CancelFunc(func() {})
+	if c.config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+	}
+
+	c.shadow.start()
+	go func() {
+		defer cancel()
+
+		start := time.Now()
+		response, err := c.config.ShadowSearcher.WebSearch(ctx, query, shadowParams)
+		latency := time.Since(start)
+
+		c.shadow.mu.Lock()
+		defer c.shadow.mu.Unlock()
+		defer c.shadow.done()
+		report := &c.shadow.report
+		report.Mirrored++
+		if err != nil {
+			report.Errors++
+			return
+		}
+
+		shadowURLs := webResultURLs(response)
+		c.shadow.overlap += urlOverlap(primaryURLs, shadowURLs)
+		c.shadow.latencyDelta += latency - primaryLatency
+		if len(primaryURLs) > 0 && len(shadowURLs) > 0 && primaryURLs[0] == shadowURLs[0] {
+			report.TopMatches++
+		}
+	}()
+}
+
+// WaitShadow waits until the shadow searches in flight have completed, so
+// ShadowReport covers every mirrored search, e.g. before shutting down. It
+// returns ctx.Err() if ctx is done first. Searches mirrored while it waits
+// are waited for too.
+func (c *Client) WaitShadow(ctx context.Context) error {
+	c.shadow.mu.Lock()
+	if c.shadow.inFlight == 0 {
+		c.shadow.mu.Unlock()
+		return nil
+	}
+	idle := c.shadow.idle
+	c.shadow.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ShadowReport compares the shadow searches completed so far (see
+// WithShadowClient and WaitShadow) with the searches they mirrored
+func (c *Client) ShadowReport() ShadowReport {
+	c.shadow.mu.Lock()
+	defer c.shadow.mu.Unlock()
+
+	report := c.shadow.report
+	if compared := report.Mirrored - report.Errors; compared > 0 {
+		report.MeanOverlap = c.shadow.overlap / float64(compared)
+		report.MeanLatencyDelta = c.shadow.latencyDelta / time.Duration(compared)
+	}
+	return report
+}
+
+// webResultURLs returns the URLs of the web results of response
+func webResultURLs(response *WebSearchResponse) []string {
+	var urls []string
+	for _, result := range response.GetWebResults() {
+		urls = append(urls, result.URL)
+	}
+	return urls
+}
+
+// urlOverlap returns the Jaccard index of two URL lists, 1 if both are empty
+func urlOverlap(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, u := range a {
+		set[u] = true
+	}
+	union := len(set)
+	both := 0
+	seen := make(map[string]bool, len(b))
+	for _, u := range b {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		if set[u] {
+			both++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 1
+	}
+	return float64(both) / float64(union)
+}
@@ -0,0 +1,242 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shadowResponse answers with web results for urls
+func shadowResponse(urls ...string) func(r *http.Request) mockResponse {
+	body := mockWebResults(urls...)
+	return func(r *http.Request) mockResponse {
+		return mockResponse{Body: body}
+	}
+}
+
+// Clients and sessions can both serve as shadows
+var (
+	_ Searcher = (*Client)(nil)
+	_ Searcher = (*Session)(nil)
+)
+
+// TestShadowClient tests mirroring searches and comparing the results
+func TestShadowClient(t *testing.T) {
+	primaryServer := newMockServer(t, shadowResponse("https://a.example/", "https://b.example/", "https://c.example/"))
+	shadowServer := newMockServer(t, shadowResponse("https://a.example/", "https://c.example/", "https://d.example/"))
+
+	shadow, err := NewClient("test-api-key", WithBaseURL(shadowServer.URL), WithAPIVersion("2024-01-01"))
+	require.NoError(t, err)
+	client, err := NewClient("test-api-key", WithBaseURL(primaryServer.URL), WithShadowClient(shadow, SampleRate(1)))
+	require.NoError(t, err)
+	assert.Equal(t, "*bravesearch.Client", client.Config().ShadowSearcher)
+
+	for range 2 {
+		response, err := client.WebSearch(context.Background(), "golang", nil)
+		require.NoError(t, err)
+		assert.Len(t, response.Web.Results, 3)
+	}
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	assert.Equal(t, 2, primaryServer.requestCount(""))
+	assert.Equal(t, 2, shadowServer.requestCount(""))
+
+	report := client.ShadowReport()
+	assert.Equal(t, 2, report.Mirrored)
+	assert.Zero(t, report.Errors)
+	assert.Equal(t, 2, report.TopMatches)
+	assert.InDelta(t, 0.5, report.MeanOverlap, 0.001) // a and c of a, b, c and d
+}
+
+// TestShadowClientFailures tests that shadow failures never reach the primary search
+func TestShadowClientFailures(t *testing.T) {
+	server := newMockServer(t, shadowResponse("https://a.example/"))
+
+	failing := SearcherFunc(func(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+		return nil, errors.New("shadow unavailable")
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithShadowClient(failing, SampleRate(1)))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	report := client.ShadowReport()
+	assert.Equal(t, ShadowReport{Mirrored: 1, Errors: 1}, report)
+}
+
+// TestShadowClientSampler tests mirroring only sampled queries
+func TestShadowClientSampler(t *testing.T) {
+	primaryServer := newMockServer(t, shadowResponse("https://a.example/"))
+	shadowServer := newMockServer(t, shadowResponse("https://a.example/"))
+
+	shadow, err := NewClient("test-api-key", WithBaseURL(shadowServer.URL))
+	require.NoError(t, err)
+	sampler := ShadowSamplerFunc(func(query string) bool { return query == "mirror me" })
+	client, err := NewClient("test-api-key", WithBaseURL(primaryServer.URL), WithShadowClient(shadow, sampler))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	_, err = client.WebSearch(context.Background(), "mirror me", &WebSearchParams{Count: 5})
+	require.NoError(t, err)
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	assert.Equal(t, 1, shadowServer.requestCount(""))
+	assert.Equal(t, 1, client.ShadowReport().Mirrored)
+}
+
+// TestShadowClientCanceled tests that shadow searches outlive the primary context
+func TestShadowClientCanceled(t *testing.T) {
+	server := newMockServer(t, shadowResponse("https://a.example/"))
+
+	release := make(chan struct{})
+	var shadowErr atomic.Value
+	shadow := SearcherFunc(func(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+		<-release
+		shadowErr.Store(fmt.Sprint(ctx.Err()))
+		return &WebSearchResponse{}, nil
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithShadowClient(shadow, SampleRate(1)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = client.WebSearch(ctx, "golang", nil)
+	require.NoError(t, err)
+	cancel()
+	close(release)
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	assert.Equal(t, "<nil>", shadowErr.Load())
+}
+
+// TestShadowClientNoTimeout tests mirroring with a client without timeout
+func TestShadowClientNoTimeout(t *testing.T) {
+	server := newMockServer(t, shadowResponse("https://a.example/"))
+
+	var deadline atomic.Bool
+	shadow := SearcherFunc(func(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+		_, ok := ctx.Deadline()
+		deadline.Store(ok)
+		return &WebSearchResponse{}, ctx.Err()
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithTimeout(0), WithShadowClient(shadow, SampleRate(1)))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	assert.False(t, deadline.Load())
+	report := client.ShadowReport()
+	assert.Equal(t, 1, report.Mirrored)
+	assert.Zero(t, report.Errors)
+}
+
+// TestShadowClientNoRetention tests that queries are not mirrored in
+// no-retention mode
+func TestShadowClientNoRetention(t *testing.T) {
+	primaryServer := newMockServer(t, shadowResponse("https://a.example/"))
+	shadowServer := newMockServer(t, shadowResponse("https://a.example/"))
+
+	shadow, err := NewClient("test-api-key", WithBaseURL(shadowServer.URL))
+	require.NoError(t, err)
+	client, err := NewClient("test-api-key", WithBaseURL(primaryServer.URL), WithNoRetention(true), WithShadowClient(shadow, SampleRate(1)))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	assert.Equal(t, 1, primaryServer.requestCount(""))
+	assert.Zero(t, shadowServer.requestCount(""))
+	assert.Zero(t, client.ShadowReport().Mirrored)
+}
+
+// TestWaitShadow tests waiting for the shadow searches in flight
+func TestWaitShadow(t *testing.T) {
+	server := newMockServer(t, shadowResponse("https://a.example/"))
+
+	release := make(chan struct{})
+	shadow := SearcherFunc(func(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+		<-release
+		return &WebSearchResponse{}, nil
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithShadowClient(shadow, SampleRate(1)))
+	require.NoError(t, err)
+
+	// Nothing to wait for yet
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, client.WaitShadow(ctx), context.DeadlineExceeded)
+	assert.Equal(t, 0, client.ShadowReport().Mirrored)
+
+	close(release)
+	require.NoError(t, client.WaitShadow(context.Background()))
+	assert.Equal(t, 1, client.ShadowReport().Mirrored)
+}
+
+// TestShadowClientSelf tests that shadow searches are not mirrored again
+func TestShadowClientSelf(t *testing.T) {
+	server := newMockServer(t, shadowResponse("https://a.example/"))
+
+	var client *Client
+	self := SearcherFunc(func(ctx context.Context, query string, params *WebSearchParams) (*WebSearchResponse, error) {
+		return client.WebSearch(ctx, query, params)
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithShadowClient(self, SampleRate(1)))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	require.NoError(t, client.WaitShadow(context.Background()))
+
+	assert.Equal(t, 2, server.requestCount(""))
+	assert.Equal(t, 1, client.ShadowReport().Mirrored)
+}
+
+// TestWithShadowClientInvalid tests rejecting a missing shadow or sampler
+func TestWithShadowClientInvalid(t *testing.T) {
+	_, err := NewClient("test-api-key", WithShadowClient(nil, SampleRate(1)))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	shadow, err := NewClient("test-api-key")
+	require.NoError(t, err)
+	_, err = NewClient("test-api-key", WithShadowClient(shadow, nil))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// TestSampleRate tests sampling a share of queries
+func TestSampleRate(t *testing.T) {
+	assert.False(t, SampleRate(0).Sample("golang"))
+	assert.True(t, SampleRate(1).Sample("golang"))
+
+	sampled := 0
+	for range 1000 {
+		if SampleRate(0.25).Sample("golang") {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 250, sampled, 80)
+}
+
+// TestURLOverlap tests the Jaccard index of result URLs
+func TestURLOverlap(t *testing.T) {
+	assert.Equal(t, 1.0, urlOverlap(nil, nil))
+	assert.Equal(t, 0.0, urlOverlap([]string{"a"}, nil))
+	assert.Equal(t, 1.0, urlOverlap([]string{"a", "b"}, []string{"b", "a", "a"}))
+	assert.InDelta(t, 1.0/3, urlOverlap([]string{"a", "b"}, []string{"b", "c"}), 0.001)
+}
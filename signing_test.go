@@ -0,0 +1,97 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestSigner tests signing and verifying requests
+func TestRequestSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := &RequestSigner{
+		Secret: []byte("audit-secret"),
+		Now:    func() time.Time { return now },
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.search.brave.com/res/v1/web/search?q=go+programming&count=10", nil)
+	require.NoError(t, err)
+
+	// Sign the request
+	err = signer.Sign(req)
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000", req.Header.Get(HeaderSignatureTimestamp))
+	assert.Len(t, req.Header.Get(HeaderSignature), 64)
+
+	// Verify the request
+	assert.NoError(t, signer.Verify(req, time.Minute))
+
+	// Tampering with the query invalidates the signature
+	tampered := req.Clone(context.Background())
+	tampered.URL.RawQuery = "q=something+else&count=10"
+	assert.Equal(t, ErrInvalidSignature, signer.Verify(tampered, time.Minute))
+
+	// A different secret does not verify
+	other := &RequestSigner{Secret: []byte("other-secret"), Now: signer.Now}
+	assert.Equal(t, ErrInvalidSignature, other.Verify(req, time.Minute))
+
+	// An old signature expires
+	later := &RequestSigner{Secret: signer.Secret, Now: func() time.Time { return now.Add(time.Hour) }}
+	assert.Equal(t, ErrSignatureExpired, later.Verify(req, time.Minute))
+	assert.NoError(t, later.Verify(req, 0))
+
+	// Missing headers
+	unsigned, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, ErrInvalidSignature, signer.Verify(unsigned, time.Minute))
+
+	// Missing secret
+	assert.Equal(t, ErrInvalidParameters, (&RequestSigner{}).Sign(req))
+}
+
+// TestRequestSignerCustomHeaders tests overriding the signature header names
+func TestRequestSignerCustomHeaders(t *testing.T) {
+	signer := &RequestSigner{
+		Secret:          []byte("audit-secret"),
+		SignatureHeader: "X-Audit-Signature",
+		TimestampHeader: "X-Audit-Timestamp",
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/path?q=test", nil)
+	require.NoError(t, err)
+	require.NoError(t, signer.Sign(req))
+
+	assert.NotEmpty(t, req.Header.Get("X-Audit-Signature"))
+	assert.NotEmpty(t, req.Header.Get("X-Audit-Timestamp"))
+	assert.Empty(t, req.Header.Get(HeaderSignature))
+	assert.NoError(t, signer.Verify(req, time.Minute))
+}
+
+// TestWithRequestSigner tests that the client signs outgoing requests
+func TestWithRequestSigner(t *testing.T) {
+	signer := &RequestSigner{Secret: []byte("audit-secret")}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, signer.Verify(r, time.Minute))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRequestSigner(signer))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "go programming", nil)
+	assert.NoError(t, err)
+
+	// Invalid signers are rejected
+	_, err = NewClient("test-api-key", WithRequestSigner(nil))
+	assert.Equal(t, ErrInvalidParameters, err)
+	_, err = NewClient("test-api-key", WithRequestSigner(&RequestSigner{}))
+	assert.Equal(t, ErrInvalidParameters, err)
+}
@@ -0,0 +1,126 @@
+package bravesearch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// Snapshot is a stable fingerprint of a search response, for change
+// detection between runs. Hashes ignore markup, case and whitespace
+// differences, so only content changes are detected.
+type Snapshot struct {
+	// Hash covers every result in order
+	Hash string
+
+	// Results are the web, news and video results, in that order
+	Results []ResultSnapshot
+}
+
+// ResultSnapshot is the fingerprint of a single result
+type ResultSnapshot struct {
+	// Kind is "web", "news" or "video"
+	Kind string
+
+	URL string
+
+	// Hash covers the URL, title and description
+	Hash string
+}
+
+// TakeSnapshot fingerprints the web, news and video results of response
+func TakeSnapshot(response *WebSearchResponse) *Snapshot {
+	snapshot := &Snapshot{}
+	if response != nil {
+		if response.Web != nil {
+			for _, result := range response.Web.Results {
+				snapshot.add("web", result.URL, result.Title, result.Description)
+			}
+		}
+		if response.News != nil {
+			for _, result := range response.News.Results {
+				snapshot.add("news", result.URL, result.Title, result.Description)
+			}
+		}
+		if response.Videos != nil {
+			for _, result := range response.Videos.Results {
+				snapshot.add("video", result.URL, result.Title, result.Description)
+			}
+		}
+	}
+
+	hashes := make([]string, 0, len(snapshot.Results))
+	for _, result := range snapshot.Results {
+		hashes = append(hashes, result.Kind+":"+result.Hash)
+	}
+	snapshot.Hash = hashFields(hashes...)
+	return snapshot
+}
+
+// Equal reports whether both snapshots have the same results in the same order
+func (s *Snapshot) Equal(other *Snapshot) bool {
+	return s.Hash == other.Hash
+}
+
+// Diff returns the results of s that are not in previous (added) and those
+// of previous that are no longer in s (removed). A result whose content
+// changed appears in both.
+func (s *Snapshot) Diff(previous *Snapshot) (added, removed []ResultSnapshot) {
+	return snapshotDifference(s.Results, previous.Results), snapshotDifference(previous.Results, s.Results)
+}
+
+// add appends the fingerprint of a result
+func (s *Snapshot) add(kind, rawURL, title, description string) {
+	s.Results = append(s.Results, ResultSnapshot{
+		Kind: kind,
+		URL:  rawURL,
+		Hash: hashFields(normalizeSnapshotURL(rawURL), normalizeSnapshotText(title), normalizeSnapshotText(description)),
+	})
+}
+
+// snapshotDifference returns the results of a whose hash is not in b
+func snapshotDifference(a, b []ResultSnapshot) []ResultSnapshot {
+	seen := make(map[string]bool, len(b))
+	for _, result := range b {
+		seen[result.Kind+":"+result.Hash] = true
+	}
+
+	var difference []ResultSnapshot
+	for _, result := range a {
+		if !seen[result.Kind+":"+result.Hash] {
+			difference = append(difference, result)
+		}
+	}
+	return difference
+}
+
+// hashFields returns the hex SHA-256 of the fields, unambiguously joined
+func hashFields(fields ...string) string {
+	h := sha256.New()
+	for _, field := range fields {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// normalizeSnapshotURL lowercases the scheme and host and drops the fragment
+func normalizeSnapshotURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// normalizeSnapshotText strips markup, lowercases and collapses whitespace
+func normalizeSnapshotText(text string) string {
+	return strings.ToLower(textutil.PlainText(text))
+}
@@ -0,0 +1,73 @@
+package bravesearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSnapshotResponse returns a response with web and news results
+func testSnapshotResponse() *WebSearchResponse {
+	return &WebSearchResponse{
+		Web: &Search{Results: []SearchResult{
+			{URL: "https://go.dev/", Title: "The Go Programming Language", Description: "Go is an open source programming language."},
+			{URL: "https://pkg.go.dev/", Title: "Go Packages", Description: "Discover packages."},
+		}},
+		News: &News{Results: []NewsResult{
+			{URL: "https://news.example/go-1-22", Title: "Go 1.22 released"},
+		}},
+	}
+}
+
+// TestTakeSnapshot tests that snapshots are stable
+func TestTakeSnapshot(t *testing.T) {
+	snapshot := TakeSnapshot(testSnapshotResponse())
+	require.Len(t, snapshot.Results, 3)
+	assert.Equal(t, "web", snapshot.Results[0].Kind)
+	assert.Equal(t, "https://go.dev/", snapshot.Results[0].URL)
+	assert.Equal(t, "news", snapshot.Results[2].Kind)
+	assert.Len(t, snapshot.Hash, 64)
+
+	assert.True(t, snapshot.Equal(TakeSnapshot(testSnapshotResponse())))
+
+	// Markup, case, whitespace and fragments are not changes
+	response := testSnapshotResponse()
+	response.Web.Results[0].URL = "https://GO.dev/#top"
+	response.Web.Results[0].Title = "The <strong>Go</strong>  programming language"
+	assert.True(t, snapshot.Equal(TakeSnapshot(response)))
+}
+
+// TestSnapshotDiff tests detecting added, removed and changed results
+func TestSnapshotDiff(t *testing.T) {
+	previous := TakeSnapshot(testSnapshotResponse())
+
+	response := testSnapshotResponse()
+	response.Web.Results[1].Description = "Search for Go packages."
+	response.News.Results = append(response.News.Results, NewsResult{URL: "https://news.example/go-1-23", Title: "Go 1.23 released"})
+	current := TakeSnapshot(response)
+
+	assert.False(t, current.Equal(previous))
+
+	added, removed := current.Diff(previous)
+	require.Len(t, added, 2)
+	assert.Equal(t, "https://pkg.go.dev/", added[0].URL)
+	assert.Equal(t, "https://news.example/go-1-23", added[1].URL)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "https://pkg.go.dev/", removed[0].URL)
+
+	// Reordering changes the response hash but adds and removes nothing
+	response = testSnapshotResponse()
+	response.Web.Results[0], response.Web.Results[1] = response.Web.Results[1], response.Web.Results[0]
+	reordered := TakeSnapshot(response)
+	assert.False(t, reordered.Equal(previous))
+	added, removed = reordered.Diff(previous)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+// TestTakeSnapshotEmpty tests snapshots of empty responses
+func TestTakeSnapshotEmpty(t *testing.T) {
+	assert.True(t, TakeSnapshot(nil).Equal(TakeSnapshot(&WebSearchResponse{})))
+	assert.Empty(t, TakeSnapshot(nil).Results)
+}
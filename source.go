@@ -0,0 +1,141 @@
+package bravesearch
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// SourceScore is a trust rating of the source of a search result
+type SourceScore struct {
+	// Score ranges from 0 (untrusted) to 1 (highly trusted)
+	Score float64
+
+	// Label describes the kind of source, e.g. "news" or "reference"
+	Label string
+}
+
+// SourceRater rates the trustworthiness of result sources, for ranking or
+// display badges. RateSource returns nil for sources it knows nothing about.
+// Implementations must be safe for concurrent use.
+type SourceRater interface {
+	RateSource(hostname string) *SourceScore
+}
+
+// SourceRaterFunc is an adapter to allow the use of ordinary functions as SourceRaters
+type SourceRaterFunc func(hostname string) *SourceScore
+
+// RateSource calls f(hostname)
+func (f SourceRaterFunc) RateSource(hostname string) *SourceScore {
+	return f(hostname)
+}
+
+// DomainSourceRater rates sources from a list of domains. A domain also
+// matches its subdomains, with the most specific entry winning; an entry
+// starting with a dot (e.g. ".gov") matches every domain with that suffix.
+type DomainSourceRater struct {
+	Domains map[string]SourceScore
+}
+
+//go:embed source_ratings.txt
+var bundledSourceRatings string
+
+// DefaultSourceRater returns a DomainSourceRater using the small sample
+// domain list bundled with the library. Real deployments will want to supply
+// their own list.
+func DefaultSourceRater() *DomainSourceRater {
+	rater, err := ParseSourceRatings(bundledSourceRatings)
+	if err != nil {
+		panic(err)
+	}
+	return rater
+}
+
+// ParseSourceRatings parses a domain list of "<domain> <score> [label]"
+// lines into a DomainSourceRater. Blank lines and lines starting with "#"
+// are ignored.
+func ParseSourceRatings(list string) (*DomainSourceRater, error) {
+	rater := &DomainSourceRater{Domains: make(map[string]SourceScore)}
+
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%w: source ratings line %d: expected domain, score and optional label", ErrInvalidParameters, line)
+		}
+
+		score, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || score < 0 || score > 1 {
+			return nil, fmt.Errorf("%w: source ratings line %d: score must be between 0 and 1", ErrInvalidParameters, line)
+		}
+
+		rating := SourceScore{Score: score}
+		if len(fields) == 3 {
+			rating.Label = fields[2]
+		}
+		rater.Domains[strings.ToLower(fields[0])] = rating
+	}
+
+	return rater, scanner.Err()
+}
+
+// RateSource implements SourceRater
+func (r *DomainSourceRater) RateSource(hostname string) *SourceScore {
+	for i, domain := range hostutil.Parents(hostname) {
+		// Entries with a leading dot rate the subdomains only
+		if i > 0 {
+			if rating, ok := r.Domains["."+domain]; ok {
+				return &rating
+			}
+		}
+		if rating, ok := r.Domains[domain]; ok {
+			return &rating
+		}
+	}
+	return nil
+}
+
+// rateSources annotates the results of response with source scores
+func (c *Client) rateSources(response *WebSearchResponse) {
+	rater := c.config.SourceRater
+	if rater == nil {
+		return
+	}
+
+	rate := func(rawURL string, metaURL *MetaURL) *SourceScore {
+		if metaURL != nil && metaURL.Hostname != "" {
+			return rater.RateSource(metaURL.Hostname)
+		}
+		if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+			return rater.RateSource(u.Hostname())
+		}
+		return nil
+	}
+
+	if response.Web != nil {
+		for i := range response.Web.Results {
+			result := &response.Web.Results[i]
+			result.SourceScore = rate(result.URL, result.MetaURL)
+		}
+	}
+	if response.News != nil {
+		for i := range response.News.Results {
+			result := &response.News.Results[i]
+			result.SourceScore = rate(result.URL, result.MetaURL)
+		}
+	}
+	if response.Videos != nil {
+		for i := range response.Videos.Results {
+			result := &response.Videos.Results[i]
+			result.SourceScore = rate(result.URL, result.MetaURL)
+		}
+	}
+}
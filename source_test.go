@@ -0,0 +1,99 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDefaultSourceRater tests the bundled domain list
+func TestDefaultSourceRater(t *testing.T) {
+	rater := DefaultSourceRater()
+
+	tests := []struct {
+		hostname string
+		label    string
+	}{
+		{"go.dev", "documentation"},
+		{"pkg.go.dev", "documentation"},
+		{"en.wikipedia.org", "reference"},
+		{"www.whitehouse.gov", "government"},
+		{"news.bbc.co.uk", "news"},
+		{"GitHub.com.", "code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			score := rater.RateSource(tt.hostname)
+			require.NotNil(t, score)
+			assert.Equal(t, tt.label, score.Label)
+			assert.Greater(t, score.Score, 0.0)
+		})
+	}
+
+	assert.Nil(t, rater.RateSource("unknown.example"))
+	assert.Nil(t, rater.RateSource(""))
+}
+
+// TestParseSourceRatings tests parsing domain lists
+func TestParseSourceRatings(t *testing.T) {
+	rater, err := ParseSourceRatings("# comment\n\nexample.com 0.5 sample\n.test 0.1\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]SourceScore{
+		"example.com": {Score: 0.5, Label: "sample"},
+		".test":       {Score: 0.1},
+	}, rater.Domains)
+
+	// The most specific entry wins
+	rater.Domains["www.example.com"] = SourceScore{Score: 0.9}
+	assert.Equal(t, 0.9, rater.RateSource("www.example.com").Score)
+	assert.Equal(t, 0.5, rater.RateSource("blog.example.com").Score)
+	assert.Equal(t, 0.1, rater.RateSource("a.b.test").Score)
+
+	for _, list := range []string{"example.com", "example.com high", "example.com 1.5", "example.com 0.5 a b"} {
+		_, err := ParseSourceRatings(list)
+		assert.ErrorIs(t, err, ErrInvalidParameters, list)
+	}
+}
+
+// TestWebSearchSourceScores tests annotating results with source scores
+func TestWebSearchSourceScores(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search",
+			"web": {"type": "search", "results": [
+				{"url": "https://go.dev/", "meta_url": {"hostname": "go.dev"}},
+				{"url": "https://unknown.example/"}
+			]},
+			"news": {"type": "news", "results": [{"url": "https://www.reuters.com/world/"}]},
+			"videos": {"type": "videos", "results": [{"url": "https://www.youtube.com/watch?v=dQw4w9WgXcQ"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithSourceRater(DefaultSourceRater()))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, response.Web.Results[0].SourceScore)
+	assert.Equal(t, "documentation", response.Web.Results[0].SourceScore.Label)
+	assert.Nil(t, response.Web.Results[1].SourceScore)
+	require.NotNil(t, response.News.Results[0].SourceScore)
+	assert.Equal(t, "news", response.News.Results[0].SourceScore.Label)
+	assert.Nil(t, response.Videos.Results[0].SourceScore)
+}
+
+// TestWithSourceRater tests the source rater option
+func TestWithSourceRater(t *testing.T) {
+	config := &ClientConfig{}
+	assert.Equal(t, ErrInvalidParameters, WithSourceRater(nil)(config))
+
+	rater := SourceRaterFunc(func(hostname string) *SourceScore { return &SourceScore{Score: 1} })
+	require.NoError(t, WithSourceRater(rater)(config))
+	assert.NotNil(t, config.SourceRater)
+}
@@ -0,0 +1,118 @@
+package bravesearch
+
+import (
+	"sort"
+)
+
+// Stabilized returns a copy of the response that serializes the same way
+// across runs, for snapshot-based tests and diffs. Web, news and video
+// results are sorted by rank, then URL, and their volatile fields (ages and
+// thumbnails) are cleared. The rank of a result is its position in the mixed
+// main results; results missing from them follow, by URL. Sections the mixed
+// results don't reference keep API order. References in Mixed are updated to
+// the new positions.
+//
+// The receiver is not modified.
+func (r *WebSearchResponse) Stabilized() *WebSearchResponse {
+	if r == nil {
+		return nil
+	}
+
+	stable := *r
+	if r.Mixed != nil {
+		mixed := *r.Mixed
+		mixed.Main = append([]MixedResultRef(nil), r.Mixed.Main...)
+		mixed.Top = append([]MixedResultRef(nil), r.Mixed.Top...)
+		mixed.Side = append([]MixedResultRef(nil), r.Mixed.Side...)
+		stable.Mixed = &mixed
+	}
+
+	if r.Web != nil {
+		web := *r.Web
+		web.Results = append([]SearchResult(nil), r.Web.Results...)
+		for i := range web.Results {
+			web.Results[i].Age, web.Results[i].PageAge, web.Results[i].Thumbnail = "", "", nil
+		}
+		stabilizeOrder(stable.Mixed, "web", web.Results, func(result SearchResult) string { return result.URL })
+		stable.Web = &web
+	}
+
+	if r.News != nil {
+		news := *r.News
+		news.Results = append([]NewsResult(nil), r.News.Results...)
+		for i := range news.Results {
+			news.Results[i].Age, news.Results[i].PageAge, news.Results[i].Thumbnail = "", "", nil
+		}
+		stabilizeOrder(stable.Mixed, "news", news.Results, func(result NewsResult) string { return result.URL })
+		stable.News = &news
+	}
+
+	if r.Videos != nil {
+		videos := *r.Videos
+		videos.Results = append([]VideoResult(nil), r.Videos.Results...)
+		for i := range videos.Results {
+			videos.Results[i].Age, videos.Results[i].PageAge, videos.Results[i].Thumbnail = "", "", nil
+		}
+		stabilizeOrder(stable.Mixed, "videos", videos.Results, func(result VideoResult) string { return result.URL })
+		stable.Videos = &videos
+	}
+
+	return &stable
+}
+
+// stabilizeOrder sorts results of the given mixed type by rank, then URL,
+// and updates the references to them in mixed
+func stabilizeOrder[T any](mixed *MixedResponse, resultType string, results []T, url func(T) string) {
+	ranks := make([]int, len(results))
+	for i := range ranks {
+		ranks[i] = -1
+	}
+	rank := 0
+	if mixed != nil {
+		for _, ref := range mixed.Main {
+			if ref.Type == resultType && ref.Index >= 0 && ref.Index < len(results) && ranks[ref.Index] < 0 {
+				ranks[ref.Index] = rank
+				rank++
+			}
+		}
+	}
+	for i := range ranks {
+		switch {
+		case rank == 0:
+			// Not in the mixed results at all, so API order is the rank
+			ranks[i] = i
+		case ranks[i] < 0:
+			ranks[i] = rank
+		}
+	}
+
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if ranks[i] != ranks[j] {
+			return ranks[i] < ranks[j]
+		}
+		return url(results[i]) < url(results[j])
+	})
+
+	sorted := make([]T, len(results))
+	positions := make([]int, len(results))
+	for position, i := range order {
+		sorted[position] = results[i]
+		positions[i] = position
+	}
+	copy(results, sorted)
+
+	if mixed != nil {
+		for _, refs := range [][]MixedResultRef{mixed.Main, mixed.Top, mixed.Side} {
+			for i, ref := range refs {
+				if ref.Type == resultType && !ref.All && ref.Index >= 0 && ref.Index < len(results) {
+					refs[i].Index = positions[ref.Index]
+				}
+			}
+		}
+	}
+}
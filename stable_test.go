@@ -0,0 +1,113 @@
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStabilized tests deterministic ordering and clearing of volatile fields
+func TestStabilized(t *testing.T) {
+	response := &WebSearchResponse{
+		Type: "search",
+		Web: &Search{Type: "search", Results: []SearchResult{
+			{URL: "https://c.example/", Age: "2 hours ago", PageAge: "2024-01-01T00:00:00", Thumbnail: &Thumbnail{Src: "https://img.example/c"}},
+			{URL: "https://b.example/"},
+			{URL: "https://a.example/"},
+			{URL: "https://d.example/"},
+		}},
+		News: &News{Results: []NewsResult{
+			{URL: "https://news.example/2", Age: "1 hour ago"},
+			{URL: "https://news.example/1", Thumbnail: &Thumbnail{Src: "https://img.example/n"}},
+		}},
+		Videos: &Videos{Results: []VideoResult{
+			{URL: "https://video.example/b", PageAge: "2024-01-01T00:00:00"},
+			{URL: "https://video.example/a"},
+		}},
+		Mixed: &MixedResponse{
+			Main: []MixedResultRef{
+				{Type: "web", Index: 2},
+				{Type: "news", All: true},
+				{Type: "web", Index: 0},
+			},
+			Side: []MixedResultRef{{Type: "web", Index: 3}},
+		},
+	}
+	original, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	stable := response.Stabilized()
+
+	var urls []string
+	for _, result := range stable.Web.Results {
+		urls = append(urls, result.URL)
+		assert.Empty(t, result.Age)
+		assert.Empty(t, result.PageAge)
+		assert.Nil(t, result.Thumbnail)
+	}
+	// Ranked by the mixed results, then the rest by URL
+	assert.Equal(t, []string{"https://a.example/", "https://c.example/", "https://b.example/", "https://d.example/"}, urls)
+	assert.Equal(t, []MixedResultRef{{Type: "web", Index: 0}, {Type: "news", All: true}, {Type: "web", Index: 1}}, stable.Mixed.Main)
+	assert.Equal(t, []MixedResultRef{{Type: "web", Index: 3}}, stable.Mixed.Side)
+
+	// Not referenced individually by the mixed results, so API order is kept
+	assert.Equal(t, "https://news.example/2", stable.News.Results[0].URL)
+	assert.Empty(t, stable.News.Results[0].Age)
+	assert.Nil(t, stable.News.Results[1].Thumbnail)
+	assert.Equal(t, "https://video.example/b", stable.Videos.Results[0].URL)
+	assert.Empty(t, stable.Videos.Results[0].PageAge)
+
+	// The receiver is unchanged
+	unchanged, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(unchanged))
+
+	assert.Nil(t, (*WebSearchResponse)(nil).Stabilized())
+	assert.Equal(t, &WebSearchResponse{Type: "search"}, (&WebSearchResponse{Type: "search"}).Stabilized())
+}
+
+// TestStabilizedIsDeterministic tests that shuffled results serialize the same way
+func TestStabilizedIsDeterministic(t *testing.T) {
+	first := &WebSearchResponse{
+		Web: &Search{Results: []SearchResult{
+			{URL: "https://b.example/", Age: "1 hour ago"},
+			{URL: "https://a.example/", Age: "3 hours ago"},
+			{URL: "https://top.example/"},
+		}},
+		Mixed: &MixedResponse{Main: []MixedResultRef{{Type: "web", Index: 2}}},
+	}
+	second := &WebSearchResponse{
+		Web: &Search{Results: []SearchResult{
+			{URL: "https://a.example/", Age: "4 hours ago"},
+			{URL: "https://top.example/"},
+			{URL: "https://b.example/", Age: "2 hours ago"},
+		}},
+		Mixed: &MixedResponse{Main: []MixedResultRef{{Type: "web", Index: 1}}},
+	}
+
+	firstJSON, err := json.Marshal(first.Stabilized())
+	require.NoError(t, err)
+	secondJSON, err := json.Marshal(second.Stabilized())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(firstJSON), string(secondJSON))
+}
+
+// TestWithStableResults tests that the option stabilizes search responses
+func TestWithStableResults(t *testing.T) {
+	server, _ := setupMockServer(t)
+	defer server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"), WithStableResults(true))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "go programming", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, response.Web.Results)
+	for _, result := range response.Web.Results {
+		assert.Empty(t, result.Age)
+		assert.Nil(t, result.Thumbnail)
+	}
+}
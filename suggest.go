@@ -0,0 +1,149 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SuggestParams holds the parameters for a query suggestion request
+type SuggestParams struct {
+	// Country is the country to suggest queries for
+	Country string `url:"country,omitempty"`
+
+	// Lang is the language to suggest queries in
+	Lang string `url:"lang,omitempty"`
+
+	// Count is the number of suggestions, at most MaxSuggestCount
+	Count int `url:"count,omitempty"`
+
+	// Rich requests entity information with the suggestions (paid plans only)
+	Rich bool `url:"rich,omitempty"`
+
+	// Extra holds query parameters the library does not model yet. Keys
+	// managed by the library are rejected with ErrInvalidParameters.
+	Extra url.Values `url:"-"`
+}
+
+// SuggestResponse represents the response from the Suggest API
+type SuggestResponse struct {
+	Type    string          `json:"type"`
+	Query   *SuggestQuery   `json:"query,omitempty"`
+	Results []SuggestResult `json:"results"`
+}
+
+// SuggestQuery represents the query suggestions were made for
+type SuggestQuery struct {
+	Original string `json:"original"`
+}
+
+// SuggestResult represents a single suggested query
+type SuggestResult struct {
+	Query       string     `json:"query"`
+	IsEntity    bool       `json:"is_entity,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Img         string     `json:"img,omitempty"`
+	Thumbnail   *Thumbnail `json:"thumbnail,omitempty"`
+}
+
+// Queries returns the suggested query strings, in order
+func (r *SuggestResponse) Queries() []string {
+	queries := make([]string, 0, len(r.Results))
+	for _, result := range r.Results {
+		queries = append(queries, result.Query)
+	}
+	return queries
+}
+
+// Suggest returns query suggestions (autocomplete) for query. The Suggest API
+// requires an Autosuggest subscription.
+func (c *Client) Suggest(ctx context.Context, query string, params *SuggestParams) (*SuggestResponse, error) {
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	query = NormalizeQuery(query, c.config.QueryNormalization)
+	if err := ValidateQuery(query, c.config.QueryLimits); err != nil {
+		return nil, err
+	}
+
+	suggestParams := &SuggestParams{}
+	if params != nil {
+		*suggestParams = *params
+	}
+
+	// Apply defaults if not set, unless the API's own defaults should apply
+	if !c.config.NoDefaults {
+		if suggestParams.Country == "" {
+			suggestParams.Country = c.config.DefaultCountry
+		}
+		if suggestParams.Lang == "" {
+			suggestParams.Lang = c.config.DefaultSearchLang
+		}
+		if suggestParams.Count == 0 {
+			suggestParams.Count = DefaultSuggestCount
+		}
+	}
+	if suggestParams.Count < 0 || suggestParams.Count > MaxSuggestCount {
+		return nil, fmt.Errorf("%w: count must be between 1 and %d", ErrInvalidParameters, MaxSuggestCount)
+	}
+
+	tables := c.codeTables(ctx)
+	if err := c.normalizeCodes(
+		codeField{"country", &suggestParams.Country, tables.normalizeCountry},
+		codeField{"search language", &suggestParams.Lang, tables.normalizeSearchLang},
+	); err != nil {
+		return nil, err
+	}
+
+	requestURL, err := c.buildSuggestURL(query, suggestParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SuggestResponse
+	if err := c.makeRequest(ctx, http.MethodGet, requestURL, nil, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// RelatedQueries returns searches related to query, for a "people also
+// search for" row. Web search responses carry no related searches, so they
+// are taken from the Suggest API, leaving out the query itself.
+func (c *Client) RelatedQueries(ctx context.Context, query string) ([]string, error) {
+	response, err := c.Suggest(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := NormalizeQuery(strings.TrimSpace(query), c.config.QueryNormalization)
+	related := make([]string, 0, len(response.Results))
+	for _, suggestion := range response.Queries() {
+		if strings.EqualFold(strings.TrimSpace(suggestion), normalized) {
+			continue
+		}
+		related = append(related, suggestion)
+	}
+	return related, nil
+}
+
+// buildSuggestURL builds the Suggest request URL with query parameters
+func (c *Client) buildSuggestURL(query string, params *SuggestParams) (string, error) {
+	values, err := encodeParams(params)
+	if err != nil {
+		return "", err
+	}
+	values.Set("q", query)
+	if params != nil {
+		if err := mergeExtra(values, params.Extra, params); err != nil {
+			return "", err
+		}
+	}
+
+	return c.endpointURL(EndpointSuggest) + "?" + values.Encode(), nil
+}
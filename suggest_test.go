@@ -0,0 +1,91 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSuggestServer sets up a mock Suggest API server, recording request queries
+func setupSuggestServer(t *testing.T) (*httptest.Server, *url.Values) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/res/v1/suggest/search", r.URL.Path)
+		query = r.URL.Query()
+
+		data, err := os.ReadFile("testdata/suggest_response.json")
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server, &query
+}
+
+// TestSuggest tests the Suggest API
+func TestSuggest(t *testing.T) {
+	server, query := setupSuggestServer(t)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	response, err := client.Suggest(context.Background(), "golang", &SuggestParams{Country: "uk", Count: 10, Rich: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "suggest", response.Type)
+	require.NotNil(t, response.Query)
+	assert.Equal(t, "golang", response.Query.Original)
+	assert.Equal(t, []string{"golang", "golang tutorial", "golang generics", "golang vs rust"}, response.Queries())
+
+	assert.Equal(t, "golang", query.Get("q"))
+	assert.Equal(t, "GB", query.Get("country"))
+	assert.Equal(t, DefaultSearchLang, query.Get("lang"))
+	assert.Equal(t, "10", query.Get("count"))
+	assert.Equal(t, "true", query.Get("rich"))
+}
+
+// TestSuggestDefaults tests the default Suggest parameters
+func TestSuggestDefaults(t *testing.T) {
+	server, query := setupSuggestServer(t)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1/"), WithDefaultCountry("JP"))
+	require.NoError(t, err)
+
+	_, err = client.Suggest(context.Background(), "golang", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "JP", query.Get("country"))
+	assert.Equal(t, "5", query.Get("count"))
+	assert.False(t, query.Has("rich"))
+}
+
+// TestSuggestInvalidParameters tests rejecting invalid Suggest parameters
+func TestSuggestInvalidParameters(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.Suggest(context.Background(), "", nil)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = client.Suggest(context.Background(), "golang", &SuggestParams{Count: MaxSuggestCount + 1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// TestRelatedQueries tests that related queries leave out the query itself
+func TestRelatedQueries(t *testing.T) {
+	server, _ := setupSuggestServer(t)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	related, err := client.RelatedQueries(context.Background(), " Golang ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"golang tutorial", "golang generics", "golang vs rust"}, related)
+}
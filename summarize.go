@@ -0,0 +1,242 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// Summary is a short summary of the results of a search
+type Summary struct {
+	// Text is the summary as plain text
+	Text string
+
+	// Sentences are the sentences of the summary with their sources
+	Sentences []SummarySentence
+
+	// Extractive reports whether the summary was assembled from result
+	// snippets rather than written by a summarizer
+	Extractive bool
+}
+
+// SummarySentence is a sentence of a summary
+type SummarySentence struct {
+	Text string
+
+	// Source is the URL of the result the sentence was taken from, if any
+	Source string
+}
+
+// SummaryProvider summarizes the results of a search, so applications can
+// switch between summarizers, or fall back to ExtractiveSummarizer where the
+// plan has no summarizer, with a single code path
+type SummaryProvider interface {
+	Summarize(ctx context.Context, query string, response *WebSearchResponse) (*Summary, error)
+}
+
+// SummaryProviderFunc is an adapter to allow the use of ordinary functions as SummaryProviders
+type SummaryProviderFunc func(ctx context.Context, query string, response *WebSearchResponse) (*Summary, error)
+
+// Summarize calls f(ctx, query, response)
+func (f SummaryProviderFunc) Summarize(ctx context.Context, query string, response *WebSearchResponse) (*Summary, error) {
+	return f(ctx, query, response)
+}
+
+// Defaults of ExtractiveSummarizer
+const (
+	DefaultSummaryResults   = 5
+	DefaultSummarySentences = 3
+)
+
+// ExtractiveSummarizer is a SummaryProvider that summarizes locally by
+// picking the most representative sentences of the top web result snippets:
+// those sharing the most words with the query and the other snippets, with
+// a preference for higher-ranked results. It needs no API features and no
+// network access.
+type ExtractiveSummarizer struct {
+	// TopResults is the number of web results to summarize, DefaultSummaryResults if 0
+	TopResults int
+
+	// MaxSentences is the length of the summary, DefaultSummarySentences if 0
+	MaxSentences int
+}
+
+// summaryQueryWeight is the weight of query words relative to words shared by snippets
+const summaryQueryWeight = 2
+
+// summaryDuplicateOverlap is the share of words above which a sentence
+// repeats one already in the summary
+const summaryDuplicateOverlap = 0.6
+
+// summarySentenceEnd splits snippets into sentences. Latin terminators need
+// whitespace after them so that "1.24" stays whole, while CJK text has no
+// spaces between sentences.
+var summarySentenceEnd = regexp.MustCompile(`([.!?])\s+|([。！？]+)\s*`)
+
+// summaryCandidate is a sentence considered for a summary
+type summaryCandidate struct {
+	sentence SummarySentence
+	words    map[string]bool
+	position int
+	score    float64
+}
+
+// Summarize implements SummaryProvider. It returns an error wrapping
+// ErrNoResults if the response has no snippets to summarize.
+func (s ExtractiveSummarizer) Summarize(ctx context.Context, query string, response *WebSearchResponse) (*Summary, error) {
+	topResults := s.TopResults
+	if topResults <= 0 {
+		topResults = DefaultSummaryResults
+	}
+	maxSentences := s.MaxSentences
+	if maxSentences <= 0 {
+		maxSentences = DefaultSummarySentences
+	}
+
+	results := response.GetWebResults()
+	if len(results) > topResults {
+		results = results[:topResults]
+	}
+
+	// Split the snippets into sentences and count the snippets each word appears in
+	var candidates []*summaryCandidate
+	frequency := make(map[string]int)
+	for rank, result := range results {
+		seen := make(map[string]bool)
+		for _, sentence := range splitSentences(textutil.PlainText(result.Description)) {
+			words := summaryWords(sentence)
+			if len(words) < 3 {
+				continue
+			}
+			candidates = append(candidates, &summaryCandidate{
+				sentence: SummarySentence{Text: sentence, Source: result.URL},
+				words:    words,
+				position: len(candidates),
+				score:    1 / float64(rank+2),
+			})
+			for word := range words {
+				if !seen[word] {
+					seen[word] = true
+					frequency[word]++
+				}
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: no snippets to summarize", ErrNoResults)
+	}
+
+	queryWords := summaryWords(query)
+	for _, candidate := range candidates {
+		var weight float64
+		for word := range candidate.words {
+			weight += float64(frequency[word] - 1)
+			if queryWords[word] {
+				weight += summaryQueryWeight
+			}
+		}
+		candidate.score += weight / float64(len(candidate.words))
+	}
+
+	ranked := append([]*summaryCandidate(nil), candidates...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	var picked []*summaryCandidate
+	for _, candidate := range ranked {
+		if len(picked) == maxSentences {
+			break
+		}
+		if !repeatsSentence(candidate, picked) {
+			picked = append(picked, candidate)
+		}
+	}
+	sort.Slice(picked, func(i, j int) bool { return picked[i].position < picked[j].position })
+
+	summary := &Summary{Extractive: true}
+	texts := make([]string, 0, len(picked))
+	for _, candidate := range picked {
+		summary.Sentences = append(summary.Sentences, candidate.sentence)
+		texts = append(texts, candidate.sentence.Text)
+	}
+	summary.Text = strings.Join(texts, " ")
+	return summary, nil
+}
+
+// splitSentences splits text into sentences, keeping their punctuation
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, sentence := range strings.Split(summarySentenceEnd.ReplaceAllString(text, "$1$2\n"), "\n") {
+		sentence = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(sentence), "..."))
+		if sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+// summaryWords returns the set of words of text that carry meaning. CJK
+// text is written without spaces, so its runs count as character bigrams.
+func summaryWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range conversationWords(text) {
+		for _, part := range splitCJK(word) {
+			if isCJK([]rune(part)[0]) || len(part) > 1 && !conversationStopWords[part] {
+				words[part] = true
+			}
+		}
+	}
+	return words
+}
+
+// splitCJK splits the CJK runs of word into overlapping character bigrams,
+// keeping the other runs whole
+func splitCJK(word string) []string {
+	var parts []string
+	runes := []rune(word)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && isCJK(runes[end]) == isCJK(runes[start]) {
+			end++
+		}
+		run := runes[start:end]
+		switch {
+		case !isCJK(run[0]):
+			parts = append(parts, string(run))
+		case len(run) == 1:
+			parts = append(parts, string(run))
+		default:
+			for i := 0; i+1 < len(run); i++ {
+				parts = append(parts, string(run[i:i+2]))
+			}
+		}
+		start = end
+	}
+	return parts
+}
+
+// isCJK reports whether r is a Chinese or Japanese character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// repeatsSentence reports whether candidate mostly repeats a picked sentence
+func repeatsSentence(candidate *summaryCandidate, picked []*summaryCandidate) bool {
+	for _, other := range picked {
+		shared := 0
+		for word := range candidate.words {
+			if other.words[word] {
+				shared++
+			}
+		}
+		smaller := min(len(candidate.words), len(other.words))
+		if float64(shared)/float64(smaller) > summaryDuplicateOverlap {
+			return true
+		}
+	}
+	return false
+}
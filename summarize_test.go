@@ -0,0 +1,111 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// summaryResponse has web results about Go with overlapping snippets
+var summaryResponse = &WebSearchResponse{
+	Web: &Search{Results: []SearchResult{
+		{
+			URL:         "https://go.dev/",
+			Description: "<strong>Go</strong> is an open source programming language. It makes it simple to build secure, scalable systems.",
+		},
+		{
+			URL:         "https://en.wikipedia.org/wiki/Go_(programming_language)",
+			Description: "Go is a statically typed, compiled programming language designed at Google. Go is syntactically similar to C.",
+		},
+		{
+			URL:         "https://example.com/go",
+			Description: "Go is an open source programming language! Click here for our newsletter...",
+		},
+	}},
+}
+
+// TestExtractiveSummarizer tests summarizing snippets locally
+func TestExtractiveSummarizer(t *testing.T) {
+	summarizer := ExtractiveSummarizer{MaxSentences: 2}
+	summary, err := summarizer.Summarize(context.Background(), "go programming language", summaryResponse)
+	require.NoError(t, err)
+
+	assert.True(t, summary.Extractive)
+	require.Len(t, summary.Sentences, 2)
+
+	// The sentences that best match the query and the other snippets are
+	// picked in their original order, and the repeated one is skipped
+	assert.Equal(t, SummarySentence{Text: "Go is an open source programming language.", Source: "https://go.dev/"}, summary.Sentences[0])
+	assert.Equal(t, "https://en.wikipedia.org/wiki/Go_(programming_language)", summary.Sentences[1].Source)
+	assert.Equal(t, "Go is an open source programming language. Go is a statically typed, compiled programming language designed at Google.", summary.Text)
+}
+
+// TestExtractiveSummarizerTopResults tests limiting the results summarized
+func TestExtractiveSummarizerTopResults(t *testing.T) {
+	summary, err := ExtractiveSummarizer{TopResults: 1}.Summarize(context.Background(), "go", summaryResponse)
+	require.NoError(t, err)
+
+	require.Len(t, summary.Sentences, 2)
+	for _, sentence := range summary.Sentences {
+		assert.Equal(t, "https://go.dev/", sentence.Source)
+	}
+}
+
+// TestExtractiveSummarizerEmpty tests summarizing a response without snippets
+func TestExtractiveSummarizerEmpty(t *testing.T) {
+	_, err := ExtractiveSummarizer{}.Summarize(context.Background(), "go", &WebSearchResponse{})
+	assert.ErrorIs(t, err, ErrNoResults)
+
+	_, err = ExtractiveSummarizer{}.Summarize(context.Background(), "go", nil)
+	assert.ErrorIs(t, err, ErrNoResults)
+}
+
+// TestSummaryProviderFallback tests falling back to the extractive summarizer behind SummaryProvider
+func TestSummaryProviderFallback(t *testing.T) {
+	unavailable := SummaryProviderFunc(func(ctx context.Context, query string, response *WebSearchResponse) (*Summary, error) {
+		return nil, &FeatureNotInPlanError{Features: []string{"summary"}}
+	})
+
+	summarize := func(provider SummaryProvider) (*Summary, error) {
+		summary, err := provider.Summarize(context.Background(), "go", summaryResponse)
+		if errors.Is(err, ErrFeatureNotInPlan) {
+			return ExtractiveSummarizer{}.Summarize(context.Background(), "go", summaryResponse)
+		}
+		return summary, err
+	}
+
+	summary, err := summarize(unavailable)
+	require.NoError(t, err)
+	assert.True(t, summary.Extractive)
+	assert.NotEmpty(t, summary.Text)
+}
+
+// TestSplitSentences tests splitting snippets into sentences
+func TestSplitSentences(t *testing.T) {
+	assert.Equal(t, []string{"One.", "Two!", "Three?", "Four"}, splitSentences("One. Two! Three? Four..."))
+	assert.Equal(t, []string{"Version 1.24 is out."}, splitSentences("Version 1.24 is out."))
+	assert.Equal(t, []string{"Goは言語です。", "速いです！", "本当？"}, splitSentences("Goは言語です。速いです！本当？"))
+	assert.Empty(t, splitSentences("  "))
+}
+
+// TestExtractiveSummarizerCJK tests summarizing Japanese snippets without spaces
+func TestExtractiveSummarizerCJK(t *testing.T) {
+	response := &WebSearchResponse{Web: &Search{Results: []SearchResult{
+		{URL: "https://go.dev/", Description: "Goはオープンソースのプログラミング言語です。シンプルで安全なシステムを構築できます。"},
+		{URL: "https://ja.wikipedia.org/wiki/Go", Description: "Goはグーグルが開発したプログラミング言語です。"},
+	}}}
+
+	summary, err := ExtractiveSummarizer{MaxSentences: 1}.Summarize(context.Background(), "プログラミング言語", response)
+	require.NoError(t, err)
+	require.Len(t, summary.Sentences, 1)
+	assert.Equal(t, SummarySentence{Text: "Goはオープンソースのプログラミング言語です。", Source: "https://go.dev/"}, summary.Sentences[0])
+}
+
+// TestSummaryWordsCJK tests counting the character bigrams of CJK text as words
+func TestSummaryWordsCJK(t *testing.T) {
+	assert.Equal(t, map[string]bool{"go": true, "言語": true, "語で": true, "です": true}, summaryWords("Go言語です"))
+	assert.Equal(t, map[string]bool{"本": true}, summaryWords("本"))
+}
@@ -0,0 +1,90 @@
+package bravesearch
+
+import "github.com/cnosuke/go-brave-search/internal/textutil"
+
+// DefaultSupportThreshold is the support score from which a sentence counts
+// as supported by its citations
+const DefaultSupportThreshold = 0.5
+
+// SentenceSupport is how well the citations support a sentence of a summary
+type SentenceSupport struct {
+	Text string
+
+	// Score ranges from 0 (unsupported) to 1 (every word of the sentence
+	// appears in a citation). It is the share of the sentence's meaningful
+	// words found in its best-matching citation.
+	Score float64
+
+	// Source is the URL of the best-matching citation, "" if none matches
+	Source string
+}
+
+// SupportReport scores a summary against its citations, to flag claims the
+// citations do not back before showing them to users
+type SupportReport struct {
+	Sentences []SentenceSupport
+
+	// Confidence is the mean score of the sentences
+	Confidence float64
+
+	// Coverage is the share of sentences scoring at least DefaultSupportThreshold
+	Coverage float64
+}
+
+// Unsupported returns the sentences scoring below threshold
+func (r *SupportReport) Unsupported(threshold float64) []SentenceSupport {
+	var unsupported []SentenceSupport
+	for _, sentence := range r.Sentences {
+		if sentence.Score < threshold {
+			unsupported = append(unsupported, sentence)
+		}
+	}
+	return unsupported
+}
+
+// ScoreSummary scores each sentence of summary, such as one written by an
+// LLM or a Summary's Text, by its word overlap with the titles and snippets
+// of citations. Sentences without meaningful words are skipped. Overlap is
+// a rough proxy: a sentence that reuses the words of a citation to claim
+// something else still scores high.
+func ScoreSummary(summary string, citations []SearchResult) *SupportReport {
+	snippets := make([]map[string]bool, len(citations))
+	for i, citation := range citations {
+		snippets[i] = summaryWords(textutil.PlainText(citation.Title + " " + citation.Description))
+	}
+
+	report := &SupportReport{}
+	supported := 0
+	for _, sentence := range splitSentences(textutil.PlainText(summary)) {
+		words := summaryWords(sentence)
+		if len(words) == 0 {
+			continue
+		}
+
+		support := SentenceSupport{Text: sentence}
+		for i, snippet := range snippets {
+			found := 0
+			for word := range words {
+				if snippet[word] {
+					found++
+				}
+			}
+			if score := float64(found) / float64(len(words)); score > support.Score {
+				support.Score = score
+				support.Source = citations[i].URL
+			}
+		}
+
+		report.Sentences = append(report.Sentences, support)
+		report.Confidence += support.Score
+		if support.Score >= DefaultSupportThreshold {
+			supported++
+		}
+	}
+
+	if len(report.Sentences) > 0 {
+		report.Confidence /= float64(len(report.Sentences))
+		report.Coverage = float64(supported) / float64(len(report.Sentences))
+	}
+	return report
+}
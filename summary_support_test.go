@@ -0,0 +1,59 @@
+package bravesearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScoreSummary tests scoring summary sentences against citations
+func TestScoreSummary(t *testing.T) {
+	citations := summaryResponse.Web.Results
+	summary := "Go is an open source programming language. Go was designed at Google. Go was first released on Mars in 1802."
+
+	report := ScoreSummary(summary, citations)
+	require.Len(t, report.Sentences, 3)
+
+	assert.Equal(t, "Go is an open source programming language.", report.Sentences[0].Text)
+	assert.Equal(t, 1.0, report.Sentences[0].Score)
+	assert.Equal(t, "https://go.dev/", report.Sentences[0].Source)
+
+	assert.Equal(t, 1.0, report.Sentences[1].Score)
+	assert.Equal(t, "https://en.wikipedia.org/wiki/Go_(programming_language)", report.Sentences[1].Source)
+
+	// "go", "first", "released", "mars" and "1802": only "go" is cited
+	assert.InDelta(t, 0.2, report.Sentences[2].Score, 0.001)
+
+	assert.InDelta(t, 2.2/3, report.Confidence, 0.001)
+	assert.InDelta(t, 2.0/3, report.Coverage, 0.001)
+
+	unsupported := report.Unsupported(DefaultSupportThreshold)
+	require.Len(t, unsupported, 1)
+	assert.Contains(t, unsupported[0].Text, "Mars")
+}
+
+// TestScoreSummaryExtractive tests that an extractive summary is fully supported
+func TestScoreSummaryExtractive(t *testing.T) {
+	summary, err := ExtractiveSummarizer{}.Summarize(context.Background(), "go", summaryResponse)
+	require.NoError(t, err)
+
+	report := ScoreSummary(summary.Text, summaryResponse.Web.Results)
+	assert.Equal(t, 1.0, report.Confidence)
+	assert.Equal(t, 1.0, report.Coverage)
+	assert.Empty(t, report.Unsupported(1))
+}
+
+// TestScoreSummaryEmpty tests scoring without sentences or citations
+func TestScoreSummaryEmpty(t *testing.T) {
+	report := ScoreSummary("", nil)
+	assert.Empty(t, report.Sentences)
+	assert.Zero(t, report.Confidence)
+
+	report = ScoreSummary("Go is fast.", nil)
+	require.Len(t, report.Sentences, 1)
+	assert.Zero(t, report.Sentences[0].Score)
+	assert.Empty(t, report.Sentences[0].Source)
+	assert.Zero(t, report.Coverage)
+}
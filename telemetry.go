@@ -0,0 +1,77 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// logRequest logs the outcome of an API request, if logging is enabled
+func (c *Client) logRequest(ctx context.Context, method, requestURL string, duration time.Duration, err error) {
+	if c.config.Logger == nil {
+		return
+	}
+
+	endpoint, params := c.telemetryParams(requestURL)
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("endpoint", endpoint),
+		slog.String("params", params.Encode()),
+		slog.Int("status", statusCodeOf(err)),
+		slog.Duration("duration", duration),
+	}
+
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", telemetryError(err).Error()))
+	}
+
+	c.config.Logger.LogAttrs(ctx, level, "brave search request", attrs...)
+}
+
+// telemetryParams splits requestURL into its path and query parameters, with
+// the query text scrubbed (or dropped in no-retention mode) so it is safe to
+// emit as telemetry
+func (c *Client) telemetryParams(requestURL string) (string, url.Values) {
+	parsed, err := url.Parse(requestURL)
+	if err != nil {
+		return "", url.Values{}
+	}
+
+	params := parsed.Query()
+	if c.config.NoRetention {
+		params.Del("q")
+	} else if query := params.Get("q"); query != "" {
+		params.Set("q", c.config.QueryScrubber.Scrub(query))
+	}
+
+	return parsed.Path, params
+}
+
+// telemetryError strips the request URL, which carries the raw query text,
+// from transport errors
+func telemetryError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// statusCodeOf returns the HTTP status code a request ended with: 200 on
+// success, the API status for API errors and 0 if no response was received
+func statusCodeOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package bravesearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogRequest tests that requests are logged with scrubbed queries
+func TestLogRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The API still receives the original query
+		assert.Equal(t, "mail jane@example.com", r.URL.Query().Get("q"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithLogger(logger))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "mail jane@example.com", nil)
+	require.NoError(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "DEBUG", entry["level"])
+	assert.Equal(t, "brave search request", entry["msg"])
+	assert.Equal(t, http.MethodGet, entry["method"])
+	assert.Equal(t, WebSearchEndpoint, entry["endpoint"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.NotContains(t, buf.String(), "jane@example.com")
+
+	params, err := url.ParseQuery(entry["params"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "mail [email]", params.Get("q"))
+}
+
+// TestLogRequestNoRetention tests that query text is never logged in no-retention mode
+func TestLogRequestNoRetention(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"type": "search"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL),
+		WithLogger(logger),
+		WithNoRetention(true),
+	)
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "symptoms of flu", nil)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, buf.String())
+	assert.NotContains(t, buf.String(), "symptoms")
+	assert.NotContains(t, buf.String(), "flu")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	params, err := url.ParseQuery(entry["params"].(string))
+	require.NoError(t, err)
+	assert.False(t, params.Has("q"))
+	assert.Equal(t, DefaultCountry, params.Get("country"))
+}
+
+// TestLogRequestError tests that failed requests are logged at warn level
+func TestLogRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithLogger(logger))
+	require.NoError(t, err)
+
+	_, err = client.WebSearch(context.Background(), "go programming", nil)
+	require.Error(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, float64(http.StatusTooManyRequests), entry["status"])
+	assert.Contains(t, entry["error"], "rate limit exceeded")
+}
+
+// TestTelemetryError tests that request URLs are stripped from logged errors
+func TestTelemetryError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := &url.Error{Op: "Get", URL: "https://api.search.brave.com/res/v1/web/search?q=secret", Err: cause}
+	assert.Equal(t, cause, telemetryError(err))
+	assert.Equal(t, cause, telemetryError(cause))
+}
+
+// TestStatusCodeOf tests deriving the status code from a request error
+func TestStatusCodeOf(t *testing.T) {
+	assert.Equal(t, http.StatusOK, statusCodeOf(nil))
+	assert.Equal(t, http.StatusNotFound, statusCodeOf(NewAPIError(http.StatusNotFound, "Not Found", ErrNotFound)))
+	assert.Equal(t, 0, statusCodeOf(errors.New("connection refused")))
+}
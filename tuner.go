@@ -0,0 +1,199 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ParamVariant is a named set of search parameters a Tuner tries, such as
+// a count, freshness or Goggle
+type ParamVariant struct {
+	Name    string
+	Options []SearchOption
+}
+
+// RewardFunc rates a response for a Tuner, from 0 (bad) to 1 (good), e.g.
+// from a relevance model or a downstream quality signal. Rewards outside
+// that range are clamped.
+type RewardFunc func(ctx context.Context, query string, response *WebSearchResponse) float64
+
+// Trial is a search made by a Tuner, for reporting its reward later with
+// Tuner.Feedback
+type Trial struct {
+	Category string
+	Variant  string
+
+	// index is the position of the variant
+	index int
+}
+
+// VariantStats are the results of a variant in a query category
+type VariantStats struct {
+	Variant string
+
+	// Trials is the number of searches made with the variant
+	Trials int
+
+	// Rewards is the number of rewards received and MeanReward their mean
+	Rewards    int
+	MeanReward float64
+}
+
+// tunerArm holds the statistics of a variant in a category
+type tunerArm struct {
+	trials  int
+	rewards int
+	total   float64
+}
+
+// Tuner is an experimental multi-armed bandit that explores parameter
+// variants per query category and converges on the variant with the best
+// reward. It uses UCB1: each variant is tried once, then the variant with
+// the highest mean reward plus an exploration bonus that shrinks as it is
+// tried is chosen. A Tuner is safe for concurrent use; its statistics live
+// in memory only.
+type Tuner struct {
+	client   *Client
+	variants []ParamVariant
+	reward   RewardFunc
+
+	mu   sync.Mutex
+	arms map[string][]tunerArm
+}
+
+// NewTuner creates a Tuner over variants, which must have distinct,
+// non-empty names. With a reward function each search is rated as soon as
+// it returns; without one rewards are reported with Feedback, e.g. once
+// click data arrives.
+func (c *Client) NewTuner(reward RewardFunc, variants ...ParamVariant) (*Tuner, error) {
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("%w: a tuner needs at least one variant", ErrInvalidParameters)
+	}
+	names := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		if variant.Name == "" || names[variant.Name] {
+			return nil, fmt.Errorf("%w: variant names must be distinct and non-empty", ErrInvalidParameters)
+		}
+		names[variant.Name] = true
+		if _, err := NewSearchParams(variant.Options...); err != nil {
+			return nil, fmt.Errorf("variant %q: %w", variant.Name, err)
+		}
+	}
+
+	return &Tuner{
+		client:   c,
+		variants: append([]ParamVariant(nil), variants...),
+		reward:   reward,
+		arms:     make(map[string][]tunerArm),
+	}, nil
+}
+
+// Search performs a web search for a query of category with the variant the
+// tuner chooses, and returns the trial to report feedback for
+func (t *Tuner) Search(ctx context.Context, category, query string) (*WebSearchResponse, *Trial, error) {
+	trial := t.choose(category)
+
+	response, err := t.client.WebSearchWithOptions(ctx, query, t.variants[trial.index].Options...)
+	if err != nil {
+		t.mu.Lock()
+		t.arms[category][trial.index].trials--
+		t.mu.Unlock()
+		return nil, nil, err
+	}
+
+	if t.reward != nil {
+		t.Feedback(trial, t.reward(ctx, query, response))
+	}
+	return response, trial, nil
+}
+
+// Feedback records the reward of a trial, clamped to [0, 1]
+func (t *Tuner) Feedback(trial *Trial, reward float64) {
+	if trial == nil {
+		return
+	}
+	reward = math.Max(0, math.Min(1, reward))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	arms := t.categoryArms(trial.Category)
+	arms[trial.index].rewards++
+	arms[trial.index].total += reward
+}
+
+// Stats returns the statistics of each variant in category, in the order
+// the variants were given
+func (t *Tuner) Stats(category string) []VariantStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	arms := t.categoryArms(category)
+	stats := make([]VariantStats, len(t.variants))
+	for i, variant := range t.variants {
+		stats[i] = VariantStats{Variant: variant.Name, Trials: arms[i].trials, Rewards: arms[i].rewards, MeanReward: arms[i].mean()}
+	}
+	return stats
+}
+
+// Best returns the name of the variant with the highest mean reward in
+// category. It is false if no rewards were received in category yet.
+func (t *Tuner) Best(category string) (string, bool) {
+	best, found := "", false
+	bestMean := -1.0
+	for _, stats := range t.Stats(category) {
+		if stats.Rewards > 0 && stats.MeanReward > bestMean {
+			best, bestMean, found = stats.Variant, stats.MeanReward, true
+		}
+	}
+	return best, found
+}
+
+// choose picks the variant for the next search in category with UCB1 and
+// counts the trial
+func (t *Tuner) choose(category string) *Trial {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	arms := t.categoryArms(category)
+	total := 0
+	for _, arm := range arms {
+		total += arm.trials
+	}
+
+	chosen := 0
+	bestScore := math.Inf(-1)
+	for i, arm := range arms {
+		if arm.trials == 0 {
+			chosen = i
+			break
+		}
+		score := arm.mean() + math.Sqrt(2*math.Log(float64(total))/float64(arm.trials))
+		if score > bestScore {
+			chosen, bestScore = i, score
+		}
+	}
+
+	arms[chosen].trials++
+	return &Trial{Category: category, Variant: t.variants[chosen].Name, index: chosen}
+}
+
+// categoryArms returns the arms of category, creating them if needed. The
+// caller must hold t.mu.
+func (t *Tuner) categoryArms(category string) []tunerArm {
+	arms, ok := t.arms[category]
+	if !ok {
+		arms = make([]tunerArm, len(t.variants))
+		t.arms[category] = arms
+	}
+	return arms
+}
+
+// mean returns the mean reward of the arm, 0 without rewards
+func (a tunerArm) mean() float64 {
+	if a.rewards == 0 {
+		return 0
+	}
+	return a.total / float64(a.rewards)
+}
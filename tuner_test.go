@@ -0,0 +1,125 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tunerResponse echoes the requested count as the original query, failing for a count of 13
+func tunerResponse(r *http.Request) mockResponse {
+	if r.URL.Query().Get("count") == "13" {
+		return mockResponse{Status: http.StatusInternalServerError}
+	}
+	return mockResponse{Body: `{"type": "search", "query": {"original": "` + r.URL.Query().Get("count") + `"}}`}
+}
+
+// TestTunerConverges tests that the tuner converges on the best variant
+func TestTunerConverges(t *testing.T) {
+	client, err := NewClient("test-api-key", WithBaseURL(newMockServer(t, tunerResponse).URL))
+	require.NoError(t, err)
+
+	// Five results are what the downstream consumer likes best
+	rewards := map[string]float64{"5": 0.9, "10": 0.5, "20": 0.1}
+	reward := func(ctx context.Context, query string, response *WebSearchResponse) float64 {
+		return rewards[response.Query.Original]
+	}
+
+	tuner, err := client.NewTuner(reward,
+		ParamVariant{Name: "few", Options: []SearchOption{WithCount(5)}},
+		ParamVariant{Name: "some", Options: []SearchOption{WithCount(10)}},
+		ParamVariant{Name: "many", Options: []SearchOption{WithCount(20)}},
+	)
+	require.NoError(t, err)
+
+	for range 60 {
+		_, trial, err := tuner.Search(context.Background(), "how-to", "go generics")
+		require.NoError(t, err)
+		require.NotNil(t, trial)
+		assert.Equal(t, "how-to", trial.Category)
+	}
+
+	best, ok := tuner.Best("how-to")
+	assert.True(t, ok)
+	assert.Equal(t, "few", best)
+
+	stats := tuner.Stats("how-to")
+	require.Len(t, stats, 3)
+	assert.Equal(t, "few", stats[0].Variant)
+	assert.Equal(t, 60, stats[0].Trials+stats[1].Trials+stats[2].Trials)
+	assert.Greater(t, stats[0].Trials, stats[1].Trials)
+	assert.Greater(t, stats[1].Trials, stats[2].Trials)
+	assert.InDelta(t, 0.9, stats[0].MeanReward, 0.001)
+
+	// Categories are tuned separately
+	_, ok = tuner.Best("news")
+	assert.False(t, ok)
+}
+
+// TestTunerFeedback tests reporting rewards after the search
+func TestTunerFeedback(t *testing.T) {
+	client, err := NewClient("test-api-key", WithBaseURL(newMockServer(t, tunerResponse).URL))
+	require.NoError(t, err)
+
+	tuner, err := client.NewTuner(nil,
+		ParamVariant{Name: "week", Options: []SearchOption{WithFreshness(FreshnessWeek)}},
+		ParamVariant{Name: "any"},
+	)
+	require.NoError(t, err)
+
+	// Each variant is tried once before any is preferred
+	_, first, err := tuner.Search(context.Background(), "news", "election")
+	require.NoError(t, err)
+	_, second, err := tuner.Search(context.Background(), "news", "election")
+	require.NoError(t, err)
+	assert.Equal(t, "week", first.Variant)
+	assert.Equal(t, "any", second.Variant)
+
+	tuner.Feedback(first, 2) // clamped to 1
+	tuner.Feedback(second, -1)
+	tuner.Feedback(nil, 1)
+
+	stats := tuner.Stats("news")
+	assert.Equal(t, VariantStats{Variant: "week", Trials: 1, Rewards: 1, MeanReward: 1}, stats[0])
+	assert.Equal(t, VariantStats{Variant: "any", Trials: 1, Rewards: 1, MeanReward: 0}, stats[1])
+
+	best, ok := tuner.Best("news")
+	assert.True(t, ok)
+	assert.Equal(t, "week", best)
+}
+
+// TestTunerSearchError tests that failed searches are not counted
+func TestTunerSearchError(t *testing.T) {
+	client, err := NewClient("test-api-key", WithBaseURL(newMockServer(t, tunerResponse).URL), WithRetries(0))
+	require.NoError(t, err)
+
+	tuner, err := client.NewTuner(nil, ParamVariant{Name: "broken", Options: []SearchOption{WithCount(13)}})
+	require.NoError(t, err)
+
+	_, trial, err := tuner.Search(context.Background(), "any", "query")
+	assert.Error(t, err)
+	assert.Nil(t, trial)
+	assert.Zero(t, tuner.Stats("any")[0].Trials)
+}
+
+// TestNewTunerInvalid tests rejecting invalid variants
+func TestNewTunerInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.NewTuner(nil)
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewTuner(nil, ParamVariant{Name: "a"}, ParamVariant{Name: "a"})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewTuner(nil, ParamVariant{Name: ""})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.NewTuner(nil, ParamVariant{Name: "a", Options: []SearchOption{WithCount(0)}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	assert.Contains(t, err.Error(), `variant "a"`)
+}
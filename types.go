@@ -1,7 +1,9 @@
 package bravesearch
 
 import (
+	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -9,13 +11,52 @@ import (
 type ClientConfig struct {
 	APIKey           string
 	BaseURL          string
+	EndpointOverrides map[Endpoint]string
 	Timeout          time.Duration
 	MaxRetries       int
+	RetryBudget      *RetryBudget
 	UserAgent        string
 	DefaultCountry   string
 	DefaultSearchLang string
 	DefaultUILang    string
 	HTTPClient       *http.Client
+	AppName          string
+	AppVersion       string
+	AppContact       string
+	Authenticator    Authenticator
+	RequestSigner    *RequestSigner
+	Logger           *slog.Logger
+	QueryScrubber    QueryScrubber
+	NoRetention      bool
+	LocationResolver LocationResolver
+	QueryLimits      QueryLimits
+	QueryNormalization QueryNormalization
+	WarningHandler   WarningHandler
+	StrictCodes      bool
+	CodeTableSource  CodeTableSource
+	EscalateBreakingNews bool
+	SourceRater      SourceRater
+	StableResults    bool
+	EmptyResultsError bool
+	Previewer        Previewer
+	PreviewConcurrency int
+	URLChecker       URLChecker
+	URLCheckAction   URLCheckAction
+	HeaderPolicy     HeaderPolicy
+	NoDefaults       bool
+	SoftFail         bool
+	QueryRewriter    QueryRewriter
+	AdaptiveCompression bool
+	CompressionStats []CompressionStat
+	APIVersion       string
+	RateLimitStore   RateLimitStore
+	RateLimitPerSecond int
+	Auditor          Auditor
+	FeedbackStore    FeedbackStore
+	CookieJar        http.CookieJar
+	ShadowSearcher   Searcher
+	ShadowSampler    ShadowSampler
+	FaultPolicy      *FaultPolicy
 }
 
 // WebSearchParams holds the parameters for a web search request
@@ -31,13 +72,22 @@ type WebSearchParams struct {
 	Offset          int    `url:"offset,omitempty"`
 	SafeSearch      string `url:"safesearch,omitempty"`
 	Freshness       string `url:"freshness,omitempty"`
-	TextDecorations bool   `url:"text_decorations,omitempty"`
-	Spellcheck      bool   `url:"spellcheck,omitempty"`
+	TextDecorations *bool  `url:"text_decorations,omitempty"` // nil leaves the API default; see Bool
+	Spellcheck      *bool  `url:"spellcheck,omitempty"`       // nil leaves the API default; see Bool
 	ResultFilter    string `url:"result_filter,omitempty"`
 	Goggles         string `url:"goggles,omitempty"`
 	Units           string `url:"units,omitempty"`
 	ExtraSnippets   bool   `url:"extra_snippets,omitempty"`
 	Summary         bool   `url:"summary,omitempty"`
+	EnableRichCallback bool `url:"enable_rich_callback,omitempty,int"`
+
+	// Location is sent as X-Loc-* headers rather than query parameters
+	Location *Location `url:"-"`
+
+	// Extra holds query parameters the library does not model yet, such as
+	// newly launched API parameters. Keys managed by the library are rejected
+	// with ErrInvalidParameters.
+	Extra url.Values `url:"-"`
 }
 
 // WebSearchResponse represents the top-level response from the Web Search API
@@ -53,6 +103,23 @@ type WebSearchResponse struct {
 	Videos      *Videos         `json:"videos,omitempty"`
 	Web         *Search         `json:"web,omitempty"`
 	Summarizer  *Summarizer     `json:"summarizer,omitempty"`
+	Rich        *RichCallback   `json:"rich,omitempty"`
+
+	// Provenance records the request that produced the response
+	Provenance *Provenance `json:"-"`
+
+	// Rewrite records how a conversational query was rewritten (see ConversationSearch)
+	Rewrite *QueryRewrite `json:"-"`
+
+	// Experiment records the experiment arm that made the search (see Experiment)
+	Experiment *ExperimentAssignment `json:"-"`
+
+	// DecodeWarnings lists the sections that could not be decoded in
+	// soft-fail mode (see WithSoftFail)
+	DecodeWarnings []DecodeWarning `json:"-"`
+
+	// params are the parameters of the request, for Pagination
+	params *WebSearchParams
 }
 
 // Search represents a collection of web search results
@@ -81,6 +148,21 @@ type SearchResult struct {
 	MetaURL        *MetaURL     `json:"meta_url,omitempty"`
 	Thumbnail      *Thumbnail   `json:"thumbnail,omitempty"`
 	Age            string       `json:"age,omitempty"`
+	Product        *Product     `json:"product,omitempty"`
+	Recipe         *Recipe      `json:"recipe,omitempty"`
+	Movie          *Movie       `json:"movie,omitempty"`
+
+	// SourceScore is set by the configured SourceRater
+	SourceScore *SourceScore `json:"-"`
+
+	// PreviewImage is set by the configured Previewer
+	PreviewImage []byte `json:"-"`
+
+	// Flagged is set by the configured URLChecker to the reason the URL was flagged
+	Flagged string `json:"-"`
+
+	// Provenance records the request that produced the result
+	Provenance *Provenance `json:"-"`
 }
 
 // Profile represents profile information associated with a search result
@@ -152,8 +234,9 @@ type MixedResultRef struct {
 	All   bool   `json:"all"`
 }
 
-// The following types are included for completeness, but aren't fully implemented
-// since we're focusing on Web results only
+// The following types are the other sections of a response. Their results
+// are typed, except for the discussions and the infobox and summarizer data,
+// which are decoded as generic JSON values
 
 // Discussions represents forum discussions
 type Discussions struct {
@@ -164,31 +247,62 @@ type Discussions struct {
 // FAQ represents frequently asked questions
 type FAQ struct {
 	Type    string `json:"type"`
-	Results []any  `json:"results,omitempty"`
+	Results []QA   `json:"results,omitempty"`
+}
+
+// QA represents a question and answer
+type QA struct {
+	Question string   `json:"question"`
+	Answer   string   `json:"answer"`
+	Title    string   `json:"title,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	MetaURL  *MetaURL `json:"meta_url,omitempty"`
 }
 
 // GraphInfobox represents an infobox
 type GraphInfobox struct {
-	Type string `json:"type"`
-	Data any    `json:"data,omitempty"`
+	Type    string          `json:"type"`
+	Data    any             `json:"data,omitempty"`
+	Results []InfoboxResult `json:"results,omitempty"`
+}
+
+// InfoboxResult represents an entity infobox. Which fields are set depends
+// on the Subtype (e.g. "generic", "entity", "location", "place", "software"
+// or "movie").
+type InfoboxResult struct {
+	Type        string      `json:"type"`
+	Subtype     string      `json:"subtype,omitempty"`
+	Position    int         `json:"position,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	URL         string      `json:"url,omitempty"`
+	Label       string      `json:"label,omitempty"`
+	Category    string      `json:"category,omitempty"`
+	Description string      `json:"description,omitempty"`
+	LongDesc    string      `json:"long_desc,omitempty"`
+	Thumbnail   *Thumbnail  `json:"thumbnail,omitempty"`
+	Attributes  [][]string  `json:"attributes,omitempty"`
+	Profiles    []Profile   `json:"profiles,omitempty"`
+	WebsiteURL  string      `json:"website_url,omitempty"`
+	Images      []Thumbnail `json:"images,omitempty"`
+	Providers   []Profile   `json:"providers,omitempty"`
 }
 
 // Locations represents location results
 type Locations struct {
-	Type    string `json:"type"`
-	Results []any  `json:"results,omitempty"`
+	Type    string           `json:"type"`
+	Results []LocationResult `json:"results,omitempty"`
 }
 
 // News represents news results
 type News struct {
-	Type    string `json:"type"`
-	Results []any  `json:"results,omitempty"`
+	Type    string       `json:"type"`
+	Results []NewsResult `json:"results,omitempty"`
 }
 
 // Videos represents video results
 type Videos struct {
-	Type    string `json:"type"`
-	Results []any  `json:"results,omitempty"`
+	Type    string        `json:"type"`
+	Results []VideoResult `json:"results,omitempty"`
 }
 
 // Summarizer represents summary results
@@ -0,0 +1,226 @@
+package bravesearch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// URLChecker checks result URLs against a threat list, such as a local
+// blocklist or a Safe Browsing service. CheckURLs returns the flagged URLs
+// mapped to the reason they were flagged (e.g. "malware"); URLs that are
+// not flagged are omitted. Implementations must be safe for concurrent use.
+//
+// Lookup services like Google Safe Browsing accept batches of URLs, so all
+// the URLs of a response are checked in a single call.
+type URLChecker interface {
+	CheckURLs(ctx context.Context, urls []string) (map[string]string, error)
+}
+
+// URLCheckerFunc is an adapter to allow the use of ordinary functions as URLCheckers
+type URLCheckerFunc func(ctx context.Context, urls []string) (map[string]string, error)
+
+// CheckURLs calls f(ctx, urls)
+func (f URLCheckerFunc) CheckURLs(ctx context.Context, urls []string) (map[string]string, error) {
+	return f(ctx, urls)
+}
+
+// URLCheckAction is what happens to results whose URL was flagged
+type URLCheckAction int
+
+// URL check actions
+const (
+	// URLCheckAnnotate sets the Flagged field of flagged results
+	URLCheckAnnotate URLCheckAction = iota
+
+	// URLCheckDrop removes flagged results from the response
+	URLCheckDrop
+)
+
+// String returns the name of the action
+func (a URLCheckAction) String() string {
+	switch a {
+	case URLCheckAnnotate:
+		return "annotate"
+	case URLCheckDrop:
+		return "drop"
+	default:
+		return fmt.Sprintf("URLCheckAction(%d)", int(a))
+	}
+}
+
+// BlocklistURLChecker flags URLs whose host is on a list of domains. A
+// domain also matches its subdomains; an entry starting with a dot (e.g.
+// ".zip") matches every domain with that suffix.
+type BlocklistURLChecker struct {
+	// Domains maps blocked domains to the reason they are blocked
+	Domains map[string]string
+}
+
+// ParseBlocklist parses a blocklist of "<domain> [reason]" lines into a
+// BlocklistURLChecker. The reason defaults to "blocklisted". Blank lines and
+// lines starting with "#" are ignored.
+func ParseBlocklist(list string) (*BlocklistURLChecker, error) {
+	checker := &BlocklistURLChecker{Domains: make(map[string]string)}
+
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		domain := hostutil.Normalize(fields[0])
+		if domain == "" || domain == "." || strings.ContainsAny(domain, "/:") {
+			return nil, fmt.Errorf("%w: blocklist line %d: expected a domain", ErrInvalidParameters, line)
+		}
+
+		reason := "blocklisted"
+		if len(fields) > 1 {
+			reason = strings.Join(fields[1:], " ")
+		}
+		checker.Domains[domain] = reason
+	}
+
+	return checker, scanner.Err()
+}
+
+// CheckURLs implements URLChecker
+func (b *BlocklistURLChecker) CheckURLs(ctx context.Context, urls []string) (map[string]string, error) {
+	flagged := make(map[string]string)
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		if reason, ok := b.check(u.Hostname()); ok {
+			flagged[rawURL] = reason
+		}
+	}
+	return flagged, nil
+}
+
+// check returns the reason hostname is blocked, if it is
+func (b *BlocklistURLChecker) check(hostname string) (string, bool) {
+	for i, domain := range hostutil.Parents(hostname) {
+		// Entries with a leading dot block the subdomains only
+		if i > 0 {
+			if reason, ok := b.Domains["."+domain]; ok {
+				return reason, true
+			}
+		}
+		if reason, ok := b.Domains[domain]; ok {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// checkURLs checks the web, news and video results of response with the
+// configured URLChecker, annotating or dropping flagged results. Failures
+// are reported as warnings and leave the results unchecked.
+func (c *Client) checkURLs(ctx context.Context, response *WebSearchResponse) {
+	checker := c.config.URLChecker
+	if checker == nil {
+		return
+	}
+
+	var urls []string
+	for _, result := range response.GetWebResults() {
+		urls = append(urls, result.URL)
+	}
+	if response.News != nil {
+		for _, result := range response.News.Results {
+			urls = append(urls, result.URL)
+		}
+	}
+	if response.Videos != nil {
+		for _, result := range response.Videos.Results {
+			urls = append(urls, result.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	flagged, err := checker.CheckURLs(ctx, urls)
+	if err != nil {
+		c.warn(Warning{
+			Code:    WarningCodeURLCheckFailed,
+			Message: "failed to check result URLs: " + err.Error(),
+		})
+		return
+	}
+	if len(flagged) == 0 {
+		return
+	}
+
+	drop := c.config.URLCheckAction == URLCheckDrop
+	if response.Web != nil {
+		var positions []int
+		response.Web.Results, positions = flagResults(response.Web.Results, flagged, drop, func(r *SearchResult) (string, *string) { return r.URL, &r.Flagged })
+		remapMixed(response.Mixed, "web", positions)
+	}
+	if response.News != nil {
+		var positions []int
+		response.News.Results, positions = flagResults(response.News.Results, flagged, drop, func(r *NewsResult) (string, *string) { return r.URL, &r.Flagged })
+		remapMixed(response.Mixed, "news", positions)
+	}
+	if response.Videos != nil {
+		var positions []int
+		response.Videos.Results, positions = flagResults(response.Videos.Results, flagged, drop, func(r *VideoResult) (string, *string) { return r.URL, &r.Flagged })
+		remapMixed(response.Mixed, "videos", positions)
+	}
+}
+
+// flagResults annotates or drops the flagged results. field returns the URL
+// of a result and its Flagged field. It also returns the new position of
+// each result, -1 for dropped ones.
+func flagResults[T any](results []T, flagged map[string]string, drop bool, field func(*T) (string, *string)) ([]T, []int) {
+	positions := make([]int, len(results))
+	kept := results[:0]
+	for i := range results {
+		result := &results[i]
+		resultURL, flag := field(result)
+		if reason, ok := flagged[resultURL]; ok {
+			if drop {
+				positions[i] = -1
+				continue
+			}
+			*flag = reason
+		}
+		positions[i] = len(kept)
+		kept = append(kept, *result)
+	}
+	return kept, positions
+}
+
+// remapMixed updates the references to results of the given mixed type to
+// their new positions, dropping references to dropped results
+func remapMixed(mixed *MixedResponse, resultType string, positions []int) {
+	if mixed == nil || !slices.Contains(positions, -1) {
+		return
+	}
+	remap := func(refs []MixedResultRef) []MixedResultRef {
+		if refs == nil {
+			return nil
+		}
+		remapped := make([]MixedResultRef, 0, len(refs))
+		for _, ref := range refs {
+			if ref.Type == resultType && !ref.All {
+				if ref.Index < 0 || ref.Index >= len(positions) || positions[ref.Index] < 0 {
+					continue
+				}
+				ref.Index = positions[ref.Index]
+			}
+			remapped = append(remapped, ref)
+		}
+		return remapped
+	}
+	mixed.Main, mixed.Top, mixed.Side = remap(mixed.Main), remap(mixed.Top), remap(mixed.Side)
+}
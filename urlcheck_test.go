@@ -0,0 +1,166 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseBlocklist tests parsing blocklists
+func TestParseBlocklist(t *testing.T) {
+	checker, err := ParseBlocklist(`
+# Known bad domains
+malware.example  malware distribution
+Phish.Example.   phishing
+.zip
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"malware.example": "malware distribution",
+		"phish.example":   "phishing",
+		".zip":            "blocklisted",
+	}, checker.Domains)
+
+	for _, list := range []string{"https://malware.example/", ". phishing", "malware.example:8080"} {
+		_, err := ParseBlocklist(list)
+		assert.ErrorIs(t, err, ErrInvalidParameters, list)
+	}
+}
+
+// TestBlocklistURLChecker tests flagging URLs by domain
+func TestBlocklistURLChecker(t *testing.T) {
+	checker := &BlocklistURLChecker{Domains: map[string]string{
+		"malware.example": "malware",
+		".zip":            "risky TLD",
+	}}
+
+	flagged, err := checker.CheckURLs(context.Background(), []string{
+		"https://malware.example/download",
+		"https://cdn.MALWARE.example/x.js",
+		"https://notmalware.example/",
+		"https://invoice.zip/",
+		"https://go.dev/",
+		"://bad url",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"https://malware.example/download": "malware",
+		"https://cdn.MALWARE.example/x.js": "malware",
+		"https://invoice.zip/":             "risky TLD",
+	}, flagged)
+}
+
+// TestWithURLChecker tests annotating and dropping flagged results
+func TestWithURLChecker(t *testing.T) {
+	server, _ := setupMockServer(t)
+	defer server.Close()
+
+	checker := URLCheckerFunc(func(ctx context.Context, urls []string) (map[string]string, error) {
+		assert.Contains(t, urls, "https://go.dev/")
+		return map[string]string{"https://go.dev/": "phishing"}, nil
+	})
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"), WithURLChecker(checker, URLCheckAnnotate))
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "go programming", nil)
+	require.NoError(t, err)
+	total := len(response.Web.Results)
+	for _, result := range response.Web.Results {
+		if result.URL == "https://go.dev/" {
+			assert.Equal(t, "phishing", result.Flagged)
+		} else {
+			assert.Empty(t, result.Flagged)
+		}
+	}
+
+	client, err = NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"), WithURLChecker(checker, URLCheckDrop))
+	require.NoError(t, err)
+
+	response, err = client.WebSearch(context.Background(), "go programming", nil)
+	require.NoError(t, err)
+	assert.Len(t, response.Web.Results, total-1)
+	for _, result := range response.Web.Results {
+		assert.NotEqual(t, "https://go.dev/", result.URL)
+	}
+}
+
+// TestWithURLCheckerFailure tests that failed checks leave results unchecked
+func TestWithURLCheckerFailure(t *testing.T) {
+	server, _ := setupMockServer(t)
+	defer server.Close()
+
+	checker := URLCheckerFunc(func(ctx context.Context, urls []string) (map[string]string, error) {
+		return nil, errors.New("lookup service unavailable")
+	})
+
+	var warnings []Warning
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/res/v1"),
+		WithURLChecker(checker, URLCheckDrop),
+		WithWarningHandler(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	require.NoError(t, err)
+
+	response, err := client.WebSearch(context.Background(), "go programming", nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.Web.Results)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningCodeURLCheckFailed, warnings[0].Code)
+
+	_, err = NewClient("test-api-key", WithURLChecker(nil, URLCheckDrop))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+	_, err = NewClient("test-api-key", WithURLChecker(checker, URLCheckAction(7)))
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
+
+// TestFlagResults tests flagging news and video results
+func TestFlagResults(t *testing.T) {
+	flagged := map[string]string{"https://bad.example/": "malware"}
+
+	news := []NewsResult{{URL: "https://bad.example/"}, {URL: "https://good.example/"}}
+	news, _ = flagResults(news, flagged, false, func(r *NewsResult) (string, *string) { return r.URL, &r.Flagged })
+	assert.Equal(t, "malware", news[0].Flagged)
+	assert.Empty(t, news[1].Flagged)
+
+	videos := []VideoResult{{URL: "https://bad.example/"}, {URL: "https://good.example/"}}
+	videos, positions := flagResults(videos, flagged, true, func(r *VideoResult) (string, *string) { return r.URL, &r.Flagged })
+	require.Len(t, videos, 1)
+	assert.Equal(t, "https://good.example/", videos[0].URL)
+	assert.Equal(t, []int{-1, 0}, positions)
+}
+
+// TestCheckURLsRemapsMixed tests that dropping results keeps the mixed
+// references aligned
+func TestCheckURLsRemapsMixed(t *testing.T) {
+	checker := URLCheckerFunc(func(ctx context.Context, urls []string) (map[string]string, error) {
+		return map[string]string{"https://b.example/": "phishing"}, nil
+	})
+	client, err := NewClient("test-api-key", WithURLChecker(checker, URLCheckDrop))
+	require.NoError(t, err)
+
+	response := &WebSearchResponse{
+		Web: &Search{Results: []SearchResult{
+			{URL: "https://a.example/"}, {URL: "https://b.example/"}, {URL: "https://c.example/"},
+		}},
+		News: &News{Results: []NewsResult{{URL: "https://news.example/"}}},
+		Mixed: &MixedResponse{
+			Main: []MixedResultRef{
+				{Type: "web", Index: 0}, {Type: "news", All: true}, {Type: "web", Index: 1}, {Type: "web", Index: 2},
+			},
+			Side: []MixedResultRef{{Type: "web", Index: 2}, {Type: "web", Index: 7}},
+		},
+	}
+	client.checkURLs(context.Background(), response)
+
+	require.Len(t, response.Web.Results, 2)
+	assert.Equal(t, []MixedResultRef{
+		{Type: "web", Index: 0}, {Type: "news", All: true}, {Type: "web", Index: 1},
+	}, response.Mixed.Main)
+	assert.Equal(t, []MixedResultRef{{Type: "web", Index: 1}}, response.Mixed.Side)
+	assert.Equal(t, "https://c.example/", response.Web.Results[response.Mixed.Main[2].Index].URL)
+	assert.Nil(t, response.Mixed.Top)
+}
@@ -0,0 +1,119 @@
+package bravesearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// verticalRequest describes a request to a vertical search endpoint, such as
+// image, news or video search. The pointers address the fields of a copy of
+// the caller's params; those the endpoint does not support are nil.
+type verticalRequest struct {
+	endpoint Endpoint
+
+	// params is the struct encoded as query parameters, extra its Extra field
+	params any
+	extra  url.Values
+
+	country, searchLang, uiLang *string
+
+	count                  *int
+	defaultCount, maxCount int
+	offset                 *int
+
+	// validate checks the endpoint-specific parameters, if set
+	validate func() error
+}
+
+// verticalSearch validates query and the params of req, fills in the
+// client's defaults, normalizes the codes and decodes the response of the
+// endpoint into response. It returns the normalized query.
+func (c *Client) verticalSearch(ctx context.Context, query string, req verticalRequest, response any) (string, error) {
+	if query == "" {
+		return "", ErrEmptyQuery
+	}
+
+	query = NormalizeQuery(query, c.config.QueryNormalization)
+	if err := ValidateQuery(query, c.config.QueryLimits); err != nil {
+		return "", err
+	}
+
+	// Apply defaults if not set, unless the API's own defaults should apply
+	if !c.config.NoDefaults {
+		defaults := []struct {
+			field *string
+			value string
+		}{
+			{req.country, c.config.DefaultCountry},
+			{req.searchLang, c.config.DefaultSearchLang},
+			{req.uiLang, c.config.DefaultUILang},
+		}
+		for _, d := range defaults {
+			if d.field != nil && *d.field == "" {
+				*d.field = d.value
+			}
+		}
+		if *req.count == 0 {
+			*req.count = req.defaultCount
+		}
+	}
+	if *req.count < 0 || *req.count > req.maxCount {
+		return "", fmt.Errorf("%w: count must be between 1 and %d", ErrInvalidParameters, req.maxCount)
+	}
+	if req.offset != nil && (*req.offset < 0 || *req.offset > MaxOffset) {
+		return "", fmt.Errorf("%w: offset must be between 0 and %d", ErrInvalidParameters, MaxOffset)
+	}
+	if req.validate != nil {
+		if err := req.validate(); err != nil {
+			return "", err
+		}
+	}
+
+	tables := c.codeTables(ctx)
+	var fields []codeField
+	if req.country != nil {
+		fields = append(fields, codeField{"country", req.country, tables.normalizeCountry})
+	}
+	if req.searchLang != nil {
+		fields = append(fields, codeField{"search language", req.searchLang, tables.normalizeSearchLang})
+	}
+	if req.uiLang != nil {
+		fields = append(fields, codeField{"UI language", req.uiLang, tables.normalizeUILang})
+	}
+	if err := c.normalizeCodes(fields...); err != nil {
+		return "", err
+	}
+
+	values, err := encodeParams(req.params)
+	if err != nil {
+		return "", err
+	}
+	values.Set("q", query)
+	if err := mergeExtra(values, req.extra, req.params); err != nil {
+		return "", err
+	}
+	requestURL := c.endpointURL(req.endpoint) + "?" + values.Encode()
+
+	if err := c.makeRequest(ctx, http.MethodGet, requestURL, nil, nil, response); err != nil {
+		return "", err
+	}
+	return query, nil
+}
+
+// annotateVertical annotates the results of view, a web search response
+// holding the results of a vertical search, as the results of a web search:
+// it sets their provenance, rates their sources and checks their URLs
+func (c *Client) annotateVertical(ctx context.Context, view *WebSearchResponse, query string, offset, count int) {
+	view.setProvenance(&Provenance{
+		Query:       query,
+		Offset:      offset,
+		Count:       count,
+		RetrievedAt: time.Now(),
+		Cache:       CacheMiss,
+	})
+	c.rateSources(view)
+	c.checkURLs(ctx, view)
+}
@@ -0,0 +1,75 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupVerticalSearchServer sets up a mock vertical search API server at the
+// path of endpoint serving the fixture in testdata, recording request queries
+func setupVerticalSearchServer(t *testing.T, endpoint Endpoint, fixture string) (*httptest.Server, *url.Values) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/res/v1"+endpointPaths[endpoint], r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get(HeaderSubscriptionToken))
+		query = r.URL.Query()
+
+		data, err := os.ReadFile("testdata/" + fixture)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server, &query
+}
+
+// TestVerticalSearch tests the shared pipeline of vertical search requests
+func TestVerticalSearch(t *testing.T) {
+	server, query := setupVerticalSearchServer(t, EndpointNewsSearch, "news_search_response.json")
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	// Fields the endpoint does not support are neither defaulted nor sent
+	params := &ImageSearchParams{}
+	request := verticalRequest{
+		endpoint:     EndpointNewsSearch,
+		params:       params,
+		country:      &params.Country,
+		count:        &params.Count,
+		defaultCount: 7,
+		maxCount:     10,
+	}
+	var response NewsSearchResponse
+	normalized, err := client.verticalSearch(context.Background(), "go news", request, &response)
+	require.NoError(t, err)
+	assert.Equal(t, "go news", normalized)
+	assert.NotEmpty(t, response.Results)
+	assert.Equal(t, url.Values{"q": {"go news"}, "country": {DefaultCountry}, "count": {"7"}}, *query)
+
+	// Endpoint-specific validation runs before the request
+	var validated atomic.Int32
+	errInvalid := errors.New("invalid")
+	request.validate = func() error {
+		validated.Add(1)
+		return errInvalid
+	}
+	_, err = client.verticalSearch(context.Background(), "go", request, &response)
+	assert.ErrorIs(t, err, errInvalid)
+	assert.Equal(t, int32(1), validated.Load())
+
+	params.Count = 11
+	request.validate = nil
+	_, err = client.verticalSearch(context.Background(), "go", request, &response)
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
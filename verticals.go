@@ -0,0 +1,287 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnosuke/go-brave-search/internal/textutil"
+)
+
+// Product represents product data of a result
+type Product struct {
+	Type        string     `json:"type,omitempty"`
+	Name        string     `json:"name"`
+	Category    string     `json:"category,omitempty"`
+	Price       string     `json:"price,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Thumbnail   *Thumbnail `json:"thumbnail,omitempty"`
+	Offers      []Offer    `json:"offers,omitempty"`
+	Rating      *Rating    `json:"rating,omitempty"`
+}
+
+// Offer represents a product offer
+type Offer struct {
+	URL           string `json:"url,omitempty"`
+	PriceCurrency string `json:"priceCurrency,omitempty"`
+	Price         string `json:"price,omitempty"`
+
+	// Availability is the schema.org availability, e.g. "https://schema.org/InStock", if known
+	Availability string `json:"availability,omitempty"`
+}
+
+// Recipe represents recipe data of a result
+type Recipe struct {
+	Title          string     `json:"title,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Thumbnail      *Thumbnail `json:"thumbnail,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	Time           string     `json:"time,omitempty"`
+	PrepTime       string     `json:"prep_time,omitempty"`
+	CookTime       string     `json:"cook_time,omitempty"`
+	Ingredients    TextList   `json:"ingredients,omitempty"`
+	Servings       int        `json:"servings,omitempty"`
+	Calories       int        `json:"calories,omitempty"`
+	Rating         *Rating    `json:"rating,omitempty"`
+	RecipeCategory string     `json:"recipeCategory,omitempty"`
+	RecipeCuisine  string     `json:"recipeCuisine,omitempty"`
+}
+
+// Movie represents movie data of a result
+type Movie struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Thumbnail   *Thumbnail `json:"thumbnail,omitempty"`
+	Release     string     `json:"release,omitempty"`
+	Directors   []Person   `json:"directors,omitempty"`
+	Actors      []Person   `json:"actors,omitempty"`
+	Rating      *Rating    `json:"rating,omitempty"`
+	Duration    string     `json:"duration,omitempty"`
+	Genre       []string   `json:"genre,omitempty"`
+}
+
+// Person represents a person, such as a movie director
+type Person struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// TextList is a list of texts that the API returns either as a single
+// string, a list of strings or a list of objects with a name
+type TextList []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *TextList) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*l = nil
+		if text = strings.TrimSpace(text); text != "" {
+			*l = TextList{text}
+		}
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	list := make(TextList, 0, len(items))
+	for _, item := range items {
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(item, &text); err == nil {
+			list = append(list, text)
+		} else if err := json.Unmarshal(item, &named); err == nil && named.Name != "" {
+			list = append(list, named.Name)
+		}
+	}
+	*l = list
+	return nil
+}
+
+// ProductListing is a product found in the results, with its offers
+type ProductListing struct {
+	Name     string
+	Category string
+
+	// SourceURL is the URL of the result the product was found in
+	SourceURL string
+
+	Offers []Offer
+	Rating *Rating
+}
+
+// RecipeSummary is a recipe found in the results
+type RecipeSummary struct {
+	Title string
+
+	// SourceURL is the recipe URL, or that of the result it was found in
+	SourceURL string
+
+	Ingredients []string
+
+	// TotalTime, PrepTime and CookTime are zero if unknown
+	TotalTime time.Duration
+	PrepTime  time.Duration
+	CookTime  time.Duration
+
+	Servings int
+	Calories int
+	Rating   *Rating
+}
+
+// MovieSummary is a movie found in the results
+type MovieSummary struct {
+	Name string
+
+	// SourceURL is the URL of the result the movie was found in
+	SourceURL string
+
+	Release   string
+	Directors []string
+	Actors    []string
+	Genres    []string
+
+	// Duration is zero if unknown
+	Duration time.Duration
+	Rating   *Rating
+}
+
+// ExtractProducts returns the products of the results that carry product data
+func ExtractProducts(results []SearchResult) []ProductListing {
+	var products []ProductListing
+	for _, result := range results {
+		if result.Product == nil {
+			continue
+		}
+		product := result.Product
+
+		offers := product.Offers
+		if len(offers) == 0 && product.Price != "" {
+			offers = []Offer{{URL: result.URL, Price: product.Price}}
+		}
+
+		products = append(products, ProductListing{
+			Name:      textutil.FirstNonEmpty(product.Name, result.Title),
+			Category:  product.Category,
+			SourceURL: result.URL,
+			Offers:    offers,
+			Rating:    product.Rating,
+		})
+	}
+	return products
+}
+
+// ExtractRecipes returns the recipes of the results that carry recipe data
+func ExtractRecipes(results []SearchResult) []RecipeSummary {
+	var recipes []RecipeSummary
+	for _, result := range results {
+		if result.Recipe == nil {
+			continue
+		}
+		recipe := result.Recipe
+
+		summary := RecipeSummary{
+			Title:       textutil.FirstNonEmpty(recipe.Title, result.Title),
+			SourceURL:   textutil.FirstNonEmpty(recipe.URL, result.URL),
+			Ingredients: []string(recipe.Ingredients),
+			PrepTime:    parseRecipeTime(recipe.PrepTime),
+			CookTime:    parseRecipeTime(recipe.CookTime),
+			TotalTime:   parseRecipeTime(recipe.Time),
+			Servings:    recipe.Servings,
+			Calories:    recipe.Calories,
+			Rating:      recipe.Rating,
+		}
+		if summary.TotalTime == 0 {
+			summary.TotalTime = summary.PrepTime + summary.CookTime
+		}
+		recipes = append(recipes, summary)
+	}
+	return recipes
+}
+
+// ExtractMovies returns the movies of the results that carry movie data
+func ExtractMovies(results []SearchResult) []MovieSummary {
+	var movies []MovieSummary
+	for _, result := range results {
+		if result.Movie == nil {
+			continue
+		}
+		movie := result.Movie
+
+		duration, _ := ParseISODuration(movie.Duration)
+		movies = append(movies, MovieSummary{
+			Name:      textutil.FirstNonEmpty(movie.Name, result.Title),
+			SourceURL: result.URL,
+			Release:   movie.Release,
+			Directors: personNames(movie.Directors),
+			Actors:    personNames(movie.Actors),
+			Genres:    movie.Genre,
+			Duration:  duration,
+			Rating:    movie.Rating,
+		})
+	}
+	return movies
+}
+
+// isoDurationPattern matches ISO 8601 durations of days, hours, minutes and seconds
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseISODuration parses an ISO 8601 duration such as "PT1H30M", as used
+// in structured data. Years, months and weeks are not supported.
+func ParseISODuration(s string) (time.Duration, bool) {
+	m := isoDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil || m[1]+m[2]+m[3]+m[4] == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+		if m[i+1] != "" {
+			n, err := strconv.Atoi(m[i+1])
+			if err != nil {
+				return 0, false
+			}
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[4] != "" {
+		seconds, err := strconv.ParseFloat(m[4], 64)
+		if err != nil {
+			return 0, false
+		}
+		d += time.Duration(seconds * float64(time.Second))
+	}
+	return d, true
+}
+
+// parseRecipeTime parses a recipe time, either an ISO 8601 duration or
+// minutes such as "45 min"
+func parseRecipeTime(s string) time.Duration {
+	if d, ok := ParseISODuration(s); ok {
+		return d
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 2 && strings.HasPrefix(strings.ToLower(fields[1]), "min") {
+		if n, err := strconv.Atoi(fields[0]); err == nil {
+			return time.Duration(n) * time.Minute
+		}
+	}
+	return 0
+}
+
+// personNames returns the names of people
+func personNames(people []Person) []string {
+	var names []string
+	for _, person := range people {
+		if person.Name != "" {
+			names = append(names, person.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,125 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExtractVerticals tests extracting products, recipes and movies from results
+func TestExtractVerticals(t *testing.T) {
+	data := `[
+		{"title": "Plain result", "url": "https://example.com/"},
+		{"title": "Kettle - Shop", "url": "https://shop.example.com/kettle", "subtype": "product",
+		 "product": {"name": "Electric Kettle", "category": "Kitchen", "rating": {"ratingValue": 4.5},
+		  "offers": [{"url": "https://shop.example.com/kettle", "priceCurrency": "USD", "price": "29.99"}]}},
+		{"title": "Pancakes", "url": "https://food.example.com/pancakes", "subtype": "recipe",
+		 "recipe": {"prep_time": "PT10M", "cook_time": "PT15M", "ingredients": ["flour", "milk", {"name": "eggs"}],
+		  "servings": 4, "calories": 350}},
+		{"title": "Heat (1995)", "url": "https://movies.example.com/heat", "subtype": "movie",
+		 "movie": {"name": "Heat", "release": "1995", "duration": "PT2H50M", "genre": ["Crime"],
+		  "directors": [{"name": "Michael Mann"}], "actors": [{"name": "Al Pacino"}, {"name": "Robert De Niro"}]}}
+	]`
+
+	var results []SearchResult
+	require.NoError(t, json.Unmarshal([]byte(data), &results))
+
+	products := ExtractProducts(results)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Electric Kettle", products[0].Name)
+	assert.Equal(t, "Kitchen", products[0].Category)
+	assert.Equal(t, "https://shop.example.com/kettle", products[0].SourceURL)
+	assert.Equal(t, []Offer{{URL: "https://shop.example.com/kettle", PriceCurrency: "USD", Price: "29.99"}}, products[0].Offers)
+	assert.Equal(t, 4.5, products[0].Rating.RatingValue)
+
+	recipes := ExtractRecipes(results)
+	require.Len(t, recipes, 1)
+	assert.Equal(t, "Pancakes", recipes[0].Title)
+	assert.Equal(t, "https://food.example.com/pancakes", recipes[0].SourceURL)
+	assert.Equal(t, []string{"flour", "milk", "eggs"}, recipes[0].Ingredients)
+	assert.Equal(t, 10*time.Minute, recipes[0].PrepTime)
+	assert.Equal(t, 15*time.Minute, recipes[0].CookTime)
+	assert.Equal(t, 25*time.Minute, recipes[0].TotalTime)
+	assert.Equal(t, 4, recipes[0].Servings)
+	assert.Equal(t, 350, recipes[0].Calories)
+
+	movies := ExtractMovies(results)
+	require.Len(t, movies, 1)
+	assert.Equal(t, "Heat", movies[0].Name)
+	assert.Equal(t, "1995", movies[0].Release)
+	assert.Equal(t, []string{"Michael Mann"}, movies[0].Directors)
+	assert.Equal(t, []string{"Al Pacino", "Robert De Niro"}, movies[0].Actors)
+	assert.Equal(t, []string{"Crime"}, movies[0].Genres)
+	assert.Equal(t, 2*time.Hour+50*time.Minute, movies[0].Duration)
+}
+
+// TestExtractProductsPriceOnly tests that a bare product price becomes an offer
+func TestExtractProductsPriceOnly(t *testing.T) {
+	products := ExtractProducts([]SearchResult{
+		{Title: "Kettle", URL: "https://shop.example.com/kettle", Product: &Product{Price: "$29.99"}},
+	})
+
+	require.Len(t, products, 1)
+	assert.Equal(t, "Kettle", products[0].Name)
+	assert.Equal(t, []Offer{{URL: "https://shop.example.com/kettle", Price: "$29.99"}}, products[0].Offers)
+}
+
+// TestTextListUnmarshal tests decoding the shapes of text lists
+func TestTextListUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected TextList
+	}{
+		{"string", `"2 cups flour"`, TextList{"2 cups flour"}},
+		{"empty string", `""`, nil},
+		{"strings", `["flour", "milk"]`, TextList{"flour", "milk"}},
+		{"objects", `[{"name": "flour"}, {"name": ""}, {"text": "milk"}]`, TextList{"flour"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list TextList
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &list))
+			assert.Equal(t, tt.expected, list)
+		})
+	}
+
+	var list TextList
+	assert.Error(t, json.Unmarshal([]byte(`42`), &list))
+}
+
+// TestParseISODuration tests parsing ISO 8601 durations
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"PT1H30M", 90 * time.Minute, true},
+		{"PT45M", 45 * time.Minute, true},
+		{"pt20s", 20 * time.Second, true},
+		{"P1DT2H", 26 * time.Hour, true},
+		{"PT1.5S", 1500 * time.Millisecond, true},
+		{"P", 0, false},
+		{"PT", 0, false},
+		{"P1Y", 0, false},
+		{"90 minutes", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, ok := ParseISODuration(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+
+	assert.Equal(t, 45*time.Minute, parseRecipeTime("45 min"))
+	assert.Equal(t, 45*time.Minute, parseRecipeTime("45 minutes"))
+	assert.Equal(t, time.Duration(0), parseRecipeTime("about an hour"))
+}
@@ -0,0 +1,127 @@
+package bravesearch
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cnosuke/go-brave-search/internal/hostutil"
+)
+
+// VideoResult represents a video search result
+type VideoResult struct {
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Age         string     `json:"age,omitempty"`
+	PageAge     string     `json:"page_age,omitempty"`
+	PageFetched string     `json:"page_fetched,omitempty"`
+	Video       *VideoData `json:"video,omitempty"`
+	MetaURL     *MetaURL   `json:"meta_url,omitempty"`
+	Thumbnail   *Thumbnail `json:"thumbnail,omitempty"`
+
+	// SourceScore is set by the configured SourceRater
+	SourceScore *SourceScore `json:"-"`
+
+	// Flagged is set by the configured URLChecker to the reason the URL was flagged
+	Flagged string `json:"-"`
+
+	// Provenance records the request that produced the result
+	Provenance *Provenance `json:"-"`
+}
+
+// VideoData represents the video-specific fields of a video result
+type VideoData struct {
+	// Duration is the length of the video, e.g. "05:13" or "1:02:45"
+	Duration             string   `json:"duration,omitempty"`
+	Views                int64    `json:"views,omitempty"`
+	Creator              string   `json:"creator,omitempty"`
+	Publisher            string   `json:"publisher,omitempty"`
+	RequiresSubscription bool     `json:"requires_subscription,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	Author               *Profile `json:"author,omitempty"`
+}
+
+// Video ID formats of the known embed providers
+var (
+	youTubeIDPattern     = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoIDPattern       = regexp.MustCompile(`^[0-9]{1,12}$`)
+	dailymotionIDPattern = regexp.MustCompile(`^x[a-z0-9]{1,12}$`)
+)
+
+// EmbedURL returns a URL for embedding the video in an iframe, if it is hosted
+// by a known provider (YouTube, Vimeo or Dailymotion). The URL is rebuilt from
+// the validated video ID rather than taken from the result, so it always
+// points at the provider's player. YouTube videos use the privacy-enhanced
+// youtube-nocookie.com player.
+func (v *VideoResult) EmbedURL() (string, bool) {
+	u, err := url.Parse(v.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", false
+	}
+
+	host := hostutil.WithoutWWW(u.Hostname())
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		id := u.Query().Get("v")
+		if len(segments) == 2 && (segments[0] == "embed" || segments[0] == "shorts" || segments[0] == "live") {
+			id = segments[1]
+		}
+		return youTubeEmbedURL(id)
+	case "youtu.be":
+		if len(segments) == 1 {
+			return youTubeEmbedURL(segments[0])
+		}
+	case "vimeo.com":
+		if len(segments) == 1 && vimeoIDPattern.MatchString(segments[0]) {
+			return "https://player.vimeo.com/video/" + segments[0], true
+		}
+	case "dailymotion.com":
+		if len(segments) == 2 && segments[0] == "video" {
+			return dailymotionEmbedURL(segments[1])
+		}
+	case "dai.ly":
+		if len(segments) == 1 {
+			return dailymotionEmbedURL(segments[0])
+		}
+	}
+
+	return "", false
+}
+
+// EmbedHTML returns an iframe embedding the video, if it is hosted by a known
+// provider. All attribute values are HTML-escaped.
+func (v *VideoResult) EmbedHTML() (string, bool) {
+	embedURL, ok := v.EmbedURL()
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf(`<iframe src="%s" title="%s" width="560" height="315" frameborder="0" `+
+		`allow="accelerometer; encrypted-media; gyroscope; picture-in-picture; fullscreen" `+
+		`referrerpolicy="strict-origin-when-cross-origin" loading="lazy" allowfullscreen></iframe>`,
+		html.EscapeString(embedURL), html.EscapeString(v.Title)), true
+}
+
+// youTubeEmbedURL returns the privacy-enhanced embed URL for a YouTube video ID
+func youTubeEmbedURL(id string) (string, bool) {
+	if !youTubeIDPattern.MatchString(id) {
+		return "", false
+	}
+	return "https://www.youtube-nocookie.com/embed/" + id, true
+}
+
+// dailymotionEmbedURL returns the embed URL for a Dailymotion video ID
+func dailymotionEmbedURL(id string) (string, bool) {
+	// Older video pages append a title slug, e.g. "x8abcd1_some-title"
+	id, _, _ = strings.Cut(id, "_")
+	if !dailymotionIDPattern.MatchString(id) {
+		return "", false
+	}
+	return "https://www.dailymotion.com/embed/video/" + id, true
+}
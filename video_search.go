@@ -0,0 +1,73 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+)
+
+// VideoSearchParams holds the parameters for a video search request
+type VideoSearchParams struct {
+	Country    string `url:"country,omitempty"`
+	SearchLang string `url:"search_lang,omitempty"`
+	UILang     string `url:"ui_lang,omitempty"`
+
+	// Count is the number of videos, at most MaxVideoCount
+	Count int `url:"count,omitempty"`
+
+	// Offset is the page of videos to return, at most MaxOffset
+	Offset int `url:"offset,omitempty"`
+
+	SafeSearch string `url:"safesearch,omitempty"`
+
+	// Freshness limits how recently videos were discovered, e.g.
+	// FreshnessWeek or a range from FreshnessRange
+	Freshness string `url:"freshness,omitempty"`
+
+	Spellcheck *bool `url:"spellcheck,omitempty"` // nil leaves the API default; see Bool
+
+	// Extra holds query parameters the library does not model yet. Keys
+	// managed by the library are rejected with ErrInvalidParameters.
+	Extra url.Values `url:"-"`
+}
+
+// VideoSearchResponse represents the response from the Video Search API
+type VideoSearchResponse struct {
+	Type    string        `json:"type"`
+	Query   *Query        `json:"query,omitempty"`
+	Results []VideoResult `json:"results"`
+}
+
+// VideoSearch searches videos for query with the Video Search API, which
+// returns more videos than the video section of a web search. The
+// configured SourceRater and URLChecker apply to the videos as they do to
+// web search results.
+func (c *Client) VideoSearch(ctx context.Context, query string, params *VideoSearchParams) (*VideoSearchResponse, error) {
+	videoParams := &VideoSearchParams{}
+	if params != nil {
+		*videoParams = *params
+	}
+
+	var response VideoSearchResponse
+	query, err := c.verticalSearch(ctx, query, verticalRequest{
+		endpoint:     EndpointVideoSearch,
+		params:       videoParams,
+		extra:        videoParams.Extra,
+		country:      &videoParams.Country,
+		searchLang:   &videoParams.SearchLang,
+		uiLang:       &videoParams.UILang,
+		count:        &videoParams.Count,
+		defaultCount: DefaultVideoCount,
+		maxCount:     MaxVideoCount,
+		offset:       &videoParams.Offset,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Annotate the videos as the video section of a web search
+	view := &WebSearchResponse{Videos: &Videos{Results: response.Results}}
+	c.annotateVertical(ctx, view, query, videoParams.Offset, videoParams.Count)
+	response.Results = view.Videos.Results
+
+	return &response, nil
+}
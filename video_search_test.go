@@ -0,0 +1,86 @@
+package bravesearch
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVideoSearch tests the Video Search API
+func TestVideoSearch(t *testing.T) {
+	server, query := setupVerticalSearchServer(t, EndpointVideoSearch, "video_search_response.json")
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"))
+	require.NoError(t, err)
+
+	response, err := client.VideoSearch(context.Background(), "go concurrency", &VideoSearchParams{
+		Count:      10,
+		SafeSearch: SafeSearchStrict,
+		Freshness:  FreshnessYear,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "videos", response.Type)
+	require.NotNil(t, response.Query)
+	assert.Equal(t, "go concurrency", response.Query.Original)
+	require.Len(t, response.Results, 2)
+
+	video := response.Results[0]
+	assert.Equal(t, "Concurrency is not Parallelism", video.Title)
+	require.NotNil(t, video.Video)
+	assert.Equal(t, "31:34", video.Video.Duration)
+	assert.Equal(t, int64(1200000), video.Video.Views)
+	assert.Equal(t, "Rob Pike", video.Video.Creator)
+	assert.Equal(t, "YouTube", video.Video.Publisher)
+	assert.Equal(t, "https://imgs.search.brave.com/pike-thumb.jpg", video.Thumbnail.Src)
+	require.NotNil(t, video.Provenance)
+	assert.Equal(t, "go concurrency", video.Provenance.Query)
+
+	embed, ok := video.EmbedURL()
+	assert.True(t, ok)
+	assert.Contains(t, embed, "oV9rvDllKEg")
+
+	assert.Equal(t, "go concurrency", query.Get("q"))
+	assert.Equal(t, DefaultCountry, query.Get("country"))
+	assert.Equal(t, "10", query.Get("count"))
+	assert.Equal(t, SafeSearchStrict, query.Get("safesearch"))
+	assert.Equal(t, FreshnessYear, query.Get("freshness"))
+}
+
+// TestVideoSearchSourceRater tests rating videos like web results
+func TestVideoSearchSourceRater(t *testing.T) {
+	server, _ := setupVerticalSearchServer(t, EndpointVideoSearch, "video_search_response.json")
+
+	rater := SourceRaterFunc(func(hostname string) *SourceScore {
+		return &SourceScore{Score: 0.5, Label: hostname}
+	})
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL+"/res/v1"), WithSourceRater(rater))
+	require.NoError(t, err)
+
+	response, err := client.VideoSearch(context.Background(), "go concurrency", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "www.youtube.com", response.Results[0].SourceScore.Label)
+	assert.Equal(t, "vimeo.com", response.Results[1].SourceScore.Label)
+}
+
+// TestVideoSearchInvalid tests rejecting invalid video search parameters
+func TestVideoSearchInvalid(t *testing.T) {
+	client, err := NewClient("test-api-key")
+	require.NoError(t, err)
+
+	_, err = client.VideoSearch(context.Background(), "", nil)
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+
+	_, err = client.VideoSearch(context.Background(), "go", &VideoSearchParams{Count: MaxVideoCount + 1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.VideoSearch(context.Background(), "go", &VideoSearchParams{Offset: -1})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+
+	_, err = client.VideoSearch(context.Background(), "go", &VideoSearchParams{Extra: url.Values{"q": {"other"}}})
+	assert.ErrorIs(t, err, ErrInvalidParameters)
+}
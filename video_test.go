@@ -0,0 +1,107 @@
+package bravesearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVideoResultUnmarshal tests decoding video results
+func TestVideoResultUnmarshal(t *testing.T) {
+	data := `{
+		"type": "videos",
+		"results": [{
+			"type": "video_result",
+			"url": "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+			"title": "Go in 100 Seconds",
+			"age": "2 years ago",
+			"video": {
+				"duration": "02:21",
+				"views": 1500000,
+				"creator": "Fireship",
+				"publisher": "YouTube",
+				"requires_subscription": false,
+				"tags": ["go", "programming"],
+				"author": {"name": "Fireship", "url": "https://www.youtube.com/@Fireship"}
+			},
+			"thumbnail": {"src": "https://imgs.search.brave.com/thumb.jpg"}
+		}]
+	}`
+
+	var videos Videos
+	require.NoError(t, json.Unmarshal([]byte(data), &videos))
+	require.Len(t, videos.Results, 1)
+
+	result := videos.Results[0]
+	assert.Equal(t, "Go in 100 Seconds", result.Title)
+	require.NotNil(t, result.Video)
+	assert.Equal(t, "02:21", result.Video.Duration)
+	assert.Equal(t, int64(1500000), result.Video.Views)
+	assert.Equal(t, "YouTube", result.Video.Publisher)
+	assert.False(t, result.Video.RequiresSubscription)
+	assert.Equal(t, []string{"go", "programming"}, result.Video.Tags)
+	require.NotNil(t, result.Video.Author)
+	assert.Equal(t, "Fireship", result.Video.Author.Name)
+}
+
+// TestVideoResultEmbedURL tests building embed URLs for known providers
+func TestVideoResultEmbedURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"youtube watch", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"youtube mobile", "https://m.youtube.com/watch?v=dQw4w9WgXcQ", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"youtube short link", "https://youtu.be/dQw4w9WgXcQ", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"youtube shorts", "https://www.youtube.com/shorts/dQw4w9WgXcQ", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"vimeo", "https://vimeo.com/76979871", "https://player.vimeo.com/video/76979871"},
+		{"dailymotion", "https://www.dailymotion.com/video/x8abcd1", "https://www.dailymotion.com/embed/video/x8abcd1"},
+		{"dailymotion slug", "https://www.dailymotion.com/video/x8abcd1_go-tutorial", "https://www.dailymotion.com/embed/video/x8abcd1"},
+		{"dailymotion short link", "https://dai.ly/x8abcd1", "https://www.dailymotion.com/embed/video/x8abcd1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := VideoResult{URL: tt.url}
+			embedURL, ok := result.EmbedURL()
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, embedURL)
+		})
+	}
+}
+
+// TestVideoResultEmbedURLRejected tests that unknown or malformed URLs are not embedded
+func TestVideoResultEmbedURLRejected(t *testing.T) {
+	for _, videoURL := range []string{
+		"",
+		"https://example.com/watch?v=dQw4w9WgXcQ",
+		"javascript:alert(1)//youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ\"onload=\"alert(1)",
+		"https://www.youtube.com/channel/UC123",
+		"https://vimeo.com/channels/staffpicks",
+		"https://youtube.com.evil.example/watch?v=dQw4w9WgXcQ",
+	} {
+		result := VideoResult{URL: videoURL}
+		_, ok := result.EmbedURL()
+		assert.False(t, ok, videoURL)
+	}
+}
+
+// TestVideoResultEmbedHTML tests generating an escaped iframe
+func TestVideoResultEmbedHTML(t *testing.T) {
+	result := VideoResult{
+		URL:   "https://youtu.be/dQw4w9WgXcQ",
+		Title: `Go "generics" <explained>`,
+	}
+
+	embedHTML, ok := result.EmbedHTML()
+	require.True(t, ok)
+	assert.Contains(t, embedHTML, `src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"`)
+	assert.Contains(t, embedHTML, `title="Go &#34;generics&#34; &lt;explained&gt;"`)
+
+	_, ok = (&VideoResult{URL: "https://example.com/video"}).EmbedHTML()
+	assert.False(t, ok)
+}
@@ -0,0 +1,118 @@
+package bravesearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// Warmup resolves the API hosts and opens a connection to each, including
+// the TLS handshake, so the first search of a cold-started process (e.g. a
+// serverless function) does not pay for them. The hosts are those of the base
+// URL and of endpoints overridden with absolute URLs on other hosts (see
+// WithEndpointOverride); they are warmed up concurrently and the connections
+// are kept in the idle pool of the HTTP client. Warmup sends an
+// unauthenticated HEAD request to the root of each host; any HTTP response,
+// whatever its status, means the connection is ready. The returned error
+// joins the failures of every host.
+func (c *Client) Warmup(ctx context.Context) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, origin := range c.origins() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.warmup(ctx, origin); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// warmup opens a connection to origin
+func (c *Client) warmup(ctx context.Context, origin string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, origin+"/", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(HeaderUserAgent, c.config.UserAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to warm up connection to %s: %w", origin, telemetryError(err))
+	}
+
+	// Drain the body so the connection is reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// origins returns the distinct origins (scheme and host) of the endpoints of
+// the client, starting with that of the base URL
+func (c *Client) origins() []string {
+	endpoints := slices.Sorted(maps.Keys(endpointPaths))
+	urls := []string{c.config.BaseURL}
+	for _, endpoint := range endpoints {
+		urls = append(urls, c.endpointURL(endpoint))
+	}
+
+	var origins []string
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		origin := u.Scheme + "://" + u.Host
+		if !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// Warmup warms up the client's connection (see Client.Warmup) and primes the
+// session cache with queries, e.g. the most popular queries of the service,
+// searched concurrently. Queries are not searched when the session cache is
+// disabled. The returned error joins the failures of every query; the others
+// are cached regardless.
+func (s *Session) Warmup(ctx context.Context, queries ...string) error {
+	if err := s.client.Warmup(ctx); err != nil {
+		return err
+	}
+	if s.cacheTTL <= 0 {
+		return nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, query := range queries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.WebSearch(ctx, query, nil); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("query %q: %w", query, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,108 @@
+package bravesearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// warmupResponse answers HEAD requests with 404 Not Found and searches with
+// a web result, failing searches for "broken"
+func warmupResponse(r *http.Request) mockResponse {
+	if r.Method == http.MethodHead {
+		return mockResponse{Status: http.StatusNotFound}
+	}
+	if r.URL.Query().Get("q") == "broken" {
+		return mockResponse{Status: http.StatusBadRequest}
+	}
+	return mockResponse{Body: `{"type": "search", "web": {"results": [{"title": "Go"}]}}`}
+}
+
+// TestWarmup tests that the warmed up connection is reused by the first search
+func TestWarmup(t *testing.T) {
+	server := newMockServer(t, warmupResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	require.NoError(t, client.Warmup(context.Background()))
+	assert.Equal(t, 1, server.requestCount(http.MethodHead))
+	assert.Equal(t, 1, server.connectionCount())
+	assert.Empty(t, server.lastRequest().Header.Get(HeaderSubscriptionToken))
+
+	_, err = client.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.connectionCount())
+}
+
+// TestWarmupEndpointHosts tests warming up the hosts of endpoints
+// overridden with absolute URLs
+func TestWarmupEndpointHosts(t *testing.T) {
+	server := newMockServer(t, warmupResponse)
+	suggestServer := newMockServer(t, warmupResponse)
+
+	client, err := NewClient("test-api-key",
+		WithBaseURL(server.URL+"/res/v1"),
+		WithEndpointOverride(EndpointSuggest, suggestServer.URL+"/suggest"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{server.URL, suggestServer.URL}, client.origins())
+
+	require.NoError(t, client.Warmup(context.Background()))
+	assert.Equal(t, 1, server.requestCount(http.MethodHead))
+	assert.Equal(t, 1, suggestServer.requestCount(http.MethodHead))
+
+	_, err = client.Suggest(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, suggestServer.connectionCount())
+}
+
+// TestWarmupUnreachable tests warming up against an unreachable host
+func TestWarmupUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	err = client.Warmup(context.Background())
+	assert.ErrorContains(t, err, "failed to warm up connection")
+}
+
+// TestSessionWarmup tests priming the session cache
+func TestSessionWarmup(t *testing.T) {
+	server := newMockServer(t, warmupResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithRetries(0))
+	require.NoError(t, err)
+	session, err := client.NewSession()
+	require.NoError(t, err)
+
+	err = session.Warmup(context.Background(), "golang", "rust", "broken")
+	assert.ErrorContains(t, err, `query "broken"`)
+	assert.Equal(t, 1, server.requestCount(http.MethodHead))
+	assert.Equal(t, 3, server.requestCount(http.MethodGet))
+
+	response, err := session.WebSearch(context.Background(), "golang", nil)
+	require.NoError(t, err)
+	assert.Equal(t, CacheHit, response.Provenance.Cache)
+	assert.Equal(t, 3, server.requestCount(http.MethodGet))
+}
+
+// TestSessionWarmupCacheDisabled tests that queries are not searched without a session cache
+func TestSessionWarmupCacheDisabled(t *testing.T) {
+	server := newMockServer(t, warmupResponse)
+
+	client, err := NewClient("test-api-key", WithBaseURL(server.URL), WithNoRetention(true))
+	require.NoError(t, err)
+	session, err := client.NewSession()
+	require.NoError(t, err)
+
+	require.NoError(t, session.Warmup(context.Background(), "golang"))
+	assert.Equal(t, 1, server.requestCount(http.MethodHead))
+	assert.Zero(t, server.requestCount(http.MethodGet))
+}
@@ -10,11 +10,17 @@ func NewWebSearchParams() *WebSearchParams {
 		Count:           DefaultCount,
 		Offset:          DefaultOffset,
 		SafeSearch:      DefaultSafeSearch,
-		TextDecorations: DefaultTextDecor,
-		Spellcheck:      DefaultSpellCheck,
+		TextDecorations: Bool(DefaultTextDecor),
+		Spellcheck:      Bool(DefaultSpellCheck),
 	}
 }
 
+// Bool returns a pointer to v, for optional boolean parameters such as
+// WebSearchParams.Spellcheck
+func Bool(v bool) *bool {
+	return &v
+}
+
 // WebSearchWithCountry performs a web search with a specific country
 func (c *Client) WebSearchWithCountry(ctx context.Context, query string, country string) (*WebSearchResponse, error) {
 	params := NewWebSearchParams()
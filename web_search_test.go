@@ -3,9 +3,14 @@ package bravesearch
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,6 +48,107 @@ func setupMockServer(t *testing.T) (*httptest.Server, *Client) {
 	return server, client
 }
 
+// mockResponse is the answer of a mock server to a request. Status is
+// http.StatusOK if 0, and a Body is sent as JSON.
+type mockResponse struct {
+	Status int
+	Header http.Header
+	Body   string
+}
+
+// mockServer is a configurable test server recording the requests it
+// receives and the connections opened to it
+type mockServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	requests    []*http.Request
+	connections int
+}
+
+// newMockServer starts a mock server answering requests with respond, or
+// with a web result titled "Go" if respond is nil. The server is closed when
+// the test ends.
+func newMockServer(t *testing.T, respond func(r *http.Request) mockResponse) *mockServer {
+	t.Helper()
+	if respond == nil {
+		respond = func(r *http.Request) mockResponse {
+			return mockResponse{Body: `{"type": "search", "web": {"results": [{"title": "Go"}]}}`}
+		}
+	}
+
+	server := &mockServer{}
+	server.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.requests = append(server.requests, r)
+		server.mu.Unlock()
+
+		response := respond(r)
+		maps.Copy(w.Header(), response.Header)
+		if response.Body != "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		if response.Status != 0 {
+			w.WriteHeader(response.Status)
+		}
+		_, _ = w.Write([]byte(response.Body))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			server.mu.Lock()
+			server.connections++
+			server.mu.Unlock()
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// receivedRequests returns the requests received so far
+func (s *mockServer) receivedRequests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...)
+}
+
+// requestCount returns the number of requests received with method, or of
+// all requests if method is empty
+func (s *mockServer) requestCount(method string) int {
+	count := 0
+	for _, r := range s.receivedRequests() {
+		if method == "" || r.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// lastRequest returns the last request received, nil if there was none
+func (s *mockServer) lastRequest() *http.Request {
+	requests := s.receivedRequests()
+	if len(requests) == 0 {
+		return nil
+	}
+	return requests[len(requests)-1]
+}
+
+// connectionCount returns the number of connections opened to the server
+func (s *mockServer) connectionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections
+}
+
+// mockWebResults returns a web search response body with a result for each of urls
+func mockWebResults(urls ...string) string {
+	results := make([]string, len(urls))
+	for i, u := range urls {
+		results[i] = fmt.Sprintf(`{"title": "Result", "url": %q}`, u)
+	}
+	return `{"type": "search", "web": {"results": [` + strings.Join(results, ",") + `]}}`
+}
+
 // TestNewWebSearchParams tests the creation of default search parameters
 func TestNewWebSearchParams(t *testing.T) {
 	params := NewWebSearchParams()
@@ -50,8 +156,8 @@ func TestNewWebSearchParams(t *testing.T) {
 	assert.Equal(t, DefaultCount, params.Count)
 	assert.Equal(t, DefaultOffset, params.Offset)
 	assert.Equal(t, DefaultSafeSearch, params.SafeSearch)
-	assert.Equal(t, DefaultTextDecor, params.TextDecorations)
-	assert.Equal(t, DefaultSpellCheck, params.Spellcheck)
+	assert.Equal(t, Bool(DefaultTextDecor), params.TextDecorations)
+	assert.Equal(t, Bool(DefaultSpellCheck), params.Spellcheck)
 }
 
 // TestWebSearchWithCountry tests the search with country helper function